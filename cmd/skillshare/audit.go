@@ -90,6 +90,7 @@ func cmdAudit(args []string) error {
 
 	var (
 		sourcePath       string
+		ignore           []string
 		projectRoot      string
 		defaultThreshold string
 		cfgPath          string
@@ -109,15 +110,17 @@ func cmdAudit(args []string) error {
 			return err
 		}
 		sourcePath = rt.sourcePath
+		ignore = rt.config.Ignore
 		projectRoot = cwd
 		defaultThreshold = rt.config.Audit.BlockThreshold
 		cfgPath = config.ProjectConfigPath(cwd)
 	} else {
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		sourcePath = cfg.Source
+		ignore = cfg.Ignore
 		defaultThreshold = cfg.Audit.BlockThreshold
 		cfgPath = config.ConfigPath()
 	}
@@ -138,7 +141,7 @@ func cmdAudit(args []string) error {
 
 	switch {
 	case opts.Target == "":
-		results, summary, err = auditInstalled(sourcePath, modeString(mode), projectRoot, threshold, opts.JSON)
+		results, summary, err = auditInstalled(sourcePath, ignore, modeString(mode), projectRoot, threshold, opts.JSON)
 	case pathExists(opts.Target):
 		results, summary, err = auditPath(opts.Target, modeString(mode), projectRoot, threshold, opts.JSON)
 	default:
@@ -224,11 +227,11 @@ func auditHeaderSubtitle(scanLine, mode, sourcePath string) string {
 	return fmt.Sprintf("%s\nmode: %s\npath: %s", scanLine, mode, displayPath)
 }
 
-func collectInstalledSkillPaths(sourcePath string) ([]struct {
+func collectInstalledSkillPaths(sourcePath string, ignore []string) ([]struct {
 	name string
 	path string
 }, error) {
-	discovered, err := sync.DiscoverSourceSkills(sourcePath)
+	discovered, err := sync.DiscoverSourceSkills(sourcePath, ignore...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover skills: %w", err)
 	}
@@ -249,11 +252,15 @@ func collectInstalledSkillPaths(sourcePath string) ([]struct {
 		}{d.FlatName, d.SourcePath})
 	}
 
+	matcher := sync.NewIgnoreMatcher(sourcePath, ignore)
 	entries, _ := os.ReadDir(sourcePath)
 	for _, e := range entries {
 		if !e.IsDir() || utils.IsHidden(e.Name()) {
 			continue
 		}
+		if ignored, _ := matcher.Match(e.Name()); ignored {
+			continue
+		}
 		p := filepath.Join(sourcePath, e.Name())
 		if !seen[p] {
 			seen[p] = true
@@ -288,14 +295,14 @@ func scanPathTarget(targetPath, projectRoot string) (*audit.Result, error) {
 	return audit.ScanFile(targetPath)
 }
 
-func auditInstalled(sourcePath, mode, projectRoot, threshold string, jsonOutput bool) ([]*audit.Result, auditRunSummary, error) {
+func auditInstalled(sourcePath string, ignore []string, mode, projectRoot, threshold string, jsonOutput bool) ([]*audit.Result, auditRunSummary, error) {
 	base := auditRunSummary{
 		Scope:     "all",
 		Mode:      mode,
 		Threshold: threshold,
 	}
 
-	skillPaths, err := collectInstalledSkillPaths(sourcePath)
+	skillPaths, err := collectInstalledSkillPaths(sourcePath, ignore)
 	if err != nil {
 		return nil, base, err
 	}
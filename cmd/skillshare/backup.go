@@ -66,7 +66,7 @@ func cmdBackup(args []string) error {
 }
 
 func createBackup(targetName string, dryRun bool) error {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
@@ -310,7 +310,7 @@ func cmdRestore(args []string) error {
 		}
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
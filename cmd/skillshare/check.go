@@ -19,6 +19,7 @@ type checkRepoResult struct {
 	Status  string `json:"status"` // "up_to_date", "behind", "dirty", "error"
 	Behind  int    `json:"behind"`
 	Message string `json:"message,omitempty"`
+	Ref     string `json:"ref,omitempty"` // Pinned branch, tag or commit
 }
 
 // checkSkillResult holds the check result for a regular skill
@@ -26,14 +27,16 @@ type checkSkillResult struct {
 	Name        string `json:"name"`
 	Source      string `json:"source"`
 	Version     string `json:"version"`
-	Status      string `json:"status"` // "up_to_date", "update_available", "local", "error"
+	Status      string `json:"status"` // "up_to_date", "update_available", "pinned", "local", "error"
 	InstalledAt string `json:"installed_at,omitempty"`
+	Ref         string `json:"ref,omitempty"` // Pinned branch, tag or commit
 }
 
 // checkOutput is the JSON output structure
 type checkOutput struct {
-	TrackedRepos []checkRepoResult  `json:"tracked_repos"`
-	Skills       []checkSkillResult `json:"skills"`
+	TrackedRepos []checkRepoResult   `json:"tracked_repos"`
+	Skills       []checkSkillResult  `json:"skills"`
+	LockDrift    []install.LockDrift `json:"lock_drift,omitempty"` // Only when a skillshare.lock exists
 }
 
 func cmdCheck(args []string) error {
@@ -76,15 +79,15 @@ func cmdCheck(args []string) error {
 		return cmdCheckProject(cwd, jsonOutput)
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
-	return runCheck(cfg.Source, jsonOutput)
+	return runCheck(cfg.Source, config.LockfilePath(), jsonOutput)
 }
 
-func runCheck(sourceDir string, jsonOutput bool) error {
+func runCheck(sourceDir, lockPath string, jsonOutput bool) error {
 	repos, err := install.GetTrackedRepos(sourceDir)
 	if err != nil {
 		repos = nil // Non-fatal: source dir might not exist yet
@@ -95,7 +98,18 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 		skills = nil
 	}
 
-	if len(repos) == 0 && len(skills) == 0 {
+	lock, err := install.ReadLockfile(lockPath)
+	if err != nil {
+		return err
+	}
+	var drift []install.LockDrift
+	if lock != nil {
+		if drift, err = lock.Drift(sourceDir); err != nil {
+			return err
+		}
+	}
+
+	if len(repos) == 0 && len(skills) == 0 && len(drift) == 0 {
 		if jsonOutput {
 			out, _ := json.MarshalIndent(checkOutput{
 				TrackedRepos: []checkRepoResult{},
@@ -137,9 +151,9 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 			for _, r := range repoResults {
 				switch r.Status {
 				case "up_to_date":
-					ui.ListItem("success", r.Name, "up to date")
+					ui.ListItem("success", r.Name, "up to date"+pinnedSuffix(r.Ref))
 				case "behind":
-					ui.ListItem("info", r.Name, fmt.Sprintf("%d commit(s) behind", r.Behind))
+					ui.ListItem("info", r.Name, fmt.Sprintf("%d commit(s) behind%s", r.Behind, pinnedSuffix(r.Ref)))
 				case "dirty":
 					ui.ListItem("warning", r.Name, "has uncommitted changes")
 				case "error":
@@ -184,6 +198,12 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 						detail += fmt.Sprintf("  %s", formatSourceShort(s.Source))
 					}
 					ui.ListItem("info", s.Name, detail)
+				case "pinned":
+					detail := fmt.Sprintf("pinned to %s", s.Ref)
+					if s.Source != "" {
+						detail += fmt.Sprintf("  %s", formatSourceShort(s.Source))
+					}
+					ui.ListItem("success", s.Name, detail)
 				case "local":
 					ui.ListItem("info", s.Name, "local source")
 				case "error":
@@ -204,6 +224,12 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 			TrackedRepos: repoResults,
 			Skills:       skillResults,
 		}
+		if lock != nil {
+			output.LockDrift = drift
+			if output.LockDrift == nil {
+				output.LockDrift = []install.LockDrift{}
+			}
+		}
 		if output.TrackedRepos == nil {
 			output.TrackedRepos = []checkRepoResult{}
 		}
@@ -244,11 +270,42 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 		ui.Info("Run 'skillshare update <name>' or 'skillshare update --all'")
 	}
 
+	if len(drift) > 0 {
+		printLockDrift(drift, lockPath)
+	}
+
 	return nil
 }
 
+// printLockDrift lists skills that no longer match skillshare.lock
+func printLockDrift(drift []install.LockDrift, lockPath string) {
+	fmt.Println()
+	ui.Warning("%d item(s) differ from %s", len(drift), filepath.Base(lockPath))
+	for _, d := range drift {
+		switch d.Status {
+		case "missing":
+			ui.ListItem("warning", d.Name, "locked but not installed")
+		case "unlocked":
+			ui.ListItem("info", d.Name, "not in lock")
+		case "commit_changed":
+			ui.ListItem("warning", d.Name, "commit differs from lock")
+		case "modified":
+			ui.ListItem("warning", d.Name, "files differ from lock")
+		}
+	}
+	ui.Info("Run 'skillshare install --frozen' to install missing locked skills, or 'skillshare install --update-lock' to record the current state")
+}
+
+// pinnedSuffix notes the ref a tracked repo is pinned to
+func pinnedSuffix(ref string) string {
+	if ref == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (pinned to %s)", ref)
+}
+
 func checkTrackedRepo(name, repoPath string) checkRepoResult {
-	result := checkRepoResult{Name: name}
+	result := checkRepoResult{Name: name, Ref: git.PinnedRef(repoPath)}
 
 	// Check for uncommitted changes
 	if isDirty, _ := git.IsDirty(repoPath); isDirty {
@@ -257,7 +314,7 @@ func checkTrackedRepo(name, repoPath string) checkRepoResult {
 		return result
 	}
 
-	// Fetch and compare
+	// Fetch and compare (against the pin when there is one)
 	behind, err := git.GetBehindCount(repoPath)
 	if err != nil {
 		result.Status = "error"
@@ -290,12 +347,40 @@ func checkRegularSkill(name, skillPath string) checkSkillResult {
 		result.InstalledAt = meta.InstalledAt.Format("2006-01-02")
 	}
 
+	// Archives compare the digest of the artifact now at their URL
+	if meta.URL != "" {
+		result.Version = meta.Digest
+		status, pin, err := install.CheckArchive(meta)
+		if err != nil {
+			result.Status = "error"
+			return result
+		}
+		result.Status = status
+		result.Ref = pin
+		return result
+	}
+
 	// If no repo URL, it's a local source
 	if meta.RepoURL == "" {
 		result.Status = "local"
 		return result
 	}
 
+	// A pin only has an update when its branch or tag moved
+	if meta.Ref != "" {
+		result.Ref = meta.Ref
+		moved, err := git.RefMoved(meta.RepoURL, meta.Ref, meta.Version)
+		switch {
+		case err != nil:
+			result.Status = "error"
+		case moved:
+			result.Status = "update_available"
+		default:
+			result.Status = "pinned"
+		}
+		return result
+	}
+
 	// Compare with remote
 	remoteHash, err := git.GetRemoteHeadHash(meta.RepoURL)
 	if err != nil {
@@ -328,6 +413,9 @@ Check for available updates to tracked repositories and installed skills.
 
 For tracked repos: fetches from origin and checks if behind
 For regular skills: compares installed version with remote HEAD
+For pinned skills (@ref): reports an update only when the branch or tag moved
+For archives: compares the sha256 of the archive at its URL with the installed one
+With a skillshare.lock: lists skills whose commit or files differ from the lock
 
 Options:
   --project, -p  Check project-level skills (.skillshare/)
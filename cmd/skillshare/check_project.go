@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"skillshare/internal/config"
 )
 
 func cmdCheckProject(root string, jsonOutput bool) error {
@@ -16,5 +18,5 @@ func cmdCheckProject(root string, jsonOutput bool) error {
 		return fmt.Errorf("no project skills directory found")
 	}
 
-	return runCheck(sourcePath, jsonOutput)
+	return runCheck(sourcePath, config.ProjectLockfilePath(root), jsonOutput)
 }
@@ -70,9 +70,10 @@ func cmdCollect(args []string) error {
 	dryRun := false
 	force := false
 	collectAll := false
-	var targetName string
+	var targetName, layer string
 
-	for _, arg := range rest {
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
 		switch arg {
 		case "--dry-run", "-n":
 			dryRun = true
@@ -80,6 +81,12 @@ func cmdCollect(args []string) error {
 			force = true
 		case "--all", "-a":
 			collectAll = true
+		case "--layer":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--layer requires a value")
+			}
+			i++
+			layer = rest[i]
 		default:
 			if targetName == "" && !strings.HasPrefix(arg, "-") {
 				targetName = arg
@@ -87,10 +94,15 @@ func cmdCollect(args []string) error {
 		}
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
+	if layer != "" {
+		if cfg.Source, err = cfg.ResolveLayer(layer); err != nil {
+			return err
+		}
+	}
 
 	// Select targets to collect from
 	targets, err := selectCollectTargets(cfg, targetName, collectAll)
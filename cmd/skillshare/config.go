@@ -0,0 +1,455 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"skillshare/internal/config"
+	"skillshare/internal/ui"
+)
+
+func cmdConfig(args []string) error {
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+
+	applyModeLabel(mode)
+
+	if len(rest) == 0 {
+		printConfigHelp()
+		return nil
+	}
+
+	sub := rest[0]
+	subArgs := rest[1:]
+
+	switch sub {
+	case "migrate":
+		return configMigrate(mode, cwd, subArgs)
+	case "get":
+		return configGet(mode, cwd, subArgs)
+	case "set":
+		return configSet(mode, cwd, subArgs)
+	case "unset":
+		return configUnset(mode, cwd, subArgs)
+	case "validate":
+		return configValidate(mode, cwd, subArgs)
+	case "schema":
+		return configSchema(mode, subArgs)
+	case "--help", "-h", "help":
+		printConfigHelp()
+		return nil
+	default:
+		printConfigHelp()
+		return fmt.Errorf("unknown subcommand: %s", sub)
+	}
+}
+
+func configMigrate(mode runMode, cwd string, args []string) error {
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		case "--help", "-h":
+			printConfigHelp()
+			return nil
+		default:
+			return fmt.Errorf("unknown option: %s", arg)
+		}
+	}
+
+	var (
+		result *config.MigrationResult
+		err    error
+	)
+	if mode == modeProject {
+		if !projectConfigExists(cwd) {
+			return fmt.Errorf("no project config found; run 'skillshare init -p' first")
+		}
+		result, err = config.MigrateProjectConfigFile(cwd, dryRun)
+	} else {
+		result, err = config.MigrateConfigFile(config.BaseConfigPath(), dryRun)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("config not found: run 'skillshare init' first")
+		}
+		return err
+	}
+
+	ui.Header("Config migration")
+	if !result.Pending() {
+		ui.Success("%s is up to date (version %d)", result.Path, result.ToVersion)
+		return nil
+	}
+
+	for _, step := range result.Steps {
+		ui.Info("%s", step)
+	}
+	fmt.Println()
+	printLineDiff(string(result.Before), string(result.After))
+	fmt.Println()
+
+	if dryRun {
+		ui.Warning("[dry-run] Would migrate %s from version %d to %d", result.Path, result.FromVersion, result.ToVersion)
+		return nil
+	}
+
+	ui.Success("Migrated %s from version %d to %d", result.Path, result.FromVersion, result.ToVersion)
+	ui.Info("Previous file saved to %s", result.BackupPath)
+	return nil
+}
+
+// openConfigDocument opens config.yaml (or the project config) for dotted-key edits
+func openConfigDocument(mode runMode, cwd string) (*config.Document, error) {
+	var (
+		doc *config.Document
+		err error
+	)
+	if mode == modeProject {
+		if !projectConfigExists(cwd) {
+			return nil, fmt.Errorf("no project config found; run 'skillshare init -p' first")
+		}
+		doc, err = config.LoadProjectDocument(cwd)
+	} else {
+		doc, err = config.LoadDocument(config.BaseConfigPath())
+	}
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("config not found: run 'skillshare init' first")
+	}
+	return doc, err
+}
+
+// configLayer is one document a key is read from. An inline profile is
+// addressed under its entry in config.yaml.
+type configLayer struct {
+	doc    *config.Document
+	prefix string // "profiles.<name>." for an inline profile
+}
+
+// profileReplaced lists the profile fields that replace the base value as a
+// whole rather than key by key
+var profileReplaced = map[string]bool{"sources": true, "targets": true, "ignore": true}
+
+// openConfigLayers returns the documents key is read from, highest precedence
+// first; set and unset edit the first. With a profile active, keys a profile
+// can override resolve to its overlay, where Config.Save writes them:
+// profiles/<name>.yaml, else its entry under profiles: in config.yaml.
+func openConfigLayers(mode runMode, cwd, key string) ([]configLayer, error) {
+	base, err := openConfigDocument(mode, cwd)
+	if err != nil {
+		return nil, err
+	}
+	name := config.ActiveProfile()
+	if mode == modeProject || name == "" || !config.ProfileOverrides(key) {
+		return []configLayer{{doc: base}}, nil
+	}
+
+	var layers []configLayer
+	file, err := config.LoadProfileDocument(name)
+	switch {
+	case err == nil:
+		layers = append(layers, configLayer{doc: file})
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+	if inline, _ := base.Get("profiles." + name); inline != nil {
+		layers = append(layers, configLayer{doc: base, prefix: "profiles." + name + "."})
+	}
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("profile %q not found (define it under profiles: in config.yaml or create %s)", name, config.ProfilePath(name))
+	}
+	return append(layers, configLayer{doc: base}), nil
+}
+
+// lookupEffective returns the value of key as the active profile sees it
+func lookupEffective(layers []configLayer, key string) (*yaml.Node, error) {
+	top, _, _ := strings.Cut(key, ".")
+	for _, layer := range layers {
+		node, err := layer.doc.Get(layer.prefix + key)
+		if err != nil || node != nil {
+			return node, err
+		}
+		// An overlay that sets a replaced field hides the base value
+		if profileReplaced[top] {
+			if owner, _ := layer.doc.Get(layer.prefix + top); owner != nil {
+				return nil, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// editedIn names the file an edit went to while a profile is active
+func editedIn(layer configLayer) string {
+	if config.ActiveProfile() == "" {
+		return ""
+	}
+	return " in " + layer.doc.Path
+}
+
+func configGet(mode runMode, cwd string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: skillshare config get <key>")
+	}
+	layers, err := openConfigLayers(mode, cwd, args[0])
+	if err != nil {
+		return err
+	}
+
+	node, err := lookupEffective(layers, args[0])
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("%s is not set", args[0])
+	}
+	if node.Kind == yaml.ScalarNode {
+		fmt.Println(node.Value)
+		return nil
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
+
+func configSet(mode runMode, cwd string, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: skillshare config set <key> <value>")
+	}
+	key, value := args[0], args[1]
+
+	layers, err := openConfigLayers(mode, cwd, key)
+	if err != nil {
+		return err
+	}
+	layer := layers[0]
+	if err := layer.doc.Set(layer.prefix+key, value); err != nil {
+		return err
+	}
+	if err := rejectFieldErrors(layer.doc, layer.prefix+key); err != nil {
+		return err
+	}
+	if err := layer.doc.Save(); err != nil {
+		return err
+	}
+
+	ui.Success("Set %s = %s%s", key, value, editedIn(layer))
+	return nil
+}
+
+func configUnset(mode runMode, cwd string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: skillshare config unset <key>")
+	}
+	key := args[0]
+
+	layers, err := openConfigLayers(mode, cwd, key)
+	if err != nil {
+		return err
+	}
+	layer := layers[0]
+	found, err := layer.doc.Unset(layer.prefix + key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		ui.Info("%s is not set%s", key, editedIn(layer))
+		return nil
+	}
+	if err := rejectFieldErrors(layer.doc, layer.prefix+key); err != nil {
+		return err
+	}
+	if err := layer.doc.Save(); err != nil {
+		return err
+	}
+
+	ui.Success("Unset %s%s", key, editedIn(layer))
+	return nil
+}
+
+// rejectFieldErrors fails when the edit of key leaves errors at, under or
+// directly above key. Unrelated problems elsewhere in the file don't block it.
+func rejectFieldErrors(doc *config.Document, key string) error {
+	var related []config.FieldError
+	for _, fe := range doc.Validate() {
+		if fe.Field == key || strings.HasPrefix(fe.Field, key+".") ||
+			fe.Field == "" || strings.HasPrefix(key, fe.Field+".") {
+			related = append(related, fe)
+		}
+	}
+	if len(related) == 0 {
+		return nil
+	}
+	for _, fe := range related {
+		ui.Error("%s", fe.Error())
+	}
+	return fmt.Errorf("not saved: %s would make the config invalid", key)
+}
+
+func configValidate(mode runMode, cwd string, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unknown option: %s", args[0])
+	}
+
+	type file struct {
+		path     string
+		validate func([]byte) []config.FieldError
+	}
+	var files []file
+	if mode == modeProject {
+		if !projectConfigExists(cwd) {
+			return fmt.Errorf("no project config found; run 'skillshare init -p' first")
+		}
+		files = append(files, file{config.ProjectConfigPath(cwd), config.ValidateProjectConfigData})
+	} else {
+		files = append(files, file{config.BaseConfigPath(), config.ValidateConfigData})
+		profiles, _ := filepath.Glob(filepath.Join(filepath.Dir(config.BaseConfigPath()), config.ProfilesDirName, "*.yaml"))
+		for _, p := range profiles {
+			files = append(files, file{p, config.ValidateProfileData})
+		}
+	}
+
+	total := 0
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("config not found: run 'skillshare init' first")
+			}
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+
+		errs := f.validate(data)
+		if len(errs) == 0 {
+			ui.Success("%s is valid", f.path)
+			continue
+		}
+		ui.Error("%s: %d error(s)", f.path, len(errs))
+		for _, fe := range errs {
+			if fe.Line > 0 {
+				fmt.Printf("  line %-4d %s\n", fe.Line, fe.Error())
+			} else {
+				fmt.Printf("  %s\n", fe.Error())
+			}
+		}
+		total += len(errs)
+	}
+
+	if total > 0 {
+		return fmt.Errorf("config has %d error(s)", total)
+	}
+	return nil
+}
+
+func configSchema(mode runMode, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unknown option: %s", args[0])
+	}
+	schema := config.ConfigSchema()
+	if mode == modeProject {
+		schema = config.ProjectConfigSchema()
+	}
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// printLineDiff prints the lines removed from and added to before, in order
+func printLineDiff(before, after string) {
+	a := strings.Split(strings.TrimRight(before, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(after, "\n"), "\n")
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			fmt.Printf("  %s+ %s%s\n", ui.Green, b[j], ui.Reset)
+			j++
+		default:
+			fmt.Printf("  %s- %s%s\n", ui.Red, a[i], ui.Reset)
+			i++
+		}
+	}
+}
+
+func printConfigHelp() {
+	fmt.Println(`Usage: skillshare config <subcommand> [options]
+
+Inspect and maintain the skillshare config file.
+
+Subcommands:
+  get <key>           Print a value by dotted key (e.g. targets.claude.mode)
+  set <key> <value>   Set a value; lists accept a,b or [a, b]
+  unset <key>         Remove a key
+  validate            Check for unknown keys, bad modes, paths and patterns
+  schema              Print the JSON Schema of the config file
+  migrate             Upgrade the config to the current schema version
+                      (the previous file is kept as config.yaml.v<N>.bak)
+
+Keys address mappings by name and lists by index; project targets and
+skills can also be addressed by name (targets.claude-code.mode).
+With a profile active, get shows the value the profile sees, and set/unset
+edit the profile's overlay for the keys a profile can override.
+
+Options:
+  --dry-run, -n       Show pending migration steps and changes without writing
+  --project, -p       Use project config (.skillshare/config.yaml)
+  --global, -g        Use global config (~/.config/skillshare/config.yaml)
+  --help, -h          Show this help
+
+Older configs are migrated in memory whenever they are loaded; the file on
+disk is upgraded on the next save or by 'skillshare config migrate'.
+
+Examples:
+  skillshare config set targets.claude.mode copy
+  skillshare config get source
+  skillshare config unset targets.cursor.exclude
+  skillshare config validate
+  skillshare config schema > skillshare.schema.json
+  skillshare config migrate --dry-run
+  skillshare config migrate -p`)
+}
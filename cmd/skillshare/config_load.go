@@ -0,0 +1,36 @@
+package main
+
+import (
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+)
+
+// loadConfig loads the global config and declares its forges: hosts for
+// source parsing
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := install.SetForgeHosts(cfg.Forges); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadProjectConfig is loadConfig for a project's config. The global
+// config's forges still apply, overridden by the project's.
+func loadProjectConfig(root string) (*config.ProjectConfig, error) {
+	cfg, err := config.LoadProject(root)
+	if err != nil {
+		return nil, err
+	}
+	forges, err := config.ProjectForges(cfg.Forges)
+	if err != nil {
+		return nil, err
+	}
+	if err := install.SetForgeHosts(forges); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -3,42 +3,49 @@ package main
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"skillshare/internal/config"
 	"skillshare/internal/sync"
 	"skillshare/internal/ui"
-	"skillshare/internal/utils"
 )
 
 func cmdDiff(args []string) error {
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
 	var targetName string
-	for i := 0; i < len(args); i++ {
-		if args[i] == "--target" || args[i] == "-t" {
-			if i+1 < len(args) {
-				targetName = args[i+1]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == "--target" || rest[i] == "-t" {
+			if i+1 < len(rest) {
+				targetName = rest[i+1]
 				i++
 			}
 		} else {
-			targetName = args[i]
+			targetName = rest[i]
 		}
 	}
 
-	cfg, err := config.Load()
+	cwd, err := os.Getwd()
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot determine working directory: %w", err)
 	}
-
-	// Use same discovery as sync to get proper skill names (including tracked repo skills)
-	discovered, err := sync.DiscoverSourceSkills(cfg.Source)
-	if err != nil {
-		return fmt.Errorf("failed to discover skills: %w", err)
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+	if mode == modeProject {
+		return cmdDiffProject(cwd, targetName)
 	}
 
-	// Build map of flat names (what gets synced to targets)
-	sourceSkills := make(map[string]bool)
-	for _, skill := range discovered {
-		sourceSkills[skill.FlatName] = true
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
 
 	targets := cfg.Targets
@@ -50,98 +57,116 @@ func cmdDiff(args []string) error {
 		}
 	}
 
-	for name, target := range targets {
-		showTargetDiff(name, target, cfg.Source, sourceSkills)
+	// Diff shows exactly what a plain sync would do
+	plan := sync.NewPlan(targets, cfg.Mode, cfg.SourceLayers(), false, cfg.Ignore...)
+	for _, tp := range plan.Targets {
+		showTargetDiff(tp)
 	}
 
 	return nil
 }
 
-func showTargetDiff(name string, target config.TargetConfig, source string, sourceSkills map[string]bool) {
-	ui.Header(name)
-
-	// Check if target is a symlink (symlink mode)
-	_, err := os.Lstat(target.Path)
+func cmdDiffProject(root, targetName string) error {
+	runtime, err := loadProjectRuntime(root)
 	if err != nil {
-		ui.Warning("Cannot access target: %v", err)
-		return
+		return err
 	}
 
-	if utils.IsSymlinkOrJunction(target.Path) {
-		showSymlinkDiff(target.Path, source)
-		return
+	targets := make(map[string]config.TargetConfig)
+	for _, entry := range runtime.config.Targets {
+		if targetName != "" && entry.Name != targetName {
+			continue
+		}
+		target, ok := runtime.targets[entry.Name]
+		if !ok {
+			ui.Error("%s: target not found", entry.Name)
+			continue
+		}
+		targets[entry.Name] = target
 	}
-
-	// Merge mode - check individual skills
-	showMergeDiff(name, target.Path, source, sourceSkills)
-}
-
-func showSymlinkDiff(targetPath, source string) {
-	absLink, err := utils.ResolveLinkTarget(targetPath)
-	if err != nil {
-		ui.Warning("Unable to resolve symlink target: %v", err)
-		return
+	if targetName != "" && len(targets) == 0 {
+		return fmt.Errorf("target '%s' not found", targetName)
 	}
-	absSource, _ := filepath.Abs(source)
-	if utils.PathsEqual(absLink, absSource) {
-		ui.Success("Fully synced (symlink mode)")
-	} else {
-		ui.Warning("Symlink points to different location: %s", absLink)
+
+	plan := sync.NewPlan(targets, "merge", []string{runtime.sourcePath}, false, runtime.config.Ignore...)
+	for _, tp := range plan.Targets {
+		showTargetDiff(tp)
 	}
+	return nil
 }
 
-func showMergeDiff(targetName, targetPath, source string, sourceSkills map[string]bool) {
-	targetSkills := make(map[string]bool)
-	targetSymlinks := make(map[string]bool)
-	entries, err := os.ReadDir(targetPath)
-	if err != nil {
-		ui.Warning("Cannot read target: %v", err)
-		return
-	}
+func showTargetDiff(tp sync.TargetPlan) {
+	ui.Header(tp.Name)
 
-	for _, e := range entries {
-		if utils.IsHidden(e.Name()) {
-			continue
-		}
-		skillPath := filepath.Join(targetPath, e.Name())
-		if utils.IsSymlinkOrJunction(skillPath) {
-			targetSymlinks[e.Name()] = true
-		}
-		targetSkills[e.Name()] = true
+	if tp.Error != "" {
+		ui.Warning("%s", tp.Error)
+		return
 	}
 
-	// Compare and count
-	var syncCount, localCount int
-
-	// Skills only in source (not synced)
-	for skill := range sourceSkills {
-		if !targetSkills[skill] {
-			ui.DiffItem("add", skill, "missing")
+	var syncCount, forceCount, localCount int
+	for _, a := range tp.Actions {
+		switch a.Kind {
+		case sync.ActionCreateLink, sync.ActionCopy, sync.ActionGenerate, sync.ActionLinkTarget:
+			ui.DiffItem("add", diffName(tp, a), "missing")
 			syncCount++
-		} else if !targetSymlinks[skill] {
-			ui.DiffItem("modify", skill, "local copy (sync --force to replace)")
+		case sync.ActionMigrate:
+			ui.DiffItem("add", diffName(tp, a), "migrate target files to source, then link")
 			syncCount++
-		}
-	}
-
-	// Skills only in target (local only)
-	for skill := range targetSkills {
-		if !sourceSkills[skill] && !targetSymlinks[skill] {
-			ui.DiffItem("remove", skill, "local only")
+		case sync.ActionConvert, sync.ActionFixLink, sync.ActionUpdateCopy, sync.ActionRegenerate, sync.ActionRelink:
+			ui.DiffItem("modify", diffName(tp, a), a.Reason)
+			syncCount++
+		case sync.ActionIndex:
+			ui.DiffItem("modify", a.Source, "skill index: "+a.Reason)
+			syncCount++
+		case sync.ActionSkip:
+			ui.DiffItem("modify", a.Skill, a.Reason)
+			forceCount++
+		case sync.ActionPrune:
+			ui.DiffItem("remove", a.Skill, pruneDetail(a))
+			syncCount++
+		case sync.ActionKeep, sync.ActionLocal:
+			ui.DiffItem("remove", a.Skill, "local only")
 			localCount++
 		}
 	}
 
 	// Show action hints
-	if syncCount == 0 && localCount == 0 {
-		ui.Success("Fully synced")
-	} else {
-		fmt.Println()
-		if syncCount > 0 {
-			ui.Info("Run 'sync' to add missing, 'sync --force' to replace local copies")
-		}
-		if localCount > 0 {
-			ui.Info("Run 'pull %s' to import local-only skills to source", targetName)
+	if syncCount == 0 && forceCount == 0 && localCount == 0 {
+		switch {
+		case tp.Format != "":
+			ui.Success("Fully synced (%s format)", tp.Format)
+		case tp.Mode == "merge":
+			ui.Success("Fully synced")
+		default:
+			ui.Success("Fully synced (%s mode)", tp.Mode)
 		}
+		return
+	}
+
+	fmt.Println()
+	if syncCount > 0 {
+		ui.Info("Run 'sync' to apply these changes")
+	}
+	if forceCount > 0 {
+		ui.Info("Run 'sync --force' to replace local or edited copies")
+	}
+	if localCount > 0 && tp.Format == "" {
+		ui.Info("Run 'pull %s' to import local-only skills to source", tp.Name)
+	}
+}
+
+// diffName labels an action; whole-target actions are shown by target path.
+func diffName(tp sync.TargetPlan, a sync.Action) string {
+	if a.Skill == "" {
+		return tp.Path
+	}
+	return a.Skill
+}
+
+func pruneDetail(a sync.Action) string {
+	switch a.Reason {
+	case "orphan symlink to source", "broken symlink to source":
+		return "orphan link (sync prunes)"
 	}
+	return a.Reason + " (sync prunes)"
 }
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"skillshare/internal/backup"
 	"skillshare/internal/config"
 	"skillshare/internal/sync"
 	"skillshare/internal/trash"
@@ -34,24 +35,44 @@ func (r *doctorResult) addWarning() {
 }
 
 func cmdDoctor(args []string) error {
+	var resolve string
+	for _, arg := range args {
+		switch arg {
+		case "--revert", "--finish":
+			if resolve != "" && resolve != arg {
+				return fmt.Errorf("--revert and --finish cannot be combined")
+			}
+			resolve = arg
+		}
+	}
+	if resolve != "" {
+		return resolveSyncJournals(resolve == "--finish")
+	}
+
 	ui.Logo(version)
 
 	ui.Header("Checking environment")
 	result := &doctorResult{}
 
 	// Check config exists
-	if _, err := os.Stat(config.ConfigPath()); os.IsNotExist(err) {
+	if _, err := os.Stat(config.BaseConfigPath()); os.IsNotExist(err) {
 		ui.Error("Config not found: run 'skillshare init' first")
 		return nil
 	}
-	ui.Success("Config: %s", config.ConfigPath())
+	ui.Success("Config: %s", config.BaseConfigPath())
+	if name := config.ActiveProfile(); name != "" {
+		ui.Success("Profile: %s", name)
+	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		ui.Error("Config error: %v", err)
 		return nil
 	}
 
+	// Check config keys and values
+	checkConfigSchema(result)
+
 	// Check source exists
 	checkSource(cfg, result)
 
@@ -64,6 +85,12 @@ func cmdDoctor(args []string) error {
 	// Check skills validity
 	checkSkillsValidity(cfg.Source, result)
 
+	// Check user target registry files
+	checkTargetRegistry(result)
+
+	// Check for syncs that were interrupted
+	checkSyncJournals(result)
+
 	// Check each target
 	checkTargets(cfg, result)
 
@@ -101,28 +128,64 @@ func cmdDoctor(args []string) error {
 	return nil
 }
 
-func checkSource(cfg *config.Config, result *doctorResult) {
-	info, err := os.Stat(cfg.Source)
+// checkConfigSchema reports unknown keys and invalid values in config.yaml
+func checkConfigSchema(result *doctorResult) {
+	data, err := os.ReadFile(config.BaseConfigPath())
 	if err != nil {
-		ui.Error("Source not found: %s", cfg.Source)
-		result.addError()
 		return
 	}
-
-	if !info.IsDir() {
-		ui.Error("Source is not a directory: %s", cfg.Source)
-		result.addError()
-		return
+	for _, fe := range config.ValidateConfigData(data) {
+		ui.Warning("Config %s", fe.Error())
+		result.addWarning()
 	}
+}
 
-	entries, _ := os.ReadDir(cfg.Source)
-	skillCount := 0
-	for _, e := range entries {
-		if e.IsDir() && !utils.IsHidden(e.Name()) {
-			skillCount++
+func checkSource(cfg *config.Config, result *doctorResult) {
+	layers := cfg.SourceLayers()
+	for i, src := range layers {
+		label := "Source"
+		if len(layers) > 1 {
+			label = fmt.Sprintf("Source layer %d", i+1)
+		}
+
+		info, err := os.Stat(src)
+		if err != nil {
+			ui.Error("%s not found: %s", label, src)
+			result.addError()
+			continue
+		}
+
+		if !info.IsDir() {
+			ui.Error("%s is not a directory: %s", label, src)
+			result.addError()
+			continue
+		}
+
+		entries, _ := os.ReadDir(src)
+		skillCount := 0
+		for _, e := range entries {
+			if e.IsDir() && !utils.IsHidden(e.Name()) {
+				skillCount++
+			}
 		}
+		ui.Success("%s: %s (%d skills)", label, src, skillCount)
+	}
+
+	checkShadowedSkills(cfg)
+}
+
+// checkShadowedSkills lists skills overridden by a later source layer
+func checkShadowedSkills(cfg *config.Config) {
+	if len(cfg.SourceLayers()) < 2 {
+		return
+	}
+	_, shadowed, err := sync.DiscoverLayeredSkills(cfg.SourceLayers(), cfg.Ignore...)
+	if err != nil {
+		return
+	}
+	for _, s := range shadowed {
+		ui.Info("Shadowed: %s in %s (overridden by %s)", s.FlatName, s.Layer, s.ShadowedBy)
 	}
-	ui.Success("Source: %s (%d skills)", cfg.Source, skillCount)
 }
 
 func checkSymlinkSupport(result *doctorResult) {
@@ -144,6 +207,14 @@ func checkSymlinkSupport(result *doctorResult) {
 	ui.Success("Link support: OK")
 }
 
+// checkTargetRegistry reports malformed entries in user targets.yaml files
+func checkTargetRegistry(result *doctorResult) {
+	for _, err := range config.TargetRegistryErrors() {
+		ui.Warning("Target registry: %v", err)
+		result.addWarning()
+	}
+}
+
 func checkTargets(cfg *config.Config, result *doctorResult) {
 	ui.Header("Checking targets")
 
@@ -163,14 +234,22 @@ func checkTargets(cfg *config.Config, result *doctorResult) {
 			ui.Error("%s [%s]: %s", name, mode, strings.Join(targetIssues, ", "))
 			result.addError()
 		} else {
-			displayTargetStatus(name, target, cfg.Source, mode)
+			displayTargetStatus(name, target, cfg.SourceLayers(), cfg.Ignore, mode)
 		}
 	}
+
+	for _, name := range cfg.InactiveTargets() {
+		ui.Info("%s: skipped on this machine (when: condition not met)", name)
+	}
 }
 
 func checkTargetIssues(target config.TargetConfig, source string) []string {
 	var targetIssues []string
 
+	if err := sync.ValidateTargetFilters(target.Include, target.Exclude); err != nil {
+		targetIssues = append(targetIssues, err.Error())
+	}
+
 	info, err := os.Lstat(target.Path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -187,11 +266,10 @@ func checkTargetIssues(target config.TargetConfig, source string) []string {
 
 	// Check if it's a symlink
 	if info.Mode()&os.ModeSymlink != 0 {
-		link, _ := os.Readlink(target.Path)
-		absLink, _ := filepath.Abs(link)
+		absLink, _ := utils.ResolveLinkTarget(target.Path)
 		absSource, _ := filepath.Abs(source)
 		if !utils.PathsEqual(absLink, absSource) {
-			targetIssues = append(targetIssues, fmt.Sprintf("symlink points to wrong location: %s", link))
+			targetIssues = append(targetIssues, fmt.Sprintf("symlink points to wrong location: %s", absLink))
 		}
 	}
 
@@ -209,12 +287,23 @@ func checkTargetIssues(target config.TargetConfig, source string) []string {
 	return targetIssues
 }
 
-func displayTargetStatus(name string, target config.TargetConfig, source, mode string) {
+func displayTargetStatus(name string, target config.TargetConfig, sources []string, ignore []string, mode string) {
 	var statusStr string
 	needsSync := false
 
-	if mode == "merge" {
-		status, linkedCount, localCount := sync.CheckStatusMerge(target.Path, source)
+	if format := target.OutputFormat(); format != config.FormatSkillDir {
+		drift := sync.CheckStatusGenerated(target, sources, ignore...)
+		switch drift.Status {
+		case sync.StatusGenerated:
+			statusStr = fmt.Sprintf("generated %s (%d files)", format, len(drift.Synced))
+		case sync.StatusLinked:
+			statusStr = fmt.Sprintf("linked (needs sync to generate %s)", format)
+			needsSync = true
+		default:
+			statusStr = drift.Status.String()
+		}
+	} else if mode == "merge" {
+		status, linkedCount, localCount := sync.CheckStatusMerge(target, sources, ignore...)
 		switch status {
 		case sync.StatusMerged:
 			statusStr = fmt.Sprintf("merged (%d shared, %d local)", linkedCount, localCount)
@@ -224,8 +313,19 @@ func displayTargetStatus(name string, target config.TargetConfig, source, mode s
 		default:
 			statusStr = status.String()
 		}
+	} else if mode == "copy" {
+		drift := sync.CheckStatusCopy(target, sources, ignore...)
+		switch drift.Status {
+		case sync.StatusCopied:
+			statusStr = fmt.Sprintf("copied (%d synced, %d local)", len(drift.Synced), len(drift.Local))
+		case sync.StatusLinked:
+			statusStr = "linked (needs sync to apply copy mode)"
+			needsSync = true
+		default:
+			statusStr = drift.Status.String()
+		}
 	} else {
-		status := sync.CheckStatus(target.Path, source)
+		status := sync.CheckStatus(target.Path, sources[len(sources)-1])
 		statusStr = status.String()
 		if status == sync.StatusMerged {
 			statusStr = "merged (needs sync to apply symlink mode)"
@@ -241,12 +341,11 @@ func displayTargetStatus(name string, target config.TargetConfig, source, mode s
 }
 
 func checkSyncDrift(cfg *config.Config, result *doctorResult) {
-	discovered, err := sync.DiscoverSourceSkills(cfg.Source)
+	discovered, _, err := sync.DiscoverLayeredSkills(cfg.SourceLayers(), cfg.Ignore...)
 	if err != nil {
 		return
 	}
-	sourceCount := len(discovered)
-	if sourceCount == 0 {
+	if len(discovered) == 0 {
 		return
 	}
 
@@ -258,11 +357,34 @@ func checkSyncDrift(cfg *config.Config, result *doctorResult) {
 		if mode == "" {
 			mode = "merge"
 		}
+
+		// Targets with include/exclude filters or skills with frontmatter
+		// placement only expect their subset
+		expected, err := sync.FilterTargetSkills(discovered, target.WithName(name))
+		if err != nil {
+			continue
+		}
+		expected, _, err = sync.NameSkills(expected, target.NamingPolicy(), cfg.SourceLayers())
+		if err != nil {
+			ui.Error("%s: %v", name, err)
+			result.addError()
+			continue
+		}
+		sourceCount := len(expected)
+
+		if target.OutputFormat() != config.FormatSkillDir {
+			checkGeneratedDrift(name, target, cfg.SourceLayers(), cfg.Ignore, result)
+			continue
+		}
+		if mode == "copy" {
+			checkCopyDrift(name, target, cfg.SourceLayers(), cfg.Ignore, sourceCount, result)
+			continue
+		}
 		if mode != "merge" {
 			continue
 		}
 
-		status, linkedCount, _ := sync.CheckStatusMerge(target.Path, cfg.Source)
+		status, linkedCount, _ := sync.CheckStatusMerge(target, cfg.SourceLayers(), cfg.Ignore...)
 		if status != sync.StatusMerged {
 			continue
 		}
@@ -274,6 +396,40 @@ func checkSyncDrift(cfg *config.Config, result *doctorResult) {
 	}
 }
 
+// checkCopyDrift reports copy-mode copies that are missing, outdated or edited in the target
+func checkCopyDrift(name string, target config.TargetConfig, sources []string, ignore []string, sourceCount int, result *doctorResult) {
+	drift := sync.CheckStatusCopy(target, sources, ignore...)
+	if drift.Status != sync.StatusCopied {
+		return
+	}
+	if pending := len(drift.Missing) + len(drift.Outdated); pending > 0 {
+		ui.Warning("%s: %d skill(s) not synced (%d/%d copied and current)", name, pending, len(drift.Synced), sourceCount)
+		result.addWarning()
+	}
+	if len(drift.Modified) > 0 {
+		ui.Warning("%s: %d copy(ies) edited in target, 'sync --force' would overwrite: %s",
+			name, len(drift.Modified), strings.Join(drift.Modified, ", "))
+		result.addWarning()
+	}
+}
+
+// checkGeneratedDrift reports rendered files that are missing, outdated or edited in the target
+func checkGeneratedDrift(name string, target config.TargetConfig, sources []string, ignore []string, result *doctorResult) {
+	drift := sync.CheckStatusGenerated(target, sources, ignore...)
+	if drift.Status != sync.StatusGenerated {
+		return
+	}
+	if pending := len(drift.Missing) + len(drift.Outdated); pending > 0 {
+		ui.Warning("%s: %d file(s) not generated or out of date", name, pending)
+		result.addWarning()
+	}
+	if edited := append(drift.Modified, drift.Local...); len(edited) > 0 {
+		ui.Warning("%s: %d file(s) written or edited by hand, 'sync --force' would overwrite: %s",
+			name, len(edited), strings.Join(edited, ", "))
+		result.addWarning()
+	}
+}
+
 // checkGitStatus checks if source is a git repo and its status
 func checkGitStatus(source string, result *doctorResult) {
 	gitDir := filepath.Join(source, ".git")
@@ -336,6 +492,127 @@ func checkSkillsValidity(source string, result *doctorResult) {
 	}
 }
 
+// journalScope is a sync journal directory and how to resync its targets.
+type journalScope struct {
+	dir     string
+	label   string
+	resolve func() (map[string]config.TargetConfig, []string, []string, error) // targets, sources, ignore
+}
+
+// journalScopes returns the global journal directory and, inside a project,
+// the project one.
+func journalScopes() []journalScope {
+	scopes := []journalScope{{
+		dir:   sync.JournalDir(),
+		label: "global",
+		resolve: func() (map[string]config.TargetConfig, []string, []string, error) {
+			cfg, err := loadConfig()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			targets := make(map[string]config.TargetConfig, len(cfg.Targets))
+			for name, target := range cfg.Targets {
+				target.Mode = getTargetMode(target.Mode, cfg.Mode)
+				targets[name] = target
+			}
+			return targets, cfg.SourceLayers(), cfg.Ignore, nil
+		},
+	}}
+
+	cwd, err := os.Getwd()
+	if err != nil || !projectConfigExists(cwd) {
+		return scopes
+	}
+	return append(scopes, journalScope{
+		dir:   sync.ProjectJournalDir(cwd),
+		label: "project",
+		resolve: func() (map[string]config.TargetConfig, []string, []string, error) {
+			runtime, err := loadProjectRuntime(cwd)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return runtime.targets, []string{runtime.sourcePath}, runtime.config.Ignore, nil
+		},
+	})
+}
+
+// checkSyncJournals reports journals left by syncs that did not finish
+func checkSyncJournals(result *doctorResult) {
+	found := false
+	for _, scope := range journalScopes() {
+		journals, err := sync.LoadJournals(scope.dir)
+		if err != nil {
+			ui.Error("Sync journals (%s): %v", scope.label, err)
+			result.addError()
+			continue
+		}
+		for _, j := range journals {
+			ui.Error("%s: sync interrupted at %s (%d change(s) recorded)",
+				j.Target, j.Started.Local().Format("2006-01-02 15:04:05"), len(j.Entries))
+			result.addError()
+			found = true
+		}
+	}
+	if found {
+		ui.Info("  Run 'skillshare doctor --revert' to restore the targets as they were,")
+		ui.Info("  or 'skillshare doctor --finish' to keep the changes and sync them again")
+	}
+}
+
+// resolveSyncJournals rolls back every leftover journal, or with finish
+// keeps its changes and syncs the target again.
+func resolveSyncJournals(finish bool) error {
+	resolved, failed := 0, 0
+	for _, scope := range journalScopes() {
+		journals, err := sync.LoadJournals(scope.dir)
+		if err != nil {
+			return err
+		}
+		for _, j := range journals {
+			if err := resolveSyncJournal(scope, j, finish); err != nil {
+				ui.Error("%s: %v", j.Target, err)
+				failed++
+				continue
+			}
+			resolved++
+		}
+	}
+
+	if resolved == 0 && failed == 0 {
+		ui.Success("No interrupted syncs found")
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d interrupted sync(s) could not be resolved", failed)
+	}
+	return nil
+}
+
+func resolveSyncJournal(scope journalScope, j *sync.Journal, finish bool) error {
+	if !finish {
+		if err := j.Rollback(); err != nil {
+			return fmt.Errorf("rollback failed: %w", err)
+		}
+		ui.Success("%s: reverted %d change(s)", j.Target, len(j.Entries))
+		return nil
+	}
+
+	if err := j.Commit(); err != nil {
+		return err
+	}
+	targets, sources, ignore, err := scope.resolve()
+	if err != nil {
+		return err
+	}
+	target, ok := targets[j.Target]
+	if !ok {
+		ui.Success("%s: kept changes (target no longer configured)", j.Target)
+		return nil
+	}
+	tp := sync.PlanTarget(j.Target, target, sources, false, ignore...)
+	return applyTargetPlan(tp, scope.dir, false)
+}
+
 // checkBrokenSymlinks finds broken symlinks in targets
 func checkBrokenSymlinks(cfg *config.Config, result *doctorResult) {
 	for name, target := range cfg.Targets {
@@ -397,8 +674,8 @@ func checkDuplicateSkills(cfg *config.Config, result *doctorResult) {
 			mode = "merge"
 		}
 
-		// Skip merge mode - local skills are intentional
-		if mode == "merge" {
+		// Skip merge and copy mode - local skills are intentional
+		if mode == "merge" || mode == "copy" {
 			continue
 		}
 
@@ -445,7 +722,7 @@ func checkDuplicateSkills(cfg *config.Config, result *doctorResult) {
 
 // checkBackupStatus shows last backup time
 func checkBackupStatus() {
-	backupDir := filepath.Join(filepath.Dir(config.ConfigPath()), "backups")
+	backupDir := backup.BackupDir()
 	entries, err := os.ReadDir(backupDir)
 	if err != nil || len(entries) == 0 {
 		ui.Info("Backups: none found")
@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"skillshare/internal/config"
 	"skillshare/internal/hub"
 	"skillshare/internal/ui"
 	appversion "skillshare/internal/version"
@@ -120,6 +119,7 @@ func cmdHubIndex(args []string) error {
 	var sourcePath string
 	var outputPath string
 	var full bool
+	var ignore []string
 
 	// Parse remaining arguments
 	i := 0
@@ -160,11 +160,12 @@ func cmdHubIndex(args []string) error {
 
 	// Resolve source path
 	if sourcePath == "" {
-		resolved, err := resolveSourcePath(mode, cwd)
+		resolved, patterns, err := resolveSourcePath(mode, cwd)
 		if err != nil {
 			return err
 		}
 		sourcePath = resolved
+		ignore = patterns
 	}
 
 	// Resolve output path
@@ -178,7 +179,7 @@ func cmdHubIndex(args []string) error {
 
 	spinner := ui.StartTreeSpinner("Scanning source directory...", false)
 
-	idx, err := hub.BuildIndex(sourcePath, full)
+	idx, err := hub.BuildIndex(sourcePath, full, ignore...)
 	if err != nil {
 		spinner.Fail("Failed to build index")
 		return err
@@ -210,20 +211,20 @@ func cmdHubIndex(args []string) error {
 }
 
 // resolveSourcePath determines the source directory based on mode.
-func resolveSourcePath(mode runMode, cwd string) (string, error) {
+func resolveSourcePath(mode runMode, cwd string) (string, []string, error) {
 	if mode == modeProject {
 		rt, err := loadProjectRuntime(cwd)
 		if err != nil {
-			return "", fmt.Errorf("failed to load project config: %w", err)
+			return "", nil, fmt.Errorf("failed to load project config: %w", err)
 		}
-		return rt.sourcePath, nil
+		return rt.sourcePath, rt.config.Ignore, nil
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		return "", fmt.Errorf("failed to load config: %w", err)
+		return "", nil, fmt.Errorf("failed to load config: %w", err)
 	}
-	return cfg.Source, nil
+	return cfg.Source, cfg.Ignore, nil
 }
 
 func printHubHelp() {
@@ -188,13 +188,13 @@ func cmdHubDefault(args []string, mode runMode, cwd string) error {
 // loadHubConfigForRead loads the HubConfig from the appropriate config.
 func loadHubConfigForRead(mode runMode, cwd string) (*config.HubConfig, error) {
 	if mode == modeProject {
-		pcfg, err := config.LoadProject(cwd)
+		pcfg, err := loadProjectConfig(cwd)
 		if err != nil {
 			return &config.HubConfig{}, nil // graceful fallback
 		}
 		return &pcfg.Hub, nil
 	}
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return &config.HubConfig{}, nil // graceful fallback
 	}
@@ -204,7 +204,7 @@ func loadHubConfigForRead(mode runMode, cwd string) (*config.HubConfig, error) {
 // loadHubConfigForWrite loads the HubConfig and returns a save function.
 func loadHubConfigForWrite(mode runMode, cwd string) (*config.HubConfig, func(config.HubConfig) error, error) {
 	if mode == modeProject {
-		pcfg, err := config.LoadProject(cwd)
+		pcfg, err := loadProjectConfig(cwd)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to load project config: %w", err)
 		}
@@ -214,7 +214,7 @@ func loadHubConfigForWrite(mode runMode, cwd string) (*config.HubConfig, func(co
 		}
 		return &pcfg.Hub, saveFn, nil
 	}
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
@@ -147,13 +147,13 @@ func validateInitOptions(opts *initOptions, home string) error {
 
 // handleExistingInit handles init when config already exists
 func handleExistingInit(opts *initOptions) (bool, error) {
-	if _, err := os.Stat(config.ConfigPath()); os.IsNotExist(err) {
+	if _, err := os.Stat(config.BaseConfigPath()); os.IsNotExist(err) {
 		return false, nil // Not initialized, continue with fresh init
 	}
 
 	// If --remote provided, just add the remote to existing setup
 	if opts.remoteURL != "" {
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return true, err
 		}
@@ -163,7 +163,7 @@ func handleExistingInit(opts *initOptions) (bool, error) {
 
 	// If --discover provided, detect and add new agents
 	if opts.discover {
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return true, err
 		}
@@ -274,14 +274,14 @@ func createSourceDir(sourcePath string, dryRun bool) error {
 func printInitSuccess(sourcePath string, dryRun bool, skillInstalled bool) {
 	if dryRun {
 		ui.Header("Dry run complete")
-		ui.Info("Would write config: %s", config.ConfigPath())
+		ui.Info("Would write config: %s", config.BaseConfigPath())
 		ui.Info("Run 'skillshare init' to apply these changes")
 		return
 	}
 
 	ui.Header("Initialized successfully")
 	ui.Success("Source: %s", sourcePath)
-	ui.Success("Config: %s", config.ConfigPath())
+	ui.Success("Config: %s", config.BaseConfigPath())
 	fmt.Println()
 	ui.Info("Next steps:")
 	fmt.Println("  skillshare sync              # Sync to all targets")
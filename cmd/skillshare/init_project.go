@@ -305,7 +305,7 @@ func reinitProjectWithDiscover(root string, opts projectInitOptions) error {
 	ui.Logo(version)
 	ui.Header("Discovering new targets")
 
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return err
 	}
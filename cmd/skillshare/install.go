@@ -22,6 +22,8 @@ import (
 // installArgs holds parsed install command arguments
 type installArgs struct {
 	sourceArg string
+	layer     string // Source layer to install into (layered sources only)
+	lock      lockFlags
 	opts      install.InstallOptions
 }
 
@@ -31,6 +33,7 @@ type installLogSummary struct {
 	SkillCount      int
 	InstalledSkills []string
 	FailedSkills    []string
+	Changed         []string // Lockfile keys of the skills and repos written
 	DryRun          bool
 	Tracked         bool
 	Into            string
@@ -41,6 +44,7 @@ type installLogSummary struct {
 type installBatchSummary struct {
 	InstalledSkills []string
 	FailedSkills    []string
+	Changed         []string
 }
 
 // parseInstallArgs parses install command arguments
@@ -79,10 +83,20 @@ func parseInstallArgs(args []string) (*installArgs, bool, error) {
 			}
 			i++
 			result.opts.Into = args[i]
+		case arg == "--layer":
+			if i+1 >= len(args) {
+				return nil, false, fmt.Errorf("--layer requires a value")
+			}
+			i++
+			result.layer = args[i]
 		case arg == "--all":
 			result.opts.All = true
 		case arg == "--yes" || arg == "-y":
 			result.opts.Yes = true
+		case arg == "--frozen":
+			result.lock.frozen = true
+		case arg == "--update-lock":
+			result.lock.updateLock = true
 		case arg == "--help" || arg == "-h":
 			return nil, true, nil // showHelp = true
 		case strings.HasPrefix(arg, "-"):
@@ -125,7 +139,14 @@ func parseInstallArgs(args []string) (*installArgs, bool, error) {
 		return nil, false, fmt.Errorf("--all/--yes cannot be used with --track")
 	}
 
-	if result.sourceArg == "" {
+	if err := result.lock.validate(result.sourceArg); err != nil {
+		return nil, false, err
+	}
+	if result.layer != "" && (result.lock.frozen || result.lock.updateLock) {
+		return nil, false, fmt.Errorf("skillshare.lock covers the primary source; --frozen and --update-lock cannot be used with --layer")
+	}
+
+	if result.sourceArg == "" && !result.lock.frozen && !result.lock.updateLock {
 		return nil, true, fmt.Errorf("source is required")
 	}
 
@@ -195,13 +216,23 @@ func resolveInstallSource(sourceArg string, opts install.InstallOptions, cfg *co
 	return nil, false, fmt.Errorf("invalid source: %w", err)
 }
 
+// fetchSpinnerText returns the progress, failure and success messages for
+// fetching a git or archive source
+func fetchSpinnerText(source *install.Source) (string, string, string) {
+	if source.IsArchive() {
+		return "Fetching archive...", "Failed to fetch archive", "Extracted"
+	}
+	return "Cloning repository...", "Failed to clone", "Cloned"
+}
+
 // dispatchInstall routes to the appropriate install handler
 func dispatchInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+	opts.SourceDir = cfg.Source
 	if opts.Track {
 		return handleTrackedRepoInstall(source, cfg, opts)
 	}
 
-	if source.IsGit() {
+	if source.IsGit() || source.IsArchive() {
 		if !source.HasSubdir() {
 			return handleGitDiscovery(source, cfg, opts)
 		}
@@ -252,11 +283,35 @@ func cmdInstall(args []string) error {
 		return parseErr
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 	parsed.opts.AuditThreshold = cfg.Audit.BlockThreshold
+	if parsed.layer != "" {
+		if cfg.Source, err = cfg.ResolveLayer(parsed.layer); err != nil {
+			return err
+		}
+	}
+
+	if parsed.lock.frozen {
+		summary, err := installFromLockfile(config.LockfilePath(), cfg.Source, parsed.opts)
+		summary.Mode = "global"
+		logInstallOp(config.ConfigPath(), rest, start, err, summary)
+		return err
+	}
+	if parsed.sourceArg == "" {
+		// --update-lock on its own re-records what is installed
+		err := updateLockfileOnly(config.LockfilePath(), cfg.Source, parsed.opts.DryRun)
+		logInstallOp(config.ConfigPath(), rest, start, err, installLogSummary{Source: "skillshare.lock", Mode: "global"})
+		return err
+	}
+	var summary installLogSummary
+	if !parsed.opts.DryRun && parsed.layer == "" {
+		defer func() {
+			refreshLockfile(config.LockfilePath(), cfg.Source, summary.Changed, parsed.lock.updateLock)
+		}()
+	}
 
 	source, resolvedFromMeta, err := resolveInstallSource(parsed.sourceArg, parsed.opts, cfg)
 	if err != nil {
@@ -267,7 +322,7 @@ func cmdInstall(args []string) error {
 		return err
 	}
 
-	summary := installLogSummary{
+	summary = installLogSummary{
 		Source:         parsed.sourceArg,
 		Mode:           "global",
 		DryRun:         parsed.opts.DryRun,
@@ -352,7 +407,7 @@ func logInstallOp(cfgPath string, args []string, start time.Time, cmdErr error,
 
 func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
 	logSummary := installLogSummary{
-		Source:         source.Raw,
+		Source:         source.Spec(),
 		DryRun:         opts.DryRun,
 		Tracked:        true,
 		Into:           opts.Into,
@@ -364,7 +419,7 @@ func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts i
 	ui.Logo(appversion.Version)
 
 	// Step 1: Show source
-	ui.StepStart("Source", source.Raw)
+	ui.StepStart("Source", source.Spec())
 	if opts.Name != "" {
 		ui.StepContinue("Name", "_"+opts.Name)
 	}
@@ -412,6 +467,7 @@ func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts i
 	if !opts.DryRun {
 		logSummary.SkillCount = result.SkillCount
 		logSummary.InstalledSkills = append(logSummary.InstalledSkills, result.Skills...)
+		logSummary.Changed = append(logSummary.Changed, lockName(cfg.Source, result.RepoPath))
 	}
 
 	// Show next steps
@@ -426,7 +482,7 @@ func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts i
 
 func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
 	logSummary := installLogSummary{
-		Source:         source.Raw,
+		Source:         source.Spec(),
 		DryRun:         opts.DryRun,
 		Into:           opts.Into,
 		SkipAudit:      opts.SkipAudit,
@@ -437,22 +493,23 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 	ui.Logo(appversion.Version)
 
 	// Step 1: Show source
-	ui.StepStart("Source", source.Raw)
+	ui.StepStart("Source", source.Spec())
 	if opts.Into != "" {
 		ui.StepContinue("Into", opts.Into)
 	}
 
-	// Step 2: Clone with tree spinner animation
-	treeSpinner := ui.StartTreeSpinner("Cloning repository...", false)
+	// Step 2: Clone (or download) with tree spinner animation
+	fetching, failed, fetched := fetchSpinnerText(source)
+	treeSpinner := ui.StartTreeSpinner(fetching, false)
 
-	discovery, err := install.DiscoverFromGit(source)
+	discovery, err := install.Discover(source)
 	if err != nil {
-		treeSpinner.Fail("Failed to clone")
+		treeSpinner.Fail(failed)
 		return logSummary, err
 	}
 	defer install.CleanupDiscovery(discovery)
 
-	treeSpinner.Success("Cloned")
+	treeSpinner.Success(fetched)
 
 	// Step 3: Show found skills
 	if len(discovery.Skills) == 0 {
@@ -487,6 +544,13 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 		if err := ensureIntoDirExists(cfg.Source, opts); err != nil {
 			return logSummary, fmt.Errorf("failed to create --into directory: %w", err)
 		}
+
+		deps, err := installRequirements(requirementRootsFor(discovery, []install.SkillInfo{skill}, opts), cfg.Source, opts)
+		logSummary.InstalledSkills = append(logSummary.InstalledSkills, deps...)
+		logSummary.Changed = append(logSummary.Changed, deps...)
+		if err != nil {
+			return logSummary, err
+		}
 		fmt.Println()
 
 		installSpinner := ui.StartSpinner(fmt.Sprintf("Installing %s...", skill.Name))
@@ -511,6 +575,7 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 			fmt.Println()
 			ui.Info("Run 'skillshare sync' to distribute to all targets")
 			logSummary.InstalledSkills = append(logSummary.InstalledSkills, skill.Name)
+			logSummary.Changed = append(logSummary.Changed, lockName(cfg.Source, destPath))
 			logSummary.SkillCount = len(logSummary.InstalledSkills)
 		}
 
@@ -529,17 +594,21 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 			for _, skill := range selected {
 				ui.SkillBoxCompact(skill.Name, skill.Path)
 			}
+			if _, err := installRequirements(requirementRootsFor(discovery, selected, opts), cfg.Source, opts); err != nil {
+				return logSummary, err
+			}
 			fmt.Println()
 			ui.Warning("[dry-run] Would install %d skill(s)", len(selected))
 			return logSummary, nil
 		}
 
 		fmt.Println()
-		batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+		batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts)
 		logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 		logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
+		logSummary.Changed = append(logSummary.Changed, batchSummary.Changed...)
 		logSummary.SkillCount = len(logSummary.InstalledSkills)
-		return logSummary, nil
+		return logSummary, err
 	}
 
 	if opts.DryRun {
@@ -566,12 +635,13 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 	}
 
 	fmt.Println()
-	batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+	batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts)
 	logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 	logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
+	logSummary.Changed = append(logSummary.Changed, batchSummary.Changed...)
 	logSummary.SkillCount = len(logSummary.InstalledSkills)
 
-	return logSummary, nil
+	return logSummary, err
 }
 
 // selectSkills routes to the appropriate skill selection method:
@@ -732,12 +802,23 @@ func promptMultiSelect(skills []install.SkillInfo) ([]install.SkillInfo, error)
 // skillInstallResult holds the result of installing a single skill
 type skillInstallResult struct {
 	skill   install.SkillInfo
+	path    string // Where the skill was installed, empty when included in its parent
 	success bool
 	message string
 }
 
-// installSelectedSkills installs multiple skills with progress display
-func installSelectedSkills(selected []install.SkillInfo, discovery *install.DiscoveryResult, cfg *config.Config, opts install.InstallOptions) installBatchSummary {
+// installSelectedSkills installs multiple skills with progress display,
+// after the dependencies they require. It fails only when those cannot be
+// resolved or installed.
+func installSelectedSkills(selected []install.SkillInfo, discovery *install.DiscoveryResult, cfg *config.Config, opts install.InstallOptions) (installBatchSummary, error) {
+	deps, err := installRequirements(requirementRootsFor(discovery, selected, opts), cfg.Source, opts)
+	if err != nil {
+		return installBatchSummary{InstalledSkills: deps, Changed: deps}, err
+	}
+	if len(deps) > 0 {
+		fmt.Println()
+	}
+
 	results := make([]skillInstallResult, 0, len(selected))
 	installSpinner := ui.StartSpinnerWithSteps("Installing...", len(selected))
 
@@ -745,7 +826,7 @@ func installSelectedSkills(selected []install.SkillInfo, discovery *install.Disc
 	if opts.Into != "" {
 		if err := ensureIntoDirExists(cfg.Source, opts); err != nil {
 			installSpinner.Fail("Failed to create --into directory")
-			return installBatchSummary{}
+			return installBatchSummary{InstalledSkills: deps, Changed: deps}, nil
 		}
 	}
 
@@ -806,23 +887,28 @@ func installSelectedSkills(selected []install.SkillInfo, discovery *install.Disc
 		if skill.Path == "." {
 			rootInstalled = true
 		}
-		results = append(results, skillInstallResult{skill: skill, success: true, message: "installed"})
+		results = append(results, skillInstallResult{skill: skill, path: destPath, success: true, message: "installed"})
 	}
 
 	displayInstallResults(results, installSpinner)
 
 	summary := installBatchSummary{
-		InstalledSkills: make([]string, 0, len(results)),
+		InstalledSkills: make([]string, 0, len(deps)+len(results)),
 		FailedSkills:    make([]string, 0, len(results)),
 	}
+	summary.InstalledSkills = append(summary.InstalledSkills, deps...)
+	summary.Changed = append(summary.Changed, deps...)
 	for _, r := range results {
 		if r.success {
 			summary.InstalledSkills = append(summary.InstalledSkills, r.skill.Name)
+			if r.path != "" {
+				summary.Changed = append(summary.Changed, lockName(cfg.Source, r.path))
+			}
 			continue
 		}
 		summary.FailedSkills = append(summary.FailedSkills, r.skill.Name)
 	}
-	return summary
+	return summary, nil
 }
 
 // displayInstallResults shows the final install results
@@ -879,7 +965,7 @@ func displayInstallResults(results []skillInstallResult, spinner *ui.Spinner) {
 
 func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
 	logSummary := installLogSummary{
-		Source:         source.Raw,
+		Source:         source.Spec(),
 		DryRun:         opts.DryRun,
 		Into:           opts.Into,
 		SkipAudit:      opts.SkipAudit,
@@ -890,24 +976,25 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 	ui.Logo(appversion.Version)
 
 	// Step 1: Show source
-	ui.StepStart("Source", source.Raw)
+	ui.StepStart("Source", source.Spec())
 	ui.StepContinue("Subdir", source.Subdir)
 	if opts.Into != "" {
 		ui.StepContinue("Into", opts.Into)
 	}
 
-	// Step 2: Clone with tree spinner
-	treeSpinner := ui.StartTreeSpinner("Cloning repository...", false)
+	// Step 2: Clone (or download) with tree spinner
+	fetching, failed, fetched := fetchSpinnerText(source)
+	treeSpinner := ui.StartTreeSpinner(fetching, false)
 
 	// Discover skills in subdir
-	discovery, err := install.DiscoverFromGitSubdir(source)
+	discovery, err := install.Discover(source)
 	if err != nil {
-		treeSpinner.Fail("Failed to clone")
+		treeSpinner.Fail(failed)
 		return logSummary, err
 	}
 	defer install.CleanupDiscovery(discovery)
 
-	treeSpinner.Success("Cloned")
+	treeSpinner.Success(fetched)
 
 	// If only one skill found, install directly
 	if len(discovery.Skills) == 1 {
@@ -925,6 +1012,13 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 			return logSummary, fmt.Errorf("failed to create --into directory: %w", err)
 		}
 
+		deps, err := installRequirements(requirementRootsFor(discovery, []install.SkillInfo{skill}, opts), cfg.Source, opts)
+		logSummary.InstalledSkills = append(logSummary.InstalledSkills, deps...)
+		logSummary.Changed = append(logSummary.Changed, deps...)
+		if err != nil {
+			return logSummary, err
+		}
+
 		fmt.Println()
 		installSpinner := ui.StartSpinner(fmt.Sprintf("Installing %s...", skill.Name))
 
@@ -949,6 +1043,7 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 			fmt.Println()
 			ui.Info("Run 'skillshare sync' to distribute to all targets")
 			logSummary.InstalledSkills = append(logSummary.InstalledSkills, skill.Name)
+			logSummary.Changed = append(logSummary.Changed, lockName(cfg.Source, destPath))
 			logSummary.SkillCount = len(logSummary.InstalledSkills)
 		}
 		return logSummary, nil
@@ -978,17 +1073,21 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 			for _, skill := range selected {
 				ui.SkillBoxCompact(skill.Name, skill.Path)
 			}
+			if _, err := installRequirements(requirementRootsFor(discovery, selected, opts), cfg.Source, opts); err != nil {
+				return logSummary, err
+			}
 			fmt.Println()
 			ui.Warning("[dry-run] Would install %d skill(s)", len(selected))
 			return logSummary, nil
 		}
 
 		fmt.Println()
-		batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+		batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts)
 		logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 		logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
+		logSummary.Changed = append(logSummary.Changed, batchSummary.Changed...)
 		logSummary.SkillCount = len(logSummary.InstalledSkills)
-		return logSummary, nil
+		return logSummary, err
 	}
 
 	if opts.DryRun {
@@ -1014,17 +1113,19 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 	}
 
 	fmt.Println()
-	batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+	batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts)
 	logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 	logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
+	logSummary.Changed = append(logSummary.Changed, batchSummary.Changed...)
 	logSummary.SkillCount = len(logSummary.InstalledSkills)
 
-	return logSummary, nil
+	return logSummary, err
 }
 
 func handleDirectInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+	opts.SourceDir = cfg.Source
 	logSummary := installLogSummary{
-		Source:         source.Raw,
+		Source:         source.Spec(),
 		DryRun:         opts.DryRun,
 		Into:           opts.Into,
 		SkipAudit:      opts.SkipAudit,
@@ -1057,7 +1158,7 @@ func handleDirectInstall(source *install.Source, cfg *config.Config, opts instal
 	ui.Logo(appversion.Version)
 
 	// Step 1: Show source info
-	ui.StepStart("Source", source.Raw)
+	ui.StepStart("Source", source.Spec())
 	ui.StepContinue("Name", skillName)
 	if opts.Into != "" {
 		ui.StepContinue("Into", opts.Into)
@@ -1068,11 +1169,22 @@ func handleDirectInstall(source *install.Source, cfg *config.Config, opts instal
 
 	// Step 2: Clone/copy with tree spinner
 	var actionMsg string
-	if source.IsGit() {
-		actionMsg = "Cloning repository..."
+	if source.IsGit() || source.IsArchive() {
+		actionMsg, _, _ = fetchSpinnerText(source)
 	} else {
 		actionMsg = "Copying files..."
 	}
+	if source.Type == install.SourceTypeLocalPath {
+		deps, err := installRequirements([]requirementRoot{{name: filepath.ToSlash(filepath.Join(opts.Into, skillName)), dir: source.Path}}, cfg.Source, opts)
+		logSummary.InstalledSkills = append(logSummary.InstalledSkills, deps...)
+		logSummary.Changed = append(logSummary.Changed, deps...)
+		if err != nil {
+			return logSummary, err
+		}
+		if len(deps) > 0 {
+			fmt.Println()
+		}
+	}
 	treeSpinner := ui.StartTreeSpinner(actionMsg, true)
 
 	// Execute installation
@@ -1101,6 +1213,7 @@ func handleDirectInstall(source *install.Source, cfg *config.Config, opts instal
 		fmt.Println()
 		ui.Info("Run 'skillshare sync' to distribute to all targets")
 		logSummary.InstalledSkills = append(logSummary.InstalledSkills, skillName)
+		logSummary.Changed = append(logSummary.Changed, lockName(cfg.Source, destPath))
 		logSummary.SkillCount = len(logSummary.InstalledSkills)
 	}
 
@@ -1112,6 +1225,7 @@ func printInstallHelp() {
 
 Install a skill from a local path or git repository.
 When using --update or --force with a skill name, skillshare uses stored metadata to resolve the source.
+Skills listed in a SKILL.md requires: field are resolved and installed first.
 
 Sources:
   user/repo                  GitHub shorthand (expands to github.com/user/repo)
@@ -1120,11 +1234,17 @@ Sources:
   github.com/user/repo/path  Subdirectory in GitHub repo (direct install)
   https://github.com/...     HTTPS git URL
   git@github.com:...         SSH git URL
+  gitlab.com/group/sub/repo  GitLab project in nested groups (// before a subdir)
+  https://host/.../tree/...  Browser link from GitHub, GitLab, Bitbucket or Gitea
   ~/path/to/skill            Local directory
+  ./skills.tar.gz[/path]     Local .tar.gz, .tgz or .zip archive
+  https://host/skills.zip    Archive URL (https only)
+  <archive>#sha256=<hex>     Archive with a required sha256 digest
 
 Options:
   --name <name>       Override installed name when exactly one skill is installed
   --into <dir>        Install into subdirectory (e.g. "frontend" or "frontend/react")
+  --layer <layer>     Source layer to install into (index, dir name or path)
   --force, -f         Overwrite existing skill; also continue if audit would block
   --update, -u        Update existing (git pull if possible, else reinstall)
   --track, -t         Install as tracked repo (preserves .git for updates)
@@ -1133,6 +1253,8 @@ Options:
   --yes, -y           Auto-accept all prompts (equivalent to --all for multi-skill repos)
   --dry-run, -n       Preview the installation without making changes
   --skip-audit        Skip security audit entirely for this install
+  --frozen            Install exactly what skillshare.lock records (no source)
+  --update-lock       Resolve sources fresh and re-record skillshare.lock
   --project, -p       Use project-level config in current directory
   --global, -g        Use global config (~/.config/skillshare)
   --help, -h          Show this help
@@ -1160,6 +1282,11 @@ Tracked repositories (Team Edition):
   skillshare install team/shared-skills --track   # Clone as _shared-skills
   skillshare install _shared-skills --update      # Update tracked repo
 
+Reproducible installs (skillshare.lock):
+  skillshare install --frozen                # Locked commits only; fail on hash mismatch
+  skillshare install -p --frozen             # Same for a project (CI)
+  skillshare install --update-lock           # Record what is installed now
+
 Update existing skills:
   skillshare install my-skill --update       # Update using stored source
   skillshare install my-skill --force        # Reinstall using stored source
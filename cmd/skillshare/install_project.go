@@ -15,6 +15,7 @@ import (
 
 type projectInstallArgs struct {
 	sourceArg string
+	lock      lockFlags
 	opts      install.InstallOptions
 }
 
@@ -57,6 +58,10 @@ func parseProjectInstallArgs(args []string) (*projectInstallArgs, bool, error) {
 			result.opts.All = true
 		case arg == "--yes" || arg == "-y":
 			result.opts.Yes = true
+		case arg == "--frozen":
+			result.lock.frozen = true
+		case arg == "--update-lock":
+			result.lock.updateLock = true
 		case arg == "--help" || arg == "-h":
 			return nil, true, nil
 		case strings.HasPrefix(arg, "-"):
@@ -99,6 +104,10 @@ func parseProjectInstallArgs(args []string) (*projectInstallArgs, bool, error) {
 		return nil, false, fmt.Errorf("--all/--yes cannot be used with --track")
 	}
 
+	if err := result.lock.validate(result.sourceArg); err != nil {
+		return nil, false, err
+	}
+
 	if result.opts.Into != "" {
 		if err := validate.IntoPath(result.opts.Into); err != nil {
 			return nil, false, err
@@ -141,6 +150,16 @@ func cmdInstallProject(args []string, root string) (installLogSummary, error) {
 	parsed.opts.AuditProjectRoot = root
 	summary.AuditThreshold = parsed.opts.AuditThreshold
 
+	lockPath := config.ProjectLockfilePath(root)
+	if parsed.lock.frozen {
+		return installProjectFrozen(runtime, lockPath, parsed.opts)
+	}
+	if !parsed.opts.DryRun {
+		defer func() {
+			refreshLockfile(lockPath, runtime.sourcePath, summary.Changed, parsed.lock.updateLock)
+		}()
+	}
+
 	if parsed.sourceArg == "" {
 		if parsed.opts.Name != "" {
 			return summary, fmt.Errorf("--name requires a source; it cannot be used with 'skillshare install -p' (no source)")
@@ -149,7 +168,14 @@ func cmdInstallProject(args []string, root string) (installLogSummary, error) {
 			return summary, fmt.Errorf("--into requires a source; it cannot be used with 'skillshare install -p' (no source)")
 		}
 		summary.Source = "project-config"
-		return installFromProjectConfig(runtime, parsed.opts)
+		var lock *install.Lockfile
+		if !parsed.lock.updateLock {
+			if lock, err = install.ReadLockfile(lockPath); err != nil {
+				return summary, err
+			}
+		}
+		summary, err = installFromProjectConfig(runtime, parsed.opts, lock)
+		return summary, err
 	}
 
 	cfg := &config.Config{Source: runtime.sourcePath}
@@ -183,12 +209,39 @@ func cmdInstallProject(args []string, root string) (installLogSummary, error) {
 	return summary, reconcileProjectRemoteSkills(runtime)
 }
 
-func installFromProjectConfig(runtime *projectRuntime, opts install.InstallOptions) (installLogSummary, error) {
+// installProjectFrozen installs the project's skills exactly as
+// .skillshare/skillshare.lock records them (install -p --frozen)
+func installProjectFrozen(runtime *projectRuntime, lockPath string, opts install.InstallOptions) (installLogSummary, error) {
+	lock, err := install.ReadLockfile(lockPath)
+	if err != nil {
+		return installLogSummary{Mode: "project"}, err
+	}
+	if lock != nil {
+		for _, skill := range runtime.config.Skills {
+			if _, ok := lock.Skills[skill.Name]; !ok {
+				return installLogSummary{Mode: "project"}, fmt.Errorf("'%s' is in .skillshare/config.yaml but not in skillshare.lock; run 'skillshare install -p --update-lock'", skill.Name)
+			}
+		}
+	}
+
+	summary, err := installFromLockfile(lockPath, runtime.sourcePath, opts)
+	summary.Mode = "project"
+	if err != nil || opts.DryRun || len(summary.InstalledSkills) == 0 {
+		return summary, err
+	}
+	return summary, reconcileProjectRemoteSkills(runtime)
+}
+
+// installFromProjectConfig installs the skills listed in the project config
+// that are missing. Skills recorded in lock are installed at their locked
+// commit and checked against the locked hash.
+func installFromProjectConfig(runtime *projectRuntime, opts install.InstallOptions, lock *install.Lockfile) (installLogSummary, error) {
 	summary := installLogSummary{
 		Mode:   "project",
 		Source: "project-config",
 		DryRun: opts.DryRun,
 	}
+	opts.SourceDir = runtime.sourcePath
 
 	if len(runtime.config.Skills) == 0 {
 		ui.Info("No remote skills defined in .skillshare/config.yaml")
@@ -221,6 +274,12 @@ func installFromProjectConfig(runtime *projectRuntime, opts install.InstallOptio
 		}
 
 		source.Name = skillName
+		var locked *install.LockedSkill
+		if lock != nil {
+			if locked = lock.Skills[skillName]; locked != nil {
+				source.Commit = locked.Commit
+			}
+		}
 
 		if skill.Tracked {
 			trackedResult, err := install.InstallTrackedRepo(source, runtime.sourcePath, opts)
@@ -232,7 +291,9 @@ func installFromProjectConfig(runtime *projectRuntime, opts install.InstallOptio
 				ui.StepDone(skillName, trackedResult.Action)
 				continue
 			}
+			warnLockMismatch(skillName, trackedResult.RepoPath, locked)
 			ui.StepDone(skillName, fmt.Sprintf("installed (tracked, %d skills)", trackedResult.SkillCount))
+			summary.Changed = append(summary.Changed, lockName(runtime.sourcePath, trackedResult.RepoPath))
 			if len(trackedResult.Skills) > 0 {
 				summary.InstalledSkills = append(summary.InstalledSkills, trackedResult.Skills...)
 			} else {
@@ -255,8 +316,10 @@ func installFromProjectConfig(runtime *projectRuntime, opts install.InstallOptio
 			if err := install.UpdateGitIgnore(filepath.Join(runtime.root, ".skillshare"), filepath.Join("skills", skillName)); err != nil {
 				ui.Warning("Failed to update .skillshare/.gitignore: %v", err)
 			}
+			warnLockMismatch(skillName, destPath, locked)
 			ui.StepDone(skillName, "installed")
 			summary.InstalledSkills = append(summary.InstalledSkills, skillName)
+			summary.Changed = append(summary.Changed, lockName(runtime.sourcePath, destPath))
 		}
 
 		installed++
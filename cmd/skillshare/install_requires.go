@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"skillshare/internal/install"
+	"skillshare/internal/ui"
+)
+
+// requirementRoot is a skill about to be installed whose requires: entries
+// are resolved before it
+type requirementRoot struct {
+	name string // Destination relative to the source directory
+	dir  string // Where its SKILL.md can be read now
+}
+
+// installRequirements resolves the requires: entries of the skills about to
+// be installed, shows the dependency tree and installs the missing
+// dependencies, each after its own. It returns the names it installed; a
+// cycle or an unresolvable requirement refuses the whole install.
+func installRequirements(roots []requirementRoot, sourceDir string, opts install.InstallOptions) ([]string, error) {
+	resolver := install.NewDepResolver(sourceDir)
+	defer resolver.Cleanup()
+
+	var resolved []*install.DepNode
+	for _, root := range roots {
+		node, err := resolver.Resolve(root.name, root.dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(node.Requires) > 0 {
+			resolved = append(resolved, node)
+		}
+	}
+	if len(resolved) == 0 {
+		return nil, nil
+	}
+
+	edges, labels := depTreeEdges(resolved)
+	fmt.Println()
+	ui.Header("Dependencies")
+	for _, root := range resolved {
+		fmt.Printf("  %s\n", root.Name)
+		printTree("  ", edges[root.Name], edges, func(name string) string { return labels[name] }, []string{root.Name})
+	}
+
+	missing := resolver.Missing()
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	if opts.DryRun {
+		fmt.Println()
+		ui.Warning("[dry-run] Would install %d dependency(ies) first", len(missing))
+		return nil, nil
+	}
+
+	fmt.Println()
+	var installed []string
+	for _, dep := range missing {
+		spinner := ui.StartSpinner(fmt.Sprintf("Installing dependency %s...", dep.Name))
+		result, err := resolver.Install(dep, opts)
+		if err != nil {
+			spinner.Fail(fmt.Sprintf("Failed to install dependency %s", dep.Name))
+			return installed, fmt.Errorf("dependency %s: %w", dep.Name, err)
+		}
+		spinner.Success(fmt.Sprintf("Installed dependency: %s", dep.Name))
+		for _, warning := range result.Warnings {
+			ui.Warning("%s", warning)
+		}
+		installed = append(installed, dep.Name)
+	}
+	return installed, nil
+}
+
+// depTreeEdges flattens resolved dependency trees into edges and status
+// labels by name for printTree
+func depTreeEdges(roots []*install.DepNode) (map[string][]string, map[string]string) {
+	edges := map[string][]string{}
+	labels := map[string]string{}
+	var walk func(n *install.DepNode)
+	walk = func(n *install.DepNode) {
+		if _, seen := edges[n.Name]; seen {
+			return
+		}
+		edges[n.Name] = []string{}
+		switch {
+		case n.Spec == "":
+		case n.Installed:
+			labels[n.Name] = "installed"
+		default:
+			labels[n.Name] = "install from " + n.Spec
+		}
+		for _, dep := range n.Requires {
+			edges[n.Name] = append(edges[n.Name], dep.Name)
+			walk(dep)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	return edges, labels
+}
+
+// printTree prints the children of a node with box-drawing branches. A name
+// already on the path from the root is marked as a cycle, not expanded.
+func printTree(indent string, children []string, edges map[string][]string, label func(string) string, chain []string) {
+	for i, name := range children {
+		branch, next := ui.StepBranch+"── ", indent+ui.StepLine+"   "
+		if i == len(children)-1 {
+			branch, next = ui.StepCorner+"── ", indent+"    "
+		}
+
+		detail := label(name)
+		cycle := slices.Contains(chain, name)
+		if cycle {
+			detail = "cycle"
+		}
+		if detail != "" {
+			fmt.Printf("%s%s%s  %s(%s)%s\n", indent, branch, name, ui.Gray, detail, ui.Reset)
+		} else {
+			fmt.Printf("%s%s%s\n", indent, branch, name)
+		}
+		if !cycle {
+			printTree(next, edges[name], edges, label, append(slices.Clone(chain), name))
+		}
+	}
+}
+
+// requirementRootsFor lists the selected skills of a discovery as
+// requirement roots, at the destinations installSelectedSkills uses
+func requirementRootsFor(discovery *install.DiscoveryResult, skills []install.SkillInfo, opts install.InstallOptions) []requirementRoot {
+	roots := make([]requirementRoot, 0, len(skills))
+	for _, skill := range skills {
+		roots = append(roots, requirementRoot{
+			name: filepath.ToSlash(filepath.Join(opts.Into, skill.Name)),
+			dir:  install.DiscoveredSkillPath(discovery, skill),
+		})
+	}
+	return roots
+}
@@ -4,30 +4,133 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
-	"skillshare/internal/config"
 	"skillshare/internal/install"
 	"skillshare/internal/sync"
 	"skillshare/internal/ui"
 	"skillshare/internal/utils"
 )
 
+// listOptions holds parsed list command flags
+type listOptions struct {
+	verbose bool
+	ignored bool
+	tree    bool
+}
+
 // parseListArgs parses list command arguments
-func parseListArgs(args []string) (verbose bool, showHelp bool, err error) {
+func parseListArgs(args []string) (opts listOptions, showHelp bool, err error) {
 	for _, arg := range args {
 		switch arg {
 		case "--verbose", "-v":
-			verbose = true
+			opts.verbose = true
+		case "--ignored":
+			opts.ignored = true
+		case "--tree":
+			opts.tree = true
 		case "--help", "-h":
-			return false, true, nil
+			return listOptions{}, true, nil
 		default:
 			if strings.HasPrefix(arg, "-") {
-				return false, false, fmt.Errorf("unknown option: %s", arg)
+				return listOptions{}, false, fmt.Errorf("unknown option: %s", arg)
 			}
 		}
 	}
-	return verbose, false, nil
+	return opts, false, nil
+}
+
+// displayIgnoredSkills lists skills excluded by ignore patterns and the rule responsible
+func displayIgnoredSkills(ignored []sync.IgnoredSkill) {
+	if len(ignored) == 0 {
+		ui.Info("No skills are ignored")
+		return
+	}
+
+	ui.Header("Ignored skills")
+	maxNameLen := 0
+	for _, s := range ignored {
+		if len(s.RelPath) > maxNameLen {
+			maxNameLen = len(s.RelPath)
+		}
+	}
+	for _, s := range ignored {
+		reason := fmt.Sprintf("%s (%s)", s.Pattern, s.Origin)
+		if s.Dir != s.RelPath {
+			reason = fmt.Sprintf("%s via %s/ (%s)", s.Pattern, s.Dir, s.Origin)
+		}
+		format := fmt.Sprintf("  %s✗%s %%-%ds  %s%%s%s\n", ui.Gray, ui.Reset, maxNameLen, ui.Gray, ui.Reset)
+		fmt.Printf(format, s.RelPath, reason)
+	}
+}
+
+// displayDependencyTree shows the skills in sourceDir as a dependency graph:
+// every skill no other skill requires is a root with its requires nested
+// below it. Required skills that are no longer installed are marked missing.
+func displayDependencyTree(sourceDir string, discovered []sync.DiscoveredSkill) error {
+	graph, err := install.DependencyGraph(sourceDir)
+	if err != nil {
+		return fmt.Errorf("cannot read dependencies: %w", err)
+	}
+
+	present := map[string]bool{}
+	var names []string
+	for _, d := range discovered {
+		if d.SourcePath == filepath.Join(sourceDir, filepath.FromSlash(d.RelPath)) {
+			rel := filepath.ToSlash(d.RelPath)
+			present[rel] = true
+			names = append(names, rel)
+		}
+	}
+	if len(names) == 0 {
+		ui.Info("No skills installed")
+		return nil
+	}
+	sort.Strings(names)
+
+	required := map[string]bool{}
+	for _, name := range names {
+		for _, dep := range graph[name] {
+			required[dep] = true
+		}
+	}
+	label := func(name string) string {
+		if !present[name] {
+			return "missing"
+		}
+		return ""
+	}
+
+	ui.Header("Skill dependencies")
+	printed := map[string]bool{}
+	var mark func(name string)
+	mark = func(name string) {
+		if printed[name] {
+			return
+		}
+		printed[name] = true
+		for _, dep := range graph[name] {
+			mark(dep)
+		}
+	}
+	printRoot := func(name string) {
+		fmt.Printf("  %s→%s %s\n", ui.Cyan, ui.Reset, name)
+		printTree("    ", graph[name], graph, label, []string{name})
+		mark(name)
+	}
+	for _, name := range names {
+		if !required[name] {
+			printRoot(name)
+		}
+	}
+	// Skills only reachable through a cycle have no root of their own
+	for _, name := range names {
+		if !printed[name] {
+			printRoot(name)
+		}
+	}
+	return nil
 }
 
 // buildSkillEntries builds skill entries from discovered skills
@@ -64,6 +167,16 @@ func displaySkillsVerbose(skills []skillEntry) {
 		if s.RepoName != "" {
 			fmt.Printf("    %sTracked repo:%s %s\n", ui.Gray, ui.Reset, s.RepoName)
 		}
+		if s.Layer != "" {
+			fmt.Printf("    %sLayer:%s       %s\n", ui.Gray, ui.Reset, s.Layer)
+		}
+		if s.Targets != nil {
+			targets := strings.Join(s.Targets, ", ")
+			if targets == "" {
+				targets = "(none)"
+			}
+			fmt.Printf("    %sTargets:%s     %s\n", ui.Gray, ui.Reset, targets)
+		}
 		if s.Source != "" {
 			fmt.Printf("    %sSource:%s      %s\n", ui.Gray, ui.Reset, s.Source)
 			fmt.Printf("    %sType:%s        %s\n", ui.Gray, ui.Reset, s.Type)
@@ -93,6 +206,10 @@ func displaySkillsCompact(skills []skillEntry) {
 
 // getSkillSuffix returns the display suffix for a skill
 func getSkillSuffix(s skillEntry) string {
+	if layer := s.Layer; layer != "" {
+		s.Layer = ""
+		return fmt.Sprintf("%s [%s]", getSkillSuffix(s), filepath.Base(layer))
+	}
 	if s.RepoName != "" {
 		return fmt.Sprintf("tracked: %s", s.RepoName)
 	}
@@ -151,7 +268,7 @@ func cmdList(args []string) error {
 
 	applyModeLabel(mode)
 
-	verbose, showHelp, err := parseListArgs(rest)
+	opts, showHelp, err := parseListArgs(rest)
 	if showHelp {
 		printListHelp()
 		return nil
@@ -159,24 +276,50 @@ func cmdList(args []string) error {
 	if err != nil {
 		return err
 	}
+	verbose := opts.verbose
 
 	if mode == modeProject {
-		_ = verbose
-		return cmdListProject(cwd)
+		return cmdListProject(cwd, opts)
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
-	discovered, err := sync.DiscoverSourceSkills(cfg.Source)
+	if opts.ignored {
+		var ignored []sync.IgnoredSkill
+		for _, src := range cfg.SourceLayers() {
+			layerIgnored, err := sync.DiscoverIgnoredSkills(src, cfg.Ignore...)
+			if err != nil {
+				return fmt.Errorf("cannot discover skills: %w", err)
+			}
+			ignored = append(ignored, layerIgnored...)
+		}
+		displayIgnoredSkills(ignored)
+		return nil
+	}
+
+	discovered, _, err := sync.DiscoverLayeredSkills(cfg.SourceLayers(), cfg.Ignore...)
 	if err != nil {
 		return fmt.Errorf("cannot discover skills: %w", err)
 	}
+	if opts.tree {
+		return displayDependencyTree(cfg.Source, discovered)
+	}
 
 	trackedRepos, _ := install.GetTrackedRepos(cfg.Source)
 	skills := buildSkillEntries(discovered)
+	if len(cfg.SourceLayers()) > 1 {
+		for i := range skills {
+			skills[i].Layer = discovered[i].Layer
+		}
+	}
+	if verbose {
+		for i := range skills {
+			skills[i].Targets = sync.SkillTargetNames(discovered[i], cfg.Targets, cfg.Mode)
+		}
+	}
 
 	if len(skills) == 0 && len(trackedRepos) == 0 {
 		ui.Info("No skills installed")
@@ -212,6 +355,8 @@ type skillEntry struct {
 	InstalledAt string
 	IsNested    bool
 	RepoName    string
+	Layer       string   // Source layer, set only with layered sources
+	Targets     []string // Targets the skill syncs to, set only for --verbose
 }
 
 // abbreviateSource shortens long sources for display
@@ -233,12 +378,16 @@ func printListHelp() {
 List all installed skills in the source directory.
 
 Options:
-  --verbose, -v   Show detailed information (source, type, install date)
+  --verbose, -v   Show detailed information (targets, source, type, install date)
+  --ignored       Show skills excluded by ignore patterns and why
+  --tree          Show skills as a dependency tree (SKILL.md requires:)
   --project, -p   Use project-level config in current directory
   --global, -g    Use global config (~/.config/skillshare)
   --help, -h      Show this help
 
 Examples:
   skillshare list
-  skillshare list --verbose`)
+  skillshare list --verbose
+  skillshare list --ignored
+  skillshare list --tree`)
 }
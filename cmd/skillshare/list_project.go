@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"path/filepath"
 	"sort"
 	"strings"
 
@@ -18,20 +17,36 @@ type projectSkillEntry struct {
 	RepoName string
 }
 
-func cmdListProject(root string) error {
+func cmdListProject(root string, opts listOptions) error {
 	if !projectConfigExists(root) {
 		if err := performProjectInit(root, projectInitOptions{}); err != nil {
 			return err
 		}
 	}
 
-	sourcePath := filepath.Join(root, ".skillshare", "skills")
+	runtime, err := loadProjectRuntime(root)
+	if err != nil {
+		return err
+	}
+	sourcePath := runtime.sourcePath
+
+	if opts.ignored {
+		ignored, err := sync.DiscoverIgnoredSkills(sourcePath, runtime.config.Ignore...)
+		if err != nil {
+			return fmt.Errorf("cannot discover project skills: %w", err)
+		}
+		displayIgnoredSkills(ignored)
+		return nil
+	}
 
 	// Use recursive discovery (same as global list and web UI)
-	discovered, err := sync.DiscoverSourceSkills(sourcePath)
+	discovered, err := sync.DiscoverSourceSkills(sourcePath, runtime.config.Ignore...)
 	if err != nil {
 		return fmt.Errorf("cannot discover project skills: %w", err)
 	}
+	if opts.tree {
+		return displayDependencyTree(sourcePath, discovered)
+	}
 
 	trackedRepos, _ := install.GetTrackedRepos(sourcePath)
 
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"skillshare/internal/config"
+	"skillshare/internal/filelock"
+	"skillshare/internal/ui"
+)
+
+// mutatingCommands change the config, the source directory or targets, and
+// run under the cross-process write lock shared with the UI server.
+var mutatingCommands = map[string]bool{
+	"init":      true,
+	"install":   true,
+	"uninstall": true,
+	"sync":      true,
+	"backup":    true,
+	"restore":   true,
+	"collect":   true,
+	"pull":      true,
+	"push":      true,
+	"target":    true,
+	"upgrade":   true,
+	"update":    true,
+	"new":       true,
+	"trash":     true,
+	"hub":       true,
+	"config":    true,
+	"profile":   true,
+}
+
+// readOnlySubcommands are the subcommands of mutating commands that only
+// read, so they run while another process holds the write lock. The empty
+// subcommand is the command on its own, which lists or prints help.
+var readOnlySubcommands = map[string]map[string]bool{
+	"config":  {"": true, "get": true, "validate": true, "schema": true},
+	"target":  {"": true, "list": true, "ls": true, "registry": true},
+	"profile": {"": true, "list": true, "ls": true, "current": true},
+	"trash":   {"": true, "list": true, "ls": true},
+	"hub":     {"": true, "list": true, "ls": true},
+}
+
+// subcommandOf returns the first argument that is not a mode flag
+func subcommandOf(args []string) (string, []string) {
+	for i, arg := range args {
+		switch arg {
+		case "--project", "-p", "--global", "-g":
+			continue
+		}
+		return arg, args[i+1:]
+	}
+	return "", nil
+}
+
+// needsWriteLock reports whether main should hold the write lock for the
+// whole command. 'sync --watch' locks each cycle instead, so it does not
+// block other commands while idle. 'doctor' only writes when resolving
+// interrupted syncs.
+func needsWriteLock(cmd string, args []string) bool {
+	if cmd == "doctor" {
+		for _, arg := range args {
+			if arg == "--revert" || arg == "--finish" {
+				return true
+			}
+		}
+		return false
+	}
+	if !mutatingCommands[cmd] {
+		return false
+	}
+	if readOnly, ok := readOnlySubcommands[cmd]; ok {
+		sub, rest := subcommandOf(args)
+		switch {
+		case sub == "help" || sub == "--help" || sub == "-h":
+			return false
+		case readOnly[sub]:
+			return false
+		case cmd == "target" && len(rest) == 0 && !isTargetSubcommand(sub):
+			return false // 'target <name>' on its own shows the target
+		case cmd == "hub" && sub == "default" && len(rest) == 0:
+			return false // Shows the default hub
+		}
+	}
+	if cmd == "sync" {
+		for _, arg := range args {
+			if arg == "--watch" || arg == "-w" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isTargetSubcommand reports whether sub is a 'target' subcommand rather
+// than a target name
+func isTargetSubcommand(sub string) bool {
+	switch sub {
+	case "add", "remove", "rm", "list", "ls", "registry":
+		return true
+	}
+	return false
+}
+
+// acquireWriteLock waits for any other skillshare process that is writing,
+// up to SKILLSHARE_LOCK_TIMEOUT (default 30s).
+func acquireWriteLock(cmd string) (*filelock.Lock, error) {
+	return filelock.Acquire(config.LockPath(), "skillshare "+cmd, filelock.TimeoutFromEnv(), func(h filelock.Holder) {
+		ui.Info("Waiting for %s to finish...", h)
+	})
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/install"
+	"skillshare/internal/ui"
+	appversion "skillshare/internal/version"
+)
+
+// lockFlags are the install flags that control skillshare.lock
+type lockFlags struct {
+	frozen     bool // Install exactly what the lock records and never rewrite it
+	updateLock bool // Resolve sources fresh and re-record every entry
+}
+
+func (f lockFlags) validate(sourceArg string) error {
+	if f.frozen && f.updateLock {
+		return fmt.Errorf("--frozen and --update-lock cannot be used together")
+	}
+	if f.frozen && sourceArg != "" {
+		return fmt.Errorf("--frozen installs from skillshare.lock and takes no source")
+	}
+	return nil
+}
+
+// refreshLockfile brings the lockfile in line with sourceDir after a command
+// changed it, re-recording the changed entries the command installed or
+// updated. Failures only warn: the skills themselves are already in place.
+func refreshLockfile(lockPath, sourceDir string, changed []string, all bool) {
+	if _, err := install.RefreshLockfile(lockPath, sourceDir, changed, all); err != nil {
+		ui.Warning("Failed to update %s: %v", filepath.Base(lockPath), err)
+	}
+}
+
+// lockName returns the lockfile key of a skill or tracked repo at path
+func lockName(sourceDir, path string) string {
+	rel, err := filepath.Rel(sourceDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// installFromLockfile installs every lock entry that is not yet present at
+// its locked commit and checks all entries against their recorded hashes.
+// Nothing is re-resolved and the lockfile is never written.
+func installFromLockfile(lockPath, sourceDir string, opts install.InstallOptions) (installLogSummary, error) {
+	summary := installLogSummary{
+		Source: filepath.Base(lockPath),
+		DryRun: opts.DryRun,
+	}
+	opts.SourceDir = sourceDir
+
+	lock, err := install.ReadLockfile(lockPath)
+	if err != nil {
+		return summary, err
+	}
+	if lock == nil {
+		return summary, fmt.Errorf("--frozen requires %s; run 'skillshare install --update-lock' to create it", lockPath)
+	}
+	if len(lock.Skills) == 0 {
+		ui.Info("No skills recorded in %s", filepath.Base(lockPath))
+		return summary, nil
+	}
+
+	ui.Logo(appversion.Version)
+	spinner := ui.StartSpinner(fmt.Sprintf("Installing %d locked skill(s)...", len(lock.Skills)))
+
+	for _, name := range lock.Names() {
+		entry := lock.Skills[name]
+		destPath := filepath.Join(sourceDir, filepath.FromSlash(name))
+
+		if _, err := os.Stat(destPath); err == nil {
+			if err := install.VerifyLocked(destPath, entry); err != nil {
+				ui.StepFail(name, err.Error())
+				summary.FailedSkills = append(summary.FailedSkills, name)
+			} else {
+				ui.StepDone(name, "up to date")
+			}
+			continue
+		}
+
+		if opts.DryRun {
+			ui.StepDone(name, fmt.Sprintf("would install %s", shortCommitLabel(entry)))
+			continue
+		}
+
+		installed, err := install.InstallLocked(name, entry, sourceDir, opts)
+		if err != nil {
+			ui.StepFail(name, err.Error())
+			summary.FailedSkills = append(summary.FailedSkills, name)
+			continue
+		}
+		if err := install.VerifyLocked(installed, entry); err != nil {
+			os.RemoveAll(installed)
+			ui.StepFail(name, err.Error())
+			summary.FailedSkills = append(summary.FailedSkills, name)
+			continue
+		}
+		ui.StepDone(name, fmt.Sprintf("installed %s", shortCommitLabel(entry)))
+		summary.InstalledSkills = append(summary.InstalledSkills, name)
+	}
+	summary.SkillCount = len(summary.InstalledSkills)
+
+	if len(summary.FailedSkills) > 0 {
+		spinner.Fail(fmt.Sprintf("%d skill(s) do not match %s", len(summary.FailedSkills), filepath.Base(lockPath)))
+		return summary, fmt.Errorf("frozen install failed: %d skill(s) do not match the lockfile", len(summary.FailedSkills))
+	}
+	if opts.DryRun {
+		spinner.Stop()
+		return summary, nil
+	}
+	spinner.Success(fmt.Sprintf("Installed %d skill(s) from %s", len(summary.InstalledSkills), filepath.Base(lockPath)))
+	if len(summary.InstalledSkills) > 0 {
+		fmt.Println()
+		ui.Info("Run 'skillshare sync' to distribute to all targets")
+	}
+	return summary, nil
+}
+
+// shortCommitLabel describes what a lock entry pins for progress output
+func shortCommitLabel(entry *install.LockedSkill) string {
+	if entry.Commit == "" {
+		return "(" + entry.Source + ")"
+	}
+	commit := entry.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	return "@ " + commit
+}
+
+// updateLockfileOnly re-records every installed skill in the lockfile
+// without installing anything (install --update-lock with no source)
+func updateLockfileOnly(lockPath, sourceDir string, dryRun bool) error {
+	if dryRun {
+		entries, err := install.ScanLockEntries(sourceDir)
+		if err != nil {
+			return err
+		}
+		ui.Info("[dry-run] would record %d skill(s) in %s", len(entries), lockPath)
+		return nil
+	}
+	lock, err := install.RefreshLockfile(lockPath, sourceDir, nil, true)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		ui.Info("No installed skills to lock")
+		return nil
+	}
+	ui.Success("Recorded %d skill(s) in %s", len(lock.Skills), lockPath)
+	return nil
+}
+
+// warnLockMismatch warns when a skill installed at its locked commit does not
+// have the locked content, e.g. because the lock was recorded after a local edit
+func warnLockMismatch(name, skillPath string, locked *install.LockedSkill) {
+	if locked == nil {
+		return
+	}
+	if err := install.VerifyLocked(skillPath, locked); err != nil {
+		ui.Warning("%s: %v (use --frozen to fail instead)", name, err)
+	}
+}
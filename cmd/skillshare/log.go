@@ -304,6 +304,12 @@ func formatSyncLogPairs(args map[string]any) []logDetailPair {
 	if scope, ok := logArgString(args, "scope"); ok && scope != "" {
 		pairs = append(pairs, logDetailPair{key: "scope", value: scope})
 	}
+	if watch, ok := logArgBool(args, "watch"); ok && watch {
+		pairs = append(pairs, logDetailPair{key: "watch", value: "yes"})
+	}
+	if changed, ok := logArgStringSlice(args, "changed"); ok && len(changed) > 0 {
+		pairs = append(pairs, logDetailPair{key: "changed", value: strings.Join(changed, ", ")})
+	}
 
 	return pairs
 }
@@ -563,6 +569,12 @@ func formatSyncLogDetail(args map[string]any) string {
 	if scope, ok := logArgString(args, "scope"); ok && scope != "" {
 		parts = append(parts, "scope="+scope)
 	}
+	if watch, ok := logArgBool(args, "watch"); ok && watch {
+		parts = append(parts, "watch")
+	}
+	if changed, ok := logArgStringSlice(args, "changed"); ok && len(changed) > 0 {
+		parts = append(parts, fmt.Sprintf("changed=%d", len(changed)))
+	}
 
 	if len(parts) == 0 {
 		return formatGenericLogDetail(args)
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"skillshare/internal/config"
 	"skillshare/internal/ui"
 	versioncheck "skillshare/internal/version"
 )
@@ -37,6 +38,8 @@ var commands = map[string]func([]string) error{
 	"audit":     cmdAudit,
 	"hub":       cmdHub,
 	"log":       cmdLog,
+	"config":    cmdConfig,
+	"profile":   cmdProfile,
 	"ui":        cmdUI,
 }
 
@@ -52,8 +55,21 @@ func main() {
 		os.Exit(1)
 	}
 
-	cmd := os.Args[1]
-	args := os.Args[2:]
+	argv, profile, err := extractProfileFlag(os.Args[1:])
+	if err != nil {
+		ui.Error("%v", err)
+		os.Exit(1)
+	}
+	if profile != "" {
+		config.SetProfile(profile)
+	}
+	if len(argv) == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cmd := argv[0]
+	args := argv[1:]
 
 	// Handle special commands (no error return)
 	switch cmd {
@@ -76,6 +92,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if needsWriteLock(cmd, args) {
+		lock, err := acquireWriteLock(cmd)
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+		defer lock.Release()
+	}
+
 	if err := handler(args); err != nil {
 		ui.Error("%v", err)
 		os.Exit(1)
@@ -159,7 +184,7 @@ func printUsage() {
 	cmd("target add", "<name> [path]", "Add a target (path optional in project mode)")
 	cmd("target remove", "<name>", "Unlink target and restore skills")
 	cmd("target list", "", "List all targets")
-	cmd("diff", "", "Show differences between source and targets")
+	cmd("diff", "[target]", "Show differences between source and targets")
 	fmt.Println()
 
 	// Sync & Backup
@@ -182,8 +207,10 @@ func printUsage() {
 	cmd("audit", "[name]", "Scan skills for security threats")
 	cmd("hub", "<subcommand>", "Manage hubs (add, list, remove, default, index)")
 	cmd("log", "", "View operation log")
+	cmd("config", "<subcommand>", "Manage config (get, set, unset, validate, schema, migrate)")
+	cmd("profile", "[list|use|current]", "Switch between named config profiles")
 	cmd("ui", "", "Launch web dashboard")
-	cmd("doctor", "", "Check environment and diagnose issues")
+	cmd("doctor", "[--revert|--finish]", "Check environment and diagnose issues")
 	cmd("version", "", "Show version")
 	cmd("help", "", "Show this help")
 	fmt.Println()
@@ -192,6 +219,7 @@ func printUsage() {
 	fmt.Println("GLOBAL OPTIONS")
 	fmt.Printf("  %s%-33s%s %s\n", c, "--project, -p", r, "Use project-level config in current directory")
 	fmt.Printf("  %s%-33s%s %s\n", c, "--global, -g", r, "Use global config (~/.config/skillshare)")
+	fmt.Printf("  %s%-33s%s %s\n", c, "--profile <name>", r, "Use a named config profile (or SKILLSHARE_PROFILE)")
 	fmt.Println()
 
 	// Examples
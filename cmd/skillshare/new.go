@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"strings"
 
-	"skillshare/internal/config"
 	"skillshare/internal/ui"
 )
 
@@ -17,7 +16,7 @@ func cmdNew(args []string) error {
 		return err
 	}
 
-	var skillName string
+	var skillName, layer string
 	var dryRun bool
 
 	// Parse arguments
@@ -27,6 +26,12 @@ func cmdNew(args []string) error {
 		switch {
 		case arg == "--dry-run" || arg == "-n":
 			dryRun = true
+		case arg == "--layer":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--layer requires a value")
+			}
+			i++
+			layer = rest[i]
 		case arg == "--help" || arg == "-h":
 			printNewHelp()
 			return nil
@@ -69,13 +74,21 @@ func cmdNew(args []string) error {
 	// Resolve source directory
 	var sourceDir string
 	if mode == modeProject {
+		if layer != "" {
+			return fmt.Errorf("--layer is only supported with layered global sources")
+		}
 		sourceDir = filepath.Join(cwd, ".skillshare", "skills")
 	} else {
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w (run 'skillshare init' first)", err)
 		}
 		sourceDir = cfg.Source
+		if layer != "" {
+			if sourceDir, err = cfg.ResolveLayer(layer); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Create skill directory path
@@ -222,6 +235,7 @@ Options:
   --project, -p   Create in project (.skillshare/skills/)
   --global, -g    Create in global (~/.config/skillshare/skills/)
   --dry-run, -n   Preview without creating files
+  --layer <layer> Source layer to create in (index, dir name or path)
   --help, -h      Show this help
 
 Arguments:
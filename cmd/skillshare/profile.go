@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/ui"
+)
+
+// extractProfileFlag removes --profile <name> / --profile=<name> from args.
+// It may appear before or after the command name.
+func extractProfileFlag(args []string) ([]string, string, error) {
+	rest := make([]string, 0, len(args))
+	profile := ""
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--profile":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("--profile requires a name")
+			}
+			i++
+			profile = args[i]
+		case strings.HasPrefix(arg, "--profile="):
+			profile = strings.TrimPrefix(arg, "--profile=")
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+		if profile != config.DefaultProfile {
+			if err := config.ValidateProfileName(profile); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	return rest, profile, nil
+}
+
+func cmdProfile(args []string) error {
+	if len(args) == 0 {
+		return profileList()
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return profileList()
+	case "use":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: skillshare profile use <name|default>")
+		}
+		return profileUse(args[1])
+	case "current":
+		fmt.Println(currentProfileName())
+		return nil
+	case "--help", "-h", "help":
+		printProfileHelp()
+		return nil
+	default:
+		printProfileHelp()
+		return fmt.Errorf("unknown subcommand: %s", args[0])
+	}
+}
+
+func currentProfileName() string {
+	if name := config.ActiveProfile(); name != "" {
+		return name
+	}
+	return config.DefaultProfile
+}
+
+func profileList() error {
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		return err
+	}
+
+	active := currentProfileName()
+	ui.Header("Profiles")
+
+	marker := func(name string) string {
+		if name == active {
+			return ui.Green + "*" + ui.Reset
+		}
+		return " "
+	}
+
+	fmt.Printf("  %s %-20s %s%s%s\n", marker(config.DefaultProfile), config.DefaultProfile, ui.Gray, config.BaseConfigPath(), ui.Reset)
+	for _, p := range profiles {
+		var where []string
+		if p.Inline {
+			where = append(where, "config.yaml")
+		}
+		if p.File != "" {
+			where = append(where, p.File)
+		}
+		fmt.Printf("  %s %-20s %s%s%s\n", marker(p.Name), p.Name, ui.Gray, strings.Join(where, " + "), ui.Reset)
+	}
+
+	if len(profiles) == 0 {
+		fmt.Println()
+		ui.Info("No profiles defined. Add a profiles: section to config.yaml or create %s", config.ProfilePath("<name>"))
+	}
+	return nil
+}
+
+func profileUse(name string) error {
+	if err := config.UseProfile(name); err != nil {
+		return err
+	}
+
+	ui.Success("Default profile set to %s", name)
+	if env := os.Getenv("SKILLSHARE_PROFILE"); env != "" && env != name {
+		ui.Warning("SKILLSHARE_PROFILE=%s overrides this in the current shell", env)
+	}
+	return nil
+}
+
+func printProfileHelp() {
+	fmt.Println(`Usage: skillshare profile [list|use|current]
+
+Switch between named config profiles (e.g. work / personal).
+A profile overlays source, mode, targets, ignore and audit settings on
+config.yaml, and keeps its own backups, trash and logs.
+
+Subcommands:
+  list                List profiles (default)
+  use <name>          Make <name> the default profile ("default" clears it)
+  current             Print the active profile
+
+Profiles are defined under profiles: in config.yaml or in
+profiles/<name>.yaml next to it. The active profile is chosen by
+--profile <name>, then SKILLSHARE_PROFILE, then 'profile use'.
+
+Examples:
+  skillshare profile use work
+  SKILLSHARE_PROFILE=personal skillshare sync
+  skillshare --profile work status`)
+}
@@ -14,7 +14,7 @@ type projectRuntime struct {
 }
 
 func loadProjectRuntime(root string) (*projectRuntime, error) {
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return nil, err
 	}
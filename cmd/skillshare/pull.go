@@ -24,7 +24,7 @@ func cmdPull(args []string) error {
 		}
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
@@ -136,7 +136,7 @@ func cmdPush(args []string) error {
 	start := time.Now()
 	opts := parsePushArgs(args)
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("config not found: run 'skillshare init' first")
 	}
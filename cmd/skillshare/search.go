@@ -494,7 +494,7 @@ func promptInstallFromSearch(results []search.SearchResult, isHub bool, mode run
 		return false, installFromSearchResultProject(selected, cwd)
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return false, fmt.Errorf("failed to load config: %w", err)
 	}
@@ -729,13 +729,13 @@ func looksLikeURLOrPath(v string) bool {
 // an empty HubConfig on error (graceful fallback).
 func loadHubConfig(mode runMode, cwd string) config.HubConfig {
 	if mode == modeProject {
-		pcfg, err := config.LoadProject(cwd)
+		pcfg, err := loadProjectConfig(cwd)
 		if err != nil {
 			return config.HubConfig{}
 		}
 		return pcfg.Hub
 	}
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return config.HubConfig{}
 	}
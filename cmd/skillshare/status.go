@@ -45,37 +45,78 @@ func cmdStatus(args []string) error {
 		return cmdStatusProject(cwd)
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
-	sourceSkillCount := countSourceSkills(cfg.Source)
-
+	printProfileStatus()
 	printSourceStatus(cfg)
 	printTrackedReposStatus(cfg)
-	printTargetsStatus(cfg, sourceSkillCount)
+	printTargetsStatus(cfg)
 	checkSkillVersion(cfg)
 
 	return nil
 }
 
-func printSourceStatus(cfg *config.Config) {
-	ui.Header("Source")
-	info, err := os.Stat(cfg.Source)
-	if err != nil {
-		ui.Error("%s (not found)", cfg.Source)
+// printProfileStatus shows the active profile once any profile is defined
+func printProfileStatus() {
+	name := config.ActiveProfile()
+	if name == "" {
+		profiles, _ := config.ListProfiles()
+		if len(profiles) == 0 {
+			return
+		}
+	}
+
+	ui.Header("Profile")
+	if name == "" {
+		ui.Info("%s (%s)", config.DefaultProfile, config.BaseConfigPath())
 		return
 	}
+	ui.Success("%s (%s)", name, config.ConfigPath())
+}
+
+func printSourceStatus(cfg *config.Config) {
+	layers := cfg.SourceLayers()
+	if len(layers) > 1 {
+		ui.Header("Sources")
+	} else {
+		ui.Header("Source")
+	}
+
+	for i, src := range layers {
+		info, err := os.Stat(src)
+		if err != nil {
+			ui.Error("%s (not found)", src)
+			continue
+		}
 
-	entries, _ := os.ReadDir(cfg.Source)
-	skillCount := 0
-	for _, e := range entries {
-		if e.IsDir() && !utils.IsHidden(e.Name()) {
-			skillCount++
+		entries, _ := os.ReadDir(src)
+		skillCount := 0
+		for _, e := range entries {
+			if e.IsDir() && !utils.IsHidden(e.Name()) {
+				skillCount++
+			}
+		}
+		if len(layers) > 1 {
+			ui.Success("%d. %s (%d skills, %s)", i+1, src, skillCount, info.ModTime().Format("2006-01-02 15:04"))
+		} else {
+			ui.Success("%s (%d skills, %s)", src, skillCount, info.ModTime().Format("2006-01-02 15:04"))
 		}
 	}
-	ui.Success("%s (%d skills, %s)", cfg.Source, skillCount, info.ModTime().Format("2006-01-02 15:04"))
+
+	if len(layers) < 2 {
+		return
+	}
+	_, shadowed, err := sync.DiscoverLayeredSkills(layers, cfg.Ignore...)
+	if err != nil || len(shadowed) == 0 {
+		return
+	}
+	ui.Info("%d shadowed skill(s) (a later layer wins):", len(shadowed))
+	for _, s := range shadowed {
+		ui.Info("  %s: %s overridden by %s", s.FlatName, s.Layer, s.ShadowedBy)
+	}
 }
 
 func printTrackedReposStatus(cfg *config.Config) {
@@ -89,7 +130,7 @@ func printTrackedReposStatus(cfg *config.Config) {
 		repoPath := filepath.Join(cfg.Source, repoName)
 
 		// Count skills in this repo
-		discovered, _ := sync.DiscoverSourceSkills(cfg.Source)
+		discovered, _ := sync.DiscoverSourceSkills(cfg.Source, cfg.Ignore...)
 		skillCount := 0
 		for _, d := range discovered {
 			if d.IsInRepo && strings.HasPrefix(d.RelPath, repoName+"/") {
@@ -120,22 +161,26 @@ func checkRepoDirty(repoPath string) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
-func printTargetsStatus(cfg *config.Config, sourceSkillCount int) {
+func printTargetsStatus(cfg *config.Config) {
 	ui.Header("Targets")
 	driftTotal := 0
 	for name, target := range cfg.Targets {
 		mode := getTargetMode(target.Mode, cfg.Mode)
-		statusStr, detail := getTargetStatusDetail(target, cfg.Source, mode)
+		statusStr, detail := getTargetStatusDetail(target, cfg.SourceLayers(), cfg.Ignore, mode)
 		ui.Status(name, statusStr, detail)
 
-		if mode == "merge" {
-			_, linkedCount, _ := sync.CheckStatusMerge(target.Path, cfg.Source)
-			if linkedCount < sourceSkillCount {
+		if target.OutputFormat() != config.FormatSkillDir {
+			printGeneratedDrift(sync.CheckStatusGenerated(target, cfg.SourceLayers(), cfg.Ignore...))
+		} else if mode == "merge" {
+			_, linkedCount, _ := sync.CheckStatusMerge(target, cfg.SourceLayers(), cfg.Ignore...)
+			if sourceSkillCount := countTargetSkills(target, cfg.SourceLayers(), cfg.Ignore); linkedCount < sourceSkillCount {
 				drift := sourceSkillCount - linkedCount
 				if drift > driftTotal {
 					driftTotal = drift
 				}
 			}
+		} else if mode == "copy" {
+			printCopyDrift(sync.CheckStatusCopy(target, cfg.SourceLayers(), cfg.Ignore...))
 		}
 	}
 	if driftTotal > 0 {
@@ -143,8 +188,41 @@ func printTargetsStatus(cfg *config.Config, sourceSkillCount int) {
 	}
 }
 
-func countSourceSkills(source string) int {
-	discovered, err := sync.DiscoverSourceSkills(source)
+// printCopyDrift lists copy-mode entries that a sync would change or refuse to overwrite.
+func printCopyDrift(drift *sync.CopyDrift) {
+	if len(drift.Outdated) > 0 {
+		ui.Info("  %d outdated copy(ies): %s", len(drift.Outdated), strings.Join(drift.Outdated, ", "))
+	}
+	if len(drift.Modified) > 0 {
+		ui.Warning("  %d copy(ies) edited in target (sync --force overwrites): %s",
+			len(drift.Modified), strings.Join(drift.Modified, ", "))
+	}
+	if len(drift.Orphaned) > 0 {
+		ui.Info("  %d orphan copy(ies) to prune: %s", len(drift.Orphaned), strings.Join(drift.Orphaned, ", "))
+	}
+}
+
+// printGeneratedDrift lists rendered files that a sync would change or refuse to overwrite.
+func printGeneratedDrift(drift *sync.CopyDrift) {
+	if pending := len(drift.Missing) + len(drift.Outdated); pending > 0 {
+		ui.Info("  %d file(s) to generate: %s", pending, strings.Join(append(drift.Missing, drift.Outdated...), ", "))
+	}
+	if len(drift.Modified) > 0 {
+		ui.Warning("  %d file(s) edited in target (sync --force overwrites): %s",
+			len(drift.Modified), strings.Join(drift.Modified, ", "))
+	}
+	if len(drift.Local) > 0 {
+		ui.Warning("  %d hand-written file(s) in the way (sync --force overwrites): %s",
+			len(drift.Local), strings.Join(drift.Local, ", "))
+	}
+	if len(drift.Orphaned) > 0 {
+		ui.Info("  %d orphan file(s) to prune: %s", len(drift.Orphaned), strings.Join(drift.Orphaned, ", "))
+	}
+}
+
+// countTargetSkills counts the source skills a target should receive after its include/exclude filters
+func countTargetSkills(target config.TargetConfig, sources []string, ignore []string) int {
+	discovered, err := sync.DiscoverTargetSkills(target, sources, ignore...)
 	if err != nil {
 		return 0
 	}
@@ -161,15 +239,61 @@ func getTargetMode(targetMode, globalMode string) string {
 	return "merge"
 }
 
-func getTargetStatusDetail(target config.TargetConfig, source, mode string) (string, string) {
-	if mode == "merge" {
-		return getMergeStatusDetail(target, source, mode)
+func getTargetStatusDetail(target config.TargetConfig, sources []string, ignore []string, mode string) (string, string) {
+	if format := target.OutputFormat(); format != config.FormatSkillDir {
+		return getGeneratedStatusDetail(target, sources, ignore, format)
+	}
+	switch mode {
+	case "merge":
+		return getMergeStatusDetail(target, sources, ignore, mode)
+	case "copy":
+		return getCopyStatusDetail(target, sources, ignore, mode)
+	}
+	// Symlink mode links a single source directory
+	return getSymlinkStatusDetail(target, sources[len(sources)-1], mode)
+}
+
+func getCopyStatusDetail(target config.TargetConfig, sources []string, ignore []string, mode string) (string, string) {
+	drift := sync.CheckStatusCopy(target, sources, ignore...)
+
+	switch drift.Status {
+	case sync.StatusCopied:
+		detail := fmt.Sprintf("[%s] %s (%d synced, %d local)", mode, target.Path, len(drift.Synced), len(drift.Local))
+		if drift.HasDrift() {
+			pending := len(drift.Missing) + len(drift.Outdated) + len(drift.Orphaned)
+			detail = fmt.Sprintf("[%s->needs sync] %s (%d synced, %d pending, %d edited)",
+				mode, target.Path, len(drift.Synced), pending, len(drift.Modified))
+		}
+		return "copied", detail
+	case sync.StatusLinked:
+		// Configured as copy but actually using symlink - needs resync
+		return "linked", fmt.Sprintf("[%s->needs sync] %s", mode, target.Path)
+	default:
+		return drift.Status.String(), fmt.Sprintf("[%s] %s (%d local)", mode, target.Path, len(drift.Local))
+	}
+}
+
+func getGeneratedStatusDetail(target config.TargetConfig, sources []string, ignore []string, format string) (string, string) {
+	drift := sync.CheckStatusGenerated(target, sources, ignore...)
+
+	switch drift.Status {
+	case sync.StatusGenerated:
+		detail := fmt.Sprintf("[%s] %s (%d files)", format, target.Path, len(drift.Synced))
+		if drift.HasDrift() {
+			pending := len(drift.Missing) + len(drift.Outdated) + len(drift.Orphaned)
+			detail = fmt.Sprintf("[%s->needs sync] %s (%d files, %d pending, %d edited)",
+				format, target.Path, len(drift.Synced), pending, len(drift.Modified))
+		}
+		return "generated", detail
+	case sync.StatusLinked:
+		return "linked", fmt.Sprintf("[%s->needs sync] %s", format, target.Path)
+	default:
+		return drift.Status.String(), fmt.Sprintf("[%s] %s", format, target.Path)
 	}
-	return getSymlinkStatusDetail(target, source, mode)
 }
 
-func getMergeStatusDetail(target config.TargetConfig, source, mode string) (string, string) {
-	status, linkedCount, localCount := sync.CheckStatusMerge(target.Path, source)
+func getMergeStatusDetail(target config.TargetConfig, sources []string, ignore []string, mode string) (string, string) {
+	status, linkedCount, localCount := sync.CheckStatusMerge(target, sources, ignore...)
 
 	switch status {
 	case sync.StatusMerged:
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"skillshare/internal/config"
 	"skillshare/internal/install"
 	"skillshare/internal/sync"
 	"skillshare/internal/ui"
@@ -24,11 +25,9 @@ func cmdStatusProject(root string) error {
 		return err
 	}
 
-	sourceSkillCount := countSourceSkills(runtime.sourcePath)
-
 	printProjectSourceStatus(runtime.sourcePath)
-	printProjectTrackedReposStatus(runtime.sourcePath)
-	printProjectTargetsStatus(runtime, sourceSkillCount)
+	printProjectTrackedReposStatus(runtime.sourcePath, runtime.config.Ignore)
+	printProjectTargetsStatus(runtime)
 
 	return nil
 }
@@ -51,7 +50,7 @@ func printProjectSourceStatus(sourcePath string) {
 	ui.Success(".skillshare/skills/ (%d skills, %s)", skillCount, info.ModTime().Format("2006-01-02 15:04"))
 }
 
-func printProjectTrackedReposStatus(sourcePath string) {
+func printProjectTrackedReposStatus(sourcePath string, ignore []string) {
 	trackedRepos, err := install.GetTrackedRepos(sourcePath)
 	if err != nil || len(trackedRepos) == 0 {
 		return
@@ -61,7 +60,7 @@ func printProjectTrackedReposStatus(sourcePath string) {
 	for _, repoName := range trackedRepos {
 		repoPath := filepath.Join(sourcePath, repoName)
 
-		discovered, _ := sync.DiscoverSourceSkills(sourcePath)
+		discovered, _ := sync.DiscoverSourceSkills(sourcePath, ignore...)
 		skillCount := 0
 		for _, d := range discovered {
 			if d.IsInRepo && strings.HasPrefix(d.RelPath, repoName+"/") {
@@ -80,7 +79,7 @@ func printProjectTrackedReposStatus(sourcePath string) {
 	}
 }
 
-func printProjectTargetsStatus(runtime *projectRuntime, sourceSkillCount int) {
+func printProjectTargetsStatus(runtime *projectRuntime) {
 	ui.Header("Targets (project)")
 	driftTotal := 0
 	for _, entry := range runtime.config.Targets {
@@ -95,17 +94,21 @@ func printProjectTargetsStatus(runtime *projectRuntime, sourceSkillCount int) {
 			mode = "merge"
 		}
 
-		statusStr, detail := getTargetStatusDetail(target, runtime.sourcePath, mode)
+		statusStr, detail := getTargetStatusDetail(target, []string{runtime.sourcePath}, runtime.config.Ignore, mode)
 		ui.Status(entry.Name, statusStr, detail)
 
-		if mode == "merge" {
-			_, linkedCount, _ := sync.CheckStatusMerge(target.Path, runtime.sourcePath)
-			if linkedCount < sourceSkillCount {
+		if target.OutputFormat() != config.FormatSkillDir {
+			printGeneratedDrift(sync.CheckStatusGenerated(target, []string{runtime.sourcePath}, runtime.config.Ignore...))
+		} else if mode == "merge" {
+			_, linkedCount, _ := sync.CheckStatusMerge(target, []string{runtime.sourcePath}, runtime.config.Ignore...)
+			if sourceSkillCount := countTargetSkills(target, []string{runtime.sourcePath}, runtime.config.Ignore); linkedCount < sourceSkillCount {
 				drift := sourceSkillCount - linkedCount
 				if drift > driftTotal {
 					driftTotal = drift
 				}
 			}
+		} else if mode == "copy" {
+			printCopyDrift(sync.CheckStatusCopy(target, []string{runtime.sourcePath}, runtime.config.Ignore...))
 		}
 	}
 	if driftTotal > 0 {
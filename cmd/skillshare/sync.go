@@ -1,8 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"skillshare/internal/backup"
@@ -11,7 +14,6 @@ import (
 	"skillshare/internal/sync"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
-	"skillshare/internal/utils"
 )
 
 type syncLogStats struct {
@@ -45,60 +47,72 @@ func cmdSync(args []string) error {
 
 	applyModeLabel(mode)
 
-	dryRun, force := parseSyncFlags(rest)
+	opts, err := parseSyncFlags(rest)
+	if err != nil {
+		return err
+	}
+	watchMode, interval, err := parseWatchFlags(rest)
+	if err != nil {
+		return err
+	}
+	if watchMode {
+		if opts.dryRun || opts.planJSON || opts.apply != "" {
+			return fmt.Errorf("--watch cannot be combined with --dry-run, --plan-json or --apply")
+		}
+		return cmdSyncWatch(mode, cwd, opts.force, interval)
+	}
+
+	return runSync(mode, cwd, opts, start)
+}
 
+// syncOptions are the flags of a one-off sync.
+type syncOptions struct {
+	dryRun   bool
+	force    bool
+	planJSON bool   // Print the plan as JSON instead of syncing
+	apply    string // Saved plan file to apply
+	jobs     int    // Targets synced at once
+}
+
+// runSync performs one full sync in the resolved mode and logs it.
+func runSync(mode runMode, cwd string, opts syncOptions, start time.Time) error {
 	if mode == modeProject {
-		stats, err := cmdSyncProject(cwd, dryRun, force)
+		stats, err := cmdSyncProject(cwd, opts)
+		if opts.planJSON {
+			return err
+		}
 		stats.ProjectScope = true
 		logSyncOp(config.ProjectConfigPath(cwd), stats, start, err)
 		return err
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
-	// Ensure source exists
-	if _, err := os.Stat(cfg.Source); os.IsNotExist(err) {
-		return fmt.Errorf("source directory does not exist: %s", cfg.Source)
+	// Ensure every source layer exists
+	for _, src := range cfg.SourceLayers() {
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			return fmt.Errorf("source directory does not exist: %s", src)
+		}
+	}
+
+	plan, err := buildSyncPlan(cfg.Targets, cfg.Mode, cfg.SourceLayers(), cfg.Ignore, opts)
+	if err != nil || opts.planJSON {
+		return err
 	}
 
 	// Backup targets before sync (only if not dry-run)
-	if !dryRun {
+	if !opts.dryRun {
 		backupTargetsBeforeSync(cfg)
 	}
 
 	// Check for name collisions before syncing
-	discoveredSkills, discoverErr := sync.DiscoverSourceSkills(cfg.Source)
-	if discoverErr == nil {
-		collisions := sync.CheckNameCollisions(discoveredSkills)
-		if len(collisions) > 0 {
-			ui.Header("Name conflicts detected")
-			for _, collision := range collisions {
-				ui.Warning("Skill name '%s' is defined in multiple places:", collision.Name)
-				for _, path := range collision.Paths {
-					ui.Info("  - %s", path)
-				}
-			}
-			ui.Info("CLI tools may not distinguish between them.")
-			ui.Info("Suggestion: Rename one in SKILL.md (e.g., 'repo:skillname')")
-			fmt.Println()
-		}
-	}
+	reportNameCollisions(plan.Skills())
 
 	ui.Header("Syncing skills")
-	if dryRun {
-		ui.Warning("Dry run mode - no changes will be made")
-	}
-
-	failedTargets := 0
-	for name, target := range cfg.Targets {
-		if err := syncTarget(name, target, cfg, dryRun, force); err != nil {
-			ui.Error("%s: %v", name, err)
-			failedTargets++
-		}
-	}
+	failedTargets := syncWithPlan(plan, cfg.Targets, sync.JournalDir(), opts.jobs, opts.dryRun)
 
 	var syncErr error
 	if failedTargets > 0 {
@@ -106,7 +120,7 @@ func cmdSync(args []string) error {
 	}
 
 	// Opportunistic cleanup of expired trash items
-	if !dryRun {
+	if !opts.dryRun {
 		if n, _ := trash.Cleanup(trash.TrashDir(), 0); n > 0 {
 			ui.Info("Cleaned up %d expired trash item(s)", n)
 		}
@@ -115,22 +129,106 @@ func cmdSync(args []string) error {
 	logSyncOp(config.ConfigPath(), syncLogStats{
 		Targets: len(cfg.Targets),
 		Failed:  failedTargets,
-		DryRun:  dryRun,
-		Force:   force,
+		DryRun:  opts.dryRun,
+		Force:   opts.force,
 	}, start, syncErr)
 	return syncErr
 }
 
-func parseSyncFlags(args []string) (dryRun bool, force bool) {
-	for _, arg := range args {
-		switch arg {
+func parseSyncFlags(args []string) (syncOptions, error) {
+	opts := syncOptions{jobs: sync.DefaultJobs()}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--jobs", "-j":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--jobs requires a number")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("--jobs must be a positive number, got %q", args[i])
+			}
+			opts.jobs = n
 		case "--dry-run", "-n":
-			dryRun = true
+			opts.dryRun = true
 		case "--force", "-f":
-			force = true
+			opts.force = true
+		case "--plan-json":
+			opts.planJSON = true
+		case "--apply":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--apply requires a plan file")
+			}
+			i++
+			opts.apply = args[i]
+		}
+	}
+	if opts.apply != "" && (opts.planJSON || opts.dryRun || opts.force) {
+		return opts, fmt.Errorf("--apply cannot be combined with --dry-run, --force or --plan-json (the plan records --force)")
+	}
+	return opts, nil
+}
+
+// buildSyncPlan plans the targets. With --plan-json the plan is printed;
+// with --apply the saved plan is checked against a fresh one first.
+func buildSyncPlan(targets map[string]config.TargetConfig, defaultMode string, sources, ignore []string, opts syncOptions) (*sync.Plan, error) {
+	if opts.apply == "" {
+		plan := sync.NewPlan(targets, defaultMode, sources, opts.force, ignore...)
+		if opts.planJSON {
+			data, err := json.MarshalIndent(plan, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			fmt.Println(string(data))
+		}
+		return plan, nil
+	}
+
+	saved, err := sync.ReadPlan(opts.apply)
+	if err != nil {
+		return nil, err
+	}
+	plan := sync.NewPlan(targets, defaultMode, sources, saved.Force, ignore...)
+	if err := saved.CheckCurrent(plan); err != nil {
+		return nil, fmt.Errorf("%w; run 'skillshare sync --plan-json' again", err)
+	}
+	return plan, nil
+}
+
+func reportNameCollisions(skills []sync.DiscoveredSkill) {
+	collisions := sync.CheckNameCollisions(skills)
+	if len(collisions) == 0 {
+		return
+	}
+	ui.Header("Name conflicts detected")
+	for _, collision := range collisions {
+		ui.Warning("Skill name '%s' is defined in multiple places:", collision.Name)
+		for _, path := range collision.Paths {
+			ui.Info("  - %s", path)
 		}
 	}
-	return dryRun, force
+	ui.Info("CLI tools may not distinguish between them.")
+	ui.Info("Suggestion: Rename one in SKILL.md (e.g., 'repo:skillname')")
+	fmt.Println()
+}
+
+// syncWithPlan applies the plan with up to jobs targets at once and reports
+// each target in plan order. It returns the number of targets that failed.
+func syncWithPlan(plan *sync.Plan, targets map[string]config.TargetConfig, journalDir string, jobs int, dryRun bool) int {
+	if dryRun {
+		ui.Warning("Dry run mode - no changes will be made")
+	}
+	failed := 0
+	plan.Apply(journalDir, jobs, dryRun, func(o sync.TargetOutcome) {
+		if target := targets[o.Plan.Name]; o.Plan.Mode == "symlink" && (len(target.Include) > 0 || len(target.Exclude) > 0) {
+			ui.Warning("%s: include/exclude are ignored in symlink mode (use merge or copy)", o.Plan.Name)
+		}
+		if err := reportTargetOutcome(o, dryRun); err != nil {
+			ui.Error("%s: %v", o.Plan.Name, err)
+			failed++
+		}
+	})
+	return failed
 }
 
 func logSyncOp(cfgPath string, stats syncLogStats, start time.Time, cmdErr error) {
@@ -167,43 +265,50 @@ func backupTargetsBeforeSync(cfg *config.Config) {
 	}
 }
 
-func syncTarget(name string, target config.TargetConfig, cfg *config.Config, dryRun, force bool) error {
-	// Determine mode: target-specific > global > default
-	mode := target.Mode
-	if mode == "" {
-		mode = cfg.Mode
+// applyTargetPlan applies and reports a single target plan.
+func applyTargetPlan(tp sync.TargetPlan, journalDir string, dryRun bool) error {
+	res, err := sync.ApplyTarget(tp, journalDir, dryRun)
+	return reportTargetOutcome(sync.TargetOutcome{Plan: tp, Result: res, Err: err}, dryRun)
+}
+
+// reportTargetOutcome prints what syncing one target did, or would do.
+func reportTargetOutcome(o sync.TargetOutcome, dryRun bool) error {
+	tp, res := o.Plan, o.Result
+	if dryRun {
+		for _, a := range tp.Changes() {
+			fmt.Printf("[dry-run] Would %s\n", a.Describe(tp.Path))
+		}
 	}
-	if mode == "" {
-		mode = "merge"
+	if o.Err != nil {
+		return o.Err
 	}
 
-	if mode == "merge" {
-		return syncMergeMode(name, target, cfg.Source, dryRun, force)
+	switch {
+	case tp.Format != "":
+		reportGeneratedResult(tp, res)
+	case tp.Mode == "symlink":
+		reportSymlinkResult(tp)
+	case tp.Mode == "copy":
+		reportCopyResult(tp.Name, res)
+	default:
+		reportMergeResult(tp.Name, res)
 	}
 
-	return syncSymlinkMode(name, target, cfg.Source, dryRun, force)
-}
-
-func syncMergeMode(name string, target config.TargetConfig, source string, dryRun, force bool) error {
-	result, err := sync.SyncTargetMerge(name, target, source, dryRun, force)
-	if err != nil {
-		return err
+	if res.Index != "" && !dryRun {
+		ui.Info("  Skill index updated in %s", res.Index)
 	}
-
-	// Prune orphan links (skills that no longer exist in source)
-	pruneResult, pruneErr := sync.PruneOrphanLinks(target.Path, source, dryRun)
-	if pruneErr != nil {
-		ui.Warning("%s: prune failed: %v", name, pruneErr)
+	for _, warn := range res.Warnings {
+		ui.Warning("  %s", warn)
 	}
+	return nil
+}
 
-	// Report results
-	linkedCount := len(result.Linked)
-	updatedCount := len(result.Updated)
-	skippedCount := len(result.Skipped)
-	removedCount := 0
-	if pruneResult != nil {
-		removedCount = len(pruneResult.Removed)
-	}
+func reportMergeResult(name string, res *sync.TargetResult) {
+	// Links that were already correct count as linked
+	linkedCount := len(res.Linked) + len(res.Unchanged)
+	updatedCount := len(res.Updated)
+	skippedCount := len(res.Skipped)
+	removedCount := len(res.Pruned)
 
 	if linkedCount > 0 || updatedCount > 0 || removedCount > 0 {
 		ui.Success("%s: merged (%d linked, %d local, %d updated, %d pruned)",
@@ -213,55 +318,75 @@ func syncMergeMode(name string, target config.TargetConfig, source string, dryRu
 	} else {
 		ui.Success("%s: merged (no skills)", name)
 	}
+}
 
-	// Show prune warnings
-	if pruneResult != nil {
-		for _, warn := range pruneResult.Warnings {
-			ui.Warning("  %s", warn)
-		}
+func reportCopyResult(name string, res *sync.TargetResult) {
+	copiedCount := len(res.Copied)
+	updatedCount := len(res.Updated)
+	skippedCount := len(res.Skipped)
+	removedCount := len(res.Pruned)
+
+	if copiedCount > 0 || updatedCount > 0 || removedCount > 0 {
+		ui.Success("%s: copied (%d new, %d updated, %d unchanged, %d pruned)",
+			name, copiedCount, updatedCount, len(res.Unchanged), removedCount)
+	} else if len(res.Unchanged) > 0 {
+		ui.Success("%s: copied (%d up to date)", name, len(res.Unchanged))
+	} else if skippedCount > 0 {
+		ui.Success("%s: copied (%d local skills preserved)", name, skippedCount)
+	} else {
+		ui.Success("%s: copied (no skills)", name)
 	}
 
-	return nil
+	if skippedCount > 0 {
+		ui.Warning("  %d skill(s) kept because the target copy differs from what skillshare wrote: %s",
+			skippedCount, strings.Join(res.Skipped, ", "))
+		ui.Info("  Use 'skillshare sync --force' to overwrite them")
+	}
 }
 
-func syncSymlinkMode(name string, target config.TargetConfig, source string, dryRun, force bool) error {
-	status := sync.CheckStatus(target.Path, source)
-
-	// Handle conflicts
-	if status == sync.StatusConflict && !force {
-		link, err := utils.ResolveLinkTarget(target.Path)
-		if err != nil {
-			link = "(unable to resolve target)"
-		}
-		return fmt.Errorf("conflict - symlink points to %s (use --force to override)", link)
+func reportGeneratedResult(tp sync.TargetPlan, res *sync.TargetResult) {
+	if len(res.Generated) > 0 || len(res.Updated) > 0 || len(res.Pruned) > 0 {
+		ui.Success("%s: generated %s (%d new, %d updated, %d unchanged, %d pruned)",
+			tp.Name, tp.Format, len(res.Generated), len(res.Updated), len(res.Unchanged), len(res.Pruned))
+	} else if len(res.Unchanged) > 0 {
+		ui.Success("%s: generated %s (%d up to date)", tp.Name, tp.Format, len(res.Unchanged))
+	} else {
+		ui.Success("%s: generated %s (no skills)", tp.Name, tp.Format)
 	}
 
-	if status == sync.StatusConflict && force {
-		if !dryRun {
-			os.Remove(target.Path)
-		}
+	if len(res.Skipped) > 0 {
+		ui.Warning("  %d file(s) kept because they were written or edited by hand: %s",
+			len(res.Skipped), strings.Join(res.Skipped, ", "))
+		ui.Info("  Use 'skillshare sync --force' to overwrite them")
 	}
+}
 
-	if err := sync.SyncTarget(name, target, source, dryRun); err != nil {
-		return err
+func reportSymlinkResult(tp sync.TargetPlan) {
+	changes := tp.Changes()
+	if len(changes) == 0 {
+		ui.Success("%s: already linked", tp.Name)
+		return
 	}
 
-	switch status {
-	case sync.StatusLinked:
-		ui.Success("%s: already linked", name)
-	case sync.StatusNotExist:
-		ui.Success("%s: symlink created", name)
-		ui.Warning("  Symlink mode: deleting files in %s will delete from source!", target.Path)
-		ui.Info("  Use 'skillshare target remove %s' to safely unlink", name)
-	case sync.StatusHasFiles:
-		ui.Success("%s: files migrated and linked", name)
-		ui.Warning("  Symlink mode: deleting files in %s will delete from source!", target.Path)
-		ui.Info("  Use 'skillshare target remove %s' to safely unlink", name)
-	case sync.StatusBroken:
-		ui.Success("%s: broken link fixed", name)
-	case sync.StatusConflict:
-		ui.Success("%s: conflict resolved (forced)", name)
+	switch a := changes[0]; {
+	case a.Kind == sync.ActionRelink && a.Reason == "broken symlink":
+		ui.Success("%s: broken link fixed", tp.Name)
+		return
+	case a.Kind == sync.ActionRelink && strings.HasPrefix(a.Reason, "symlink pointed to"):
+		ui.Success("%s: conflict resolved (forced)", tp.Name)
+		return
+	case a.Kind == sync.ActionRelink:
+		style := config.LinkAbsolute
+		if tp.LinkStyle == config.LinkRelative {
+			style = config.LinkRelative
+		}
+		ui.Success("%s: link rewritten as %s", tp.Name, style)
+		return
+	case a.Kind == sync.ActionMigrate:
+		ui.Success("%s: files migrated and linked", tp.Name)
+	default:
+		ui.Success("%s: symlink created", tp.Name)
 	}
-
-	return nil
+	ui.Warning("  Symlink mode: deleting files in %s will delete from source!", tp.Path)
+	ui.Info("  Use 'skillshare target remove %s' to safely unlink", tp.Name)
 }
@@ -10,10 +10,10 @@ import (
 	"skillshare/internal/ui"
 )
 
-func cmdSyncProject(root string, dryRun, force bool) (syncLogStats, error) {
+func cmdSyncProject(root string, opts syncOptions) (syncLogStats, error) {
 	stats := syncLogStats{
-		DryRun:       dryRun,
-		Force:        force,
+		DryRun:       opts.dryRun,
+		Force:        opts.force,
 		ProjectScope: true,
 	}
 
@@ -33,55 +33,28 @@ func cmdSyncProject(root string, dryRun, force bool) (syncLogStats, error) {
 		return stats, fmt.Errorf("source directory does not exist: %s", runtime.sourcePath)
 	}
 
-	discoveredSkills, discoverErr := sync.DiscoverSourceSkills(runtime.sourcePath)
-	if discoverErr == nil {
-		collisions := sync.CheckNameCollisions(discoveredSkills)
-		if len(collisions) > 0 {
-			ui.Header("Name conflicts detected")
-			for _, collision := range collisions {
-				ui.Warning("Skill name '%s' is defined in multiple places:", collision.Name)
-				for _, path := range collision.Paths {
-					ui.Info("  - %s", path)
-				}
-			}
-			ui.Info("CLI tools may not distinguish between them.")
-			ui.Info("Suggestion: Rename one in SKILL.md (e.g., 'repo:skillname')")
-			fmt.Println()
+	targets := make(map[string]config.TargetConfig, len(runtime.config.Targets))
+	var missing []string
+	for _, entry := range runtime.config.Targets {
+		if target, ok := runtime.targets[entry.Name]; ok {
+			targets[entry.Name] = target
+		} else {
+			missing = append(missing, entry.Name)
 		}
 	}
 
-	ui.Header("Syncing skills (project)")
-	if dryRun {
-		ui.Warning("Dry run mode - no changes will be made")
+	plan, err := buildSyncPlan(targets, "merge", []string{runtime.sourcePath}, runtime.config.Ignore, opts)
+	if err != nil || opts.planJSON {
+		return stats, err
 	}
 
-	failedTargets := 0
-	for _, entry := range runtime.config.Targets {
-		name := entry.Name
-		target, ok := runtime.targets[name]
-		if !ok {
-			ui.Error("%s: target not found", name)
-			failedTargets++
-			continue
-		}
+	reportNameCollisions(plan.Skills())
 
-		mode := target.Mode
-		if mode == "" {
-			mode = "merge"
-		}
-
-		if mode == "symlink" {
-			if err := syncSymlinkMode(name, target, runtime.sourcePath, dryRun, force); err != nil {
-				ui.Error("%s: %v", name, err)
-				failedTargets++
-			}
-		} else {
-			if err := syncMergeMode(name, target, runtime.sourcePath, dryRun, force); err != nil {
-				ui.Error("%s: %v", name, err)
-				failedTargets++
-			}
-		}
+	ui.Header("Syncing skills (project)")
+	for _, name := range missing {
+		ui.Error("%s: target not found", name)
 	}
+	failedTargets := len(missing) + syncWithPlan(plan, targets, sync.ProjectJournalDir(root), opts.jobs, opts.dryRun)
 
 	stats.Failed = failedTargets
 	if failedTargets > 0 {
@@ -89,7 +62,7 @@ func cmdSyncProject(root string, dryRun, force bool) (syncLogStats, error) {
 	}
 
 	// Opportunistic cleanup of expired trash items
-	if !dryRun {
+	if !opts.dryRun {
 		if n, _ := trash.Cleanup(trash.ProjectTrashDir(root), 0); n > 0 {
 			ui.Info("Cleaned up %d expired trash item(s)", n)
 		}
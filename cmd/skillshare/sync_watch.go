@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+	"skillshare/internal/ui"
+	"skillshare/internal/watch"
+)
+
+// watchSetup is what a watch session syncs: targets with resolved modes,
+// source layers and ignore patterns.
+type watchSetup struct {
+	configPath string
+	scope      string
+	targets    map[string]config.TargetConfig
+	sources    []string
+	ignore     []string
+	journalDir string
+}
+
+func parseWatchFlags(args []string) (enabled bool, interval time.Duration, err error) {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--watch", "-w":
+			enabled = true
+		case "--interval":
+			if i+1 >= len(args) {
+				return false, 0, fmt.Errorf("--interval requires a duration (e.g. 2s)")
+			}
+			i++
+			interval, err = time.ParseDuration(args[i])
+			if err != nil || interval <= 0 {
+				return false, 0, fmt.Errorf("invalid --interval %q (e.g. 500ms, 2s)", args[i])
+			}
+		}
+	}
+	if interval > 0 && !enabled {
+		return false, 0, fmt.Errorf("--interval requires --watch")
+	}
+	return enabled, interval, nil
+}
+
+func loadWatchSetup(mode runMode, cwd string) (*watchSetup, error) {
+	if mode == modeProject {
+		runtime, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return nil, err
+		}
+		targets := make(map[string]config.TargetConfig, len(runtime.config.Targets))
+		for _, entry := range runtime.config.Targets {
+			target, ok := runtime.targets[entry.Name]
+			if !ok {
+				continue
+			}
+			if target.Mode == "" {
+				target.Mode = "merge"
+			}
+			targets[entry.Name] = target
+		}
+		return &watchSetup{
+			configPath: config.ProjectConfigPath(cwd),
+			scope:      "project",
+			targets:    targets,
+			sources:    []string{runtime.sourcePath},
+			ignore:     runtime.config.Ignore,
+			journalDir: sync.ProjectJournalDir(cwd),
+		}, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]config.TargetConfig, len(cfg.Targets))
+	for name, target := range cfg.Targets {
+		if target.Mode == "" {
+			target.Mode = cfg.Mode
+		}
+		if target.Mode == "" {
+			target.Mode = "merge"
+		}
+		targets[name] = target
+	}
+	return &watchSetup{
+		configPath: config.ConfigPath(),
+		scope:      "global",
+		targets:    targets,
+		sources:    cfg.SourceLayers(),
+		ignore:     cfg.Ignore,
+		journalDir: sync.JournalDir(),
+	}, nil
+}
+
+// cmdSyncWatch runs a full sync, then resyncs changed skills until
+// interrupted. The write lock is taken per cycle, so other commands can run
+// while the watcher is idle. Config changes apply after a restart.
+func cmdSyncWatch(mode runMode, cwd string, force bool, interval time.Duration) error {
+	lock, err := acquireWriteLock("sync --watch")
+	if err != nil {
+		return err
+	}
+	err = runSync(mode, cwd, syncOptions{force: force, jobs: sync.DefaultJobs()}, time.Now())
+	lock.Release()
+	if err != nil {
+		return err
+	}
+
+	setup, err := loadWatchSetup(mode, cwd)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println()
+	ui.Info("Watching %s for changes (Ctrl+C to stop)", strings.Join(setup.sources, ", "))
+
+	w := &watch.Watcher{
+		Dirs:     setup.sources,
+		Scan:     func() (watch.Snapshot, error) { return watch.Scan(setup.sources, setup.ignore...) },
+		Interval: interval,
+		OnError:  func(err error) { ui.Warning("scan failed: %v", err) },
+	}
+	err = w.Run(ctx, func(changed []string) error {
+		return runWatchCycle(setup, changed)
+	})
+	if err != nil {
+		return err
+	}
+	ui.Info("Stopped watching")
+	return nil
+}
+
+// runWatchCycle resyncs one burst of changes under the write lock and logs it.
+func runWatchCycle(setup *watchSetup, changed []string) error {
+	start := time.Now()
+	lock, err := acquireWriteLock("sync --watch")
+	if err != nil {
+		ui.Warning("%v; will retry", err)
+		return err
+	}
+	results := watch.Resync(setup.targets, setup.sources, changed, setup.ignore, setup.journalDir)
+	lock.Release()
+
+	ui.Info("[%s] %d skill(s) changed: %s", start.Format("15:04:05"), len(changed), strings.Join(changed, ", "))
+	failed := 0
+	for _, res := range results {
+		switch {
+		case res.Err != nil:
+			ui.Error("%s: %v", res.Target, res.Err)
+			failed++
+		case len(res.Synced) > 0 || len(res.Pruned) > 0:
+			ui.Success("%s: %d synced, %d pruned", res.Target, len(res.Synced), len(res.Pruned))
+		}
+		for _, warn := range res.Warnings {
+			ui.Warning("  %s", warn)
+		}
+	}
+
+	var cycleErr error
+	if failed > 0 {
+		cycleErr = fmt.Errorf("%d target(s) failed to sync", failed)
+	}
+	e := oplog.NewEntry("sync", statusFromErr(cycleErr), time.Since(start))
+	e.Args = map[string]any{
+		"watch":          true,
+		"changed":        changed,
+		"targets_total":  len(setup.targets),
+		"targets_failed": failed,
+		"scope":          setup.scope,
+	}
+	if cycleErr != nil {
+		e.Message = cycleErr.Error()
+	}
+	oplog.Write(setup.configPath, oplog.OpsFile, e) //nolint:errcheck
+	return nil
+}
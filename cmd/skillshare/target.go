@@ -21,7 +21,7 @@ func cmdTarget(args []string) error {
 	}
 
 	if len(rest) < 1 {
-		return fmt.Errorf("usage: skillshare target <add|remove|list|name> [options]")
+		return fmt.Errorf("usage: skillshare target <add|remove|list|registry|name> [options]")
 	}
 
 	cwd, err := os.Getwd()
@@ -61,6 +61,8 @@ func cmdTarget(args []string) error {
 			return targetListProject(cwd)
 		}
 		return targetList()
+	case "registry":
+		return targetRegistry(subargs, mode)
 	default:
 		// Assume it's a target name - show info or modify settings
 		if mode == modeProject {
@@ -71,7 +73,7 @@ func cmdTarget(args []string) error {
 }
 
 func printTargetHelp() {
-	fmt.Println(`Usage: skillshare target <add|remove|list|name> [options]
+	fmt.Println(`Usage: skillshare target <add|remove|list|registry|name> [options]
 
 Manage target skill directories.
 
@@ -80,6 +82,7 @@ Subcommands:
   remove <name>          Remove a target
   remove --all           Remove all targets
   list                   List configured targets
+  registry list          List known targets and where each is defined
   <name>                 Show target info
 
 Options:
@@ -91,6 +94,7 @@ Examples:
   skillshare target add my-ide .my-ide/skills
   skillshare target remove cursor
   skillshare target list
+  skillshare target registry list
   skillshare target cursor`)
 }
 
@@ -140,7 +144,7 @@ func targetAdd(args []string) error {
 		}
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
@@ -246,7 +250,7 @@ func targetRemove(args []string) error {
 		return err
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
@@ -339,11 +343,10 @@ func unlinkMergeMode(targetPath, sourcePath string) error {
 
 		// Check if it's a symlink pointing to source
 		if info.Mode()&os.ModeSymlink != 0 {
-			link, _ := os.Readlink(skillPath)
 			sourceSkillPath := filepath.Join(sourcePath, entry.Name())
 
-			// Check if symlink points to our source
-			absLink, _ := filepath.Abs(link)
+			// Check if symlink points to our source (relative links resolve from the target)
+			absLink, _ := utils.ResolveLinkTarget(skillPath)
 			absSource, _ := filepath.Abs(sourceSkillPath)
 
 			if utils.PathsEqual(absLink, absSource) {
@@ -356,11 +359,14 @@ func unlinkMergeMode(targetPath, sourcePath string) error {
 		}
 	}
 
+	// Copies stay behind as plain local skills
+	os.Remove(filepath.Join(targetPath, sync.ManifestFile))
+
 	return nil
 }
 
 func targetList() error {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
@@ -374,11 +380,15 @@ func targetList() error {
 		fmt.Printf("  %-12s %s (%s)\n", name, target.Path, mode)
 	}
 
+	for _, name := range cfg.InactiveTargets() {
+		fmt.Printf("  %s%-12s (inactive on this machine)%s\n", ui.Gray, name, ui.Reset)
+	}
+
 	return nil
 }
 
 func targetInfo(name string, args []string) error {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
@@ -394,7 +404,7 @@ func targetInfo(name string, args []string) error {
 		switch args[i] {
 		case "--mode", "-m":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--mode requires a value (merge or symlink)")
+				return fmt.Errorf("--mode requires a value (merge, symlink or copy)")
 			}
 			newMode = args[i+1]
 			i++
@@ -411,8 +421,8 @@ func targetInfo(name string, args []string) error {
 }
 
 func updateTargetMode(cfg *config.Config, name string, target config.TargetConfig, newMode string) error {
-	if newMode != "merge" && newMode != "symlink" {
-		return fmt.Errorf("invalid mode '%s'. Use 'merge' or 'symlink'", newMode)
+	if newMode != "merge" && newMode != "symlink" && newMode != "copy" {
+		return fmt.Errorf("invalid mode '%s'. Use 'merge', 'symlink' or 'copy'", newMode)
 	}
 
 	oldMode := target.Mode
@@ -449,7 +459,18 @@ func showTargetInfo(cfg *config.Config, name string, target config.TargetConfig)
 	ui.Header(fmt.Sprintf("Target: %s", name))
 	fmt.Printf("  Path:   %s\n", target.Path)
 	fmt.Printf("  Mode:   %s\n", mode)
+	printTargetFilters(target)
 	fmt.Printf("  Status: %s\n", status)
 
 	return nil
 }
+
+// printTargetFilters shows a target's include/exclude patterns, if any
+func printTargetFilters(target config.TargetConfig) {
+	if len(target.Include) > 0 {
+		fmt.Printf("  Include: %s\n", strings.Join(target.Include, ", "))
+	}
+	if len(target.Exclude) > 0 {
+		fmt.Printf("  Exclude: %s\n", strings.Join(target.Exclude, ", "))
+	}
+}
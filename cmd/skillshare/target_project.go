@@ -79,7 +79,7 @@ func targetAddProject(args []string, root string) error {
 		}
 	}
 
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return err
 	}
@@ -133,7 +133,7 @@ func targetRemoveProject(args []string, root string) error {
 		}
 	}
 
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return err
 	}
@@ -256,7 +256,7 @@ func targetListProject(root string) error {
 		}
 	}
 
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return err
 	}
@@ -292,14 +292,14 @@ func targetInfoProject(name string, args []string, root string) error {
 		switch args[i] {
 		case "--mode", "-m":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--mode requires a value (merge or symlink)")
+				return fmt.Errorf("--mode requires a value (merge, symlink or copy)")
 			}
 			newMode = args[i+1]
 			i++
 		}
 	}
 
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return err
 	}
@@ -343,12 +343,13 @@ func targetInfoProject(name string, args []string, root string) error {
 	ui.Header(fmt.Sprintf("Target: %s", name))
 	fmt.Printf("  Path:   %s\n", projectTargetDisplayPath(targetEntry))
 	fmt.Printf("  Mode:   %s\n", displayMode)
+	printTargetFilters(target)
 
 	if mode == "symlink" {
 		status := sync.CheckStatus(target.Path, sourcePath)
 		fmt.Printf("  Status: %s\n", status)
 	} else {
-		status, linked, local := sync.CheckStatusMerge(target.Path, sourcePath)
+		status, linked, local := sync.CheckStatusMerge(target, []string{sourcePath}, cfg.Ignore...)
 		fmt.Printf("  Status: %s (%d shared, %d local)\n", status, linked, local)
 	}
 
@@ -356,8 +357,8 @@ func targetInfoProject(name string, args []string, root string) error {
 }
 
 func updateTargetModeProject(cfg *config.ProjectConfig, idx int, newMode string, root string) error {
-	if newMode != "merge" && newMode != "symlink" {
-		return fmt.Errorf("invalid mode '%s'. Use 'merge' or 'symlink'", newMode)
+	if newMode != "merge" && newMode != "symlink" && newMode != "copy" {
+		return fmt.Errorf("invalid mode '%s'. Use 'merge', 'symlink' or 'copy'", newMode)
 	}
 
 	entry := &cfg.Targets[idx]
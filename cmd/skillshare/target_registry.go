@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/config"
+	"skillshare/internal/ui"
+)
+
+// targetRegistry handles `target registry <list>`
+func targetRegistry(args []string, mode runMode) error {
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
+		printTargetRegistryHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return targetRegistryList(mode)
+	default:
+		return fmt.Errorf("unknown registry subcommand: %s", args[0])
+	}
+}
+
+func targetRegistryList(mode runMode) error {
+	entries := config.TargetRegistry()
+
+	type row struct{ name, path, origin string }
+	var rows []row
+	maxNameLen := 0
+	for _, e := range entries {
+		name, path := e.GlobalName, e.GlobalPath
+		if mode == modeProject {
+			name, path = e.ProjectName, e.ProjectPath
+		}
+		if name == "" || path == "" {
+			continue
+		}
+		origin := registryOriginLabel(e.Origin)
+		if e.Format != "" {
+			origin += ", format " + e.Format
+		}
+		rows = append(rows, row{name, path, origin})
+		if len(name) > maxNameLen {
+			maxNameLen = len(name)
+		}
+	}
+
+	if mode == modeProject {
+		ui.Header("Known targets (project)")
+	} else {
+		ui.Header("Known targets")
+	}
+	for _, r := range rows {
+		format := fmt.Sprintf("  %%-%ds  %%s  %s(%%s)%s\n", maxNameLen, ui.Gray, ui.Reset)
+		fmt.Printf(format, r.name, r.path, r.origin)
+	}
+
+	for _, err := range config.TargetRegistryErrors() {
+		ui.Warning("%v", err)
+	}
+
+	fmt.Println()
+	ui.Info("Add or override targets in %s", config.UserTargetsPath())
+	if mode == modeProject {
+		ui.Info("Project-only targets go in %s", filepath.Join(".skillshare", config.TargetsFileName))
+	}
+	return nil
+}
+
+// registryOriginLabel shortens registry file paths for display
+func registryOriginLabel(origin string) string {
+	if origin == config.TargetOriginBuiltin {
+		return origin
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, origin); err == nil && !filepath.IsAbs(rel) && rel[0] != '.' {
+			return rel
+		}
+	}
+	return origin
+}
+
+func printTargetRegistryHelp() {
+	fmt.Println(`Usage: skillshare target registry list [options]
+
+Show every known target (built-in and user-defined) and where it was defined.
+
+User targets are read from targets.yaml next to config.yaml, and from
+.skillshare/targets.yaml in project mode. Entries are merged over the
+built-in list by name:
+
+  targets:
+    - name: in-house-agent          # New target (name for both modes)
+      global_path: ~/.in-house/skills
+      project_path: .in-house/skills
+    - name: claude                  # Override a built-in path
+      global_path: ~/.claude-fork/skills
+
+Options:
+  --project, -p   Show project target names and paths
+  --global, -g    Show global target names and paths
+  --help, -h      Show this help`)
+}
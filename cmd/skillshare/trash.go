@@ -6,7 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"skillshare/internal/config"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
 )
@@ -206,7 +205,7 @@ func resolveSourceDir(mode runMode, cwd string) (string, error) {
 	if mode == modeProject {
 		return fmt.Sprintf("%s/.skillshare/skills", cwd), nil
 	}
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return "", fmt.Errorf("failed to load config: %w", err)
 	}
@@ -21,6 +21,7 @@ func cmdUI(args []string) error {
 	port := "19420"
 	host := "127.0.0.1"
 	noOpen := false
+	watchSources := false
 
 	for i := 0; i < len(rest); i++ {
 		switch rest[i] {
@@ -40,6 +41,8 @@ func cmdUI(args []string) error {
 			}
 		case "--no-open":
 			noOpen = true
+		case "--watch":
+			watchSources = true
 		default:
 			return fmt.Errorf("unknown flag: %s", rest[i])
 		}
@@ -61,12 +64,12 @@ func cmdUI(args []string) error {
 	url := "http://" + addr
 
 	if mode == modeProject {
-		return startProjectUI(addr, url, noOpen)
+		return startProjectUI(addr, url, noOpen, watchSources)
 	}
-	return startGlobalUI(addr, url, noOpen)
+	return startGlobalUI(addr, url, noOpen, watchSources)
 }
 
-func startProjectUI(addr, url string, noOpen bool) error {
+func startProjectUI(addr, url string, noOpen, watchSources bool) error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get working directory: %w", err)
@@ -86,6 +89,7 @@ func startProjectUI(addr, url string, noOpen bool) error {
 		Source:  rt.sourcePath,
 		Targets: rt.targets,
 		Mode:    "merge",
+		Ignore:  rt.config.Ignore,
 	}
 
 	if !noOpen {
@@ -94,10 +98,16 @@ func startProjectUI(addr, url string, noOpen bool) error {
 	}
 
 	srv := server.NewProject(cfg, rt.config, cwd, addr)
+	if watchSources {
+		if err := srv.StartWatch(); err != nil {
+			return err
+		}
+		ui.Info("Watching %s for changes", rt.sourcePath)
+	}
 	return srv.Start()
 }
 
-func startGlobalUI(addr, url string, noOpen bool) error {
+func startGlobalUI(addr, url string, noOpen, watchSources bool) error {
 	cfg, err := loadUIConfig()
 	if err != nil {
 		return err
@@ -109,11 +119,17 @@ func startGlobalUI(addr, url string, noOpen bool) error {
 	}
 
 	srv := server.New(cfg, addr)
+	if watchSources {
+		if err := srv.StartWatch(); err != nil {
+			return err
+		}
+		ui.Info("Watching %s for changes", strings.Join(cfg.SourceLayers(), ", "))
+	}
 	return srv.Start()
 }
 
 func loadUIConfig() (*config.Config, error) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("skillshare is not initialized: run 'skillshare init' first")
 	}
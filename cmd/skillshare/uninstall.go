@@ -6,12 +6,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"skillshare/internal/config"
 	"skillshare/internal/install"
 	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
 )
@@ -177,6 +180,37 @@ func checkTrackedRepoStatus(target *uninstallTarget, force bool) error {
 	return nil
 }
 
+// checkDependents refuses to remove a skill that installed skills in any
+// source layer require, unless forced, in which case it only warns. In a dry
+// run it only warns.
+func checkDependents(layers []string, target *uninstallTarget, force, dryRun bool) error {
+	var dependents []string
+	for _, layer := range layers {
+		graph, err := install.DependencyGraph(layer)
+		if err != nil {
+			ui.Warning("Could not check dependents: %v", err)
+			continue
+		}
+		for _, skill := range install.Dependents(graph, target.name) {
+			if !slices.Contains(dependents, skill) {
+				dependents = append(dependents, skill)
+			}
+		}
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+	sort.Strings(dependents)
+
+	if force || dryRun {
+		ui.Warning("Required by: %s", strings.Join(dependents, ", "))
+		return nil
+	}
+	ui.Error("%s is required by: %s", target.name, strings.Join(dependents, ", "))
+	ui.Info("Use --force to uninstall anyway, or uninstall those skills first")
+	return fmt.Errorf("required by %d installed skill(s), use --force to override", len(dependents))
+}
+
 // confirmUninstall prompts user for confirmation
 func confirmUninstall(target *uninstallTarget) (bool, error) {
 	prompt := "Are you sure you want to uninstall this skill?"
@@ -220,6 +254,8 @@ func performUninstall(target *uninstallTarget, cfg *config.Config) error {
 		ui.Success("Uninstalled: %s", target.name)
 	}
 	ui.Info("Moved to trash (7 days): %s", trashPath)
+	removeFromTargets(cfg.Targets, target.name, target.path, false)
+	refreshLockfile(config.LockfilePath(), cfg.Source, nil, false)
 	if meta != nil && meta.Source != "" {
 		ui.Info("Reinstall: skillshare install %s", meta.Source)
 	}
@@ -272,7 +308,7 @@ func cmdUninstall(args []string) error {
 		return parseErr
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -284,6 +320,10 @@ func cmdUninstall(args []string) error {
 
 	displayUninstallInfo(target)
 
+	if err := checkDependents(cfg.SourceLayers(), target, opts.force, opts.dryRun); err != nil {
+		return err
+	}
+
 	// Check for uncommitted changes (skip in dry-run)
 	if !opts.dryRun {
 		if err := checkTrackedRepoStatus(target, opts.force); err != nil {
@@ -297,6 +337,7 @@ func cmdUninstall(args []string) error {
 		if target.isTrackedRepo {
 			ui.Warning("[dry-run] would remove %s from .gitignore", target.name)
 		}
+		removeFromTargets(cfg.Targets, target.name, target.path, true)
 		if meta, err := install.ReadMeta(target.path); err == nil && meta != nil && meta.Source != "" {
 			ui.Info("[dry-run] Reinstall: skillshare install %s", meta.Source)
 		}
@@ -349,13 +390,16 @@ Remove a skill or tracked repository from the source directory.
 Skills are moved to trash and kept for 7 days before automatic cleanup.
 If the skill was installed from a remote source, a reinstall command is shown.
 
+Skills that other installed skills require (see 'skillshare list --tree')
+are kept unless --force is given.
+
 For tracked repositories (_repo-name):
   - Checks for uncommitted changes (requires --force to override)
   - Automatically removes the entry from .gitignore
   - The _ prefix is optional (automatically detected)
 
 Options:
-  --force, -f     Skip confirmation and ignore uncommitted changes
+  --force, -f     Skip confirmation, ignore uncommitted changes and dependents
   --dry-run, -n   Preview without making changes
   --project, -p   Use project-level config in current directory
   --global, -g    Use global config (~/.config/skillshare)
@@ -368,3 +412,31 @@ Examples:
   skillshare uninstall team-repo             # _ prefix is optional
   skillshare uninstall _team-repo --force    # Force remove with uncommitted changes`)
 }
+
+// removeFromTargets removes the links and copies sync made of the skill at
+// relPath from every target, going by each target's manifest. Copies edited
+// in a target are left for the user.
+func removeFromTargets(targets map[string]config.TargetConfig, relPath, skillPath string, dryRun bool) {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		removed, kept, err := sync.RemoveManaged(targets[name].Path, relPath, skillPath, dryRun)
+		if err != nil {
+			ui.Warning("%s: %v", name, err)
+		}
+		for _, entry := range removed {
+			if dryRun {
+				ui.Warning("[dry-run] would remove %s from %s", entry, name)
+			} else {
+				ui.Info("Removed %s from %s", entry, name)
+			}
+		}
+		for _, entry := range kept {
+			ui.Warning("%s: %s has local edits, kept", name, entry)
+		}
+	}
+}
@@ -53,9 +53,17 @@ func cmdUninstallProject(args []string, root string) error {
 
 	isTracked := install.IsGitRepo(skillPath)
 
+	target := &uninstallTarget{name: skillName, path: skillPath, isTrackedRepo: isTracked}
+	if err := checkDependents([]string{sourceDir}, target, opts.force, opts.dryRun); err != nil {
+		return err
+	}
+
 	if opts.dryRun {
 		ui.Warning("[dry-run] would move to trash: %s", skillPath)
 		ui.Warning("[dry-run] would update .skillshare/config.yaml and .skillshare/.gitignore")
+		if runtime, err := loadProjectRuntime(root); err == nil {
+			removeFromTargets(runtime.targets, skillName, skillPath, true)
+		}
 		if meta, err := install.ReadMeta(skillPath); err == nil && meta != nil && meta.Source != "" {
 			ui.Info("[dry-run] Reinstall: skillshare install %s --project", meta.Source)
 		}
@@ -90,7 +98,7 @@ func cmdUninstallProject(args []string, root string) error {
 		return fmt.Errorf("failed to move to trash: %w", err)
 	}
 
-	cfg, err := config.LoadProject(root)
+	cfg, err := loadProjectConfig(root)
 	if err != nil {
 		return err
 	}
@@ -109,6 +117,7 @@ func cmdUninstallProject(args []string, root string) error {
 	if _, err := install.RemoveFromGitIgnore(filepath.Join(root, ".skillshare"), filepath.Join("skills", skillName)); err != nil {
 		ui.Warning("Could not update .skillshare/.gitignore: %v", err)
 	}
+	refreshLockfile(config.ProjectLockfilePath(root), sourceDir, nil, false)
 
 	if isTracked {
 		ui.Success("Uninstalled tracked repository: %s", skillName)
@@ -116,10 +125,13 @@ func cmdUninstallProject(args []string, root string) error {
 		ui.Success("Uninstalled: %s", skillName)
 	}
 	ui.Info("Moved to trash (7 days): %s", trashPath)
+	if targets, err := config.ResolveProjectTargets(root, cfg); err == nil {
+		removeFromTargets(targets, skillName, skillPath, false)
+	}
 	if meta != nil && meta.Source != "" {
 		ui.Info("Reinstall: skillshare install %s --project", meta.Source)
 	}
-	ui.Info("Run 'skillshare sync' to clean up symlinks")
+	ui.Info("Run 'skillshare sync' to update all targets")
 
 	// Opportunistic cleanup of expired trash items
 	if n, _ := trash.Cleanup(trash.ProjectTrashDir(root), 0); n > 0 {
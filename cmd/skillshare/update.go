@@ -47,6 +47,7 @@ func cmdUpdate(args []string) error {
 	var updateAll bool
 	var dryRun bool
 	var force bool
+	var pin pinUpdate
 
 	// Parse arguments
 	for i := 0; i < len(rest); i++ {
@@ -54,6 +55,14 @@ func cmdUpdate(args []string) error {
 		switch {
 		case arg == "--all" || arg == "-a":
 			updateAll = true
+		case arg == "--to":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--to requires a ref")
+			}
+			i++
+			pin.to = rest[i]
+		case arg == "--unpin":
+			pin.unpin = true
 		case arg == "--dry-run" || arg == "-n":
 			dryRun = true
 		case arg == "--force" || arg == "-f":
@@ -75,20 +84,30 @@ func cmdUpdate(args []string) error {
 		printUpdateHelp()
 		return fmt.Errorf("specify a skill or repo name, or use --all")
 	}
+	if err := pin.validate(updateAll); err != nil {
+		return err
+	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
+	var changed []string
+	if !dryRun {
+		defer func() { refreshLockfile(config.LockfilePath(), cfg.Source, changed, false) }()
+	}
 
 	if updateAll {
-		err = updateAllTrackedRepos(cfg, dryRun, force)
+		changed, err = updateAllTrackedRepos(cfg, dryRun, force)
 		logUpdateOp(config.ConfigPath(), []string{"--all"}, start, err)
 		return err
 	}
 
 	// Determine if it's a tracked repo or regular skill
-	err = updateSkillOrRepo(cfg, name, dryRun, force)
+	resolved, err := updateSkillOrRepo(cfg, name, dryRun, force, pin)
+	if err == nil {
+		changed = []string{filepath.ToSlash(resolved)}
+	}
 	logUpdateOp(config.ConfigPath(), []string{name}, start, err)
 	return err
 }
@@ -104,6 +123,67 @@ func logUpdateOp(cfgPath string, args []string, start time.Time, cmdErr error) {
 	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
 }
 
+// pinUpdate moves or drops a skill's pinned ref (--to, --unpin). Without
+// either, update keeps the skill at its current pin.
+type pinUpdate struct {
+	to    string
+	unpin bool
+}
+
+func (p pinUpdate) isSet() bool {
+	return p.to != "" || p.unpin
+}
+
+func (p pinUpdate) validate(updateAll bool) error {
+	if p.to != "" && p.unpin {
+		return fmt.Errorf("--to and --unpin cannot be used together")
+	}
+	if p.isSet() && updateAll {
+		return fmt.Errorf("--to and --unpin apply to a single skill, not --all")
+	}
+	return install.ValidateRef(p.to)
+}
+
+// updateTracked updates a tracked repo with the pin change applied. The
+// new pin is recorded first so the update checks it out, and the old one
+// is put back if that fails.
+func (p pinUpdate) updateTracked(repoPath string, force bool) (*git.UpdateInfo, error) {
+	if !p.isSet() {
+		return git.UpdateTracked(repoPath, force)
+	}
+	prev := git.PinnedRef(repoPath)
+	if err := git.SetPinnedRef(repoPath, p.to); err != nil {
+		return nil, err
+	}
+	info, err := git.UpdateTracked(repoPath, force)
+	if err != nil {
+		git.SetPinnedRef(repoPath, prev) //nolint:errcheck
+	}
+	return info, err
+}
+
+// describe names what updating a tracked repo will do, for dry runs.
+func (p pinUpdate) describe(repoPath string) string {
+	switch ref := git.PinnedRef(repoPath); {
+	case p.to != "":
+		return "check out " + p.to
+	case p.unpin:
+		return "drop the pin and check out the default branch"
+	case ref != "":
+		return "check out " + ref
+	default:
+		return "git pull"
+	}
+}
+
+// apply returns the source to reinstall from with the pin change applied.
+func (p pinUpdate) apply(source *install.Source) (*install.Source, error) {
+	if !p.isSet() {
+		return source, nil
+	}
+	return source.WithRef(p.to)
+}
+
 // updateResult tracks the result of an update operation
 type updateResult struct {
 	updated int
@@ -127,18 +207,13 @@ func updateTrackedRepoQuick(repo, repoPath, progress string, dryRun, force bool)
 	}
 
 	if dryRun {
-		ui.ListItem("info", repo, "[dry-run] would git pull")
+		ui.ListItem("info", repo, "[dry-run] would "+pinUpdate{}.describe(repoPath))
 		return false, nil
 	}
 
 	spinner := ui.StartSpinner(fmt.Sprintf("%s Updating %s...", progress, repo))
 
-	var info *git.UpdateInfo
-	if force {
-		info, err = git.ForcePull(repoPath)
-	} else {
-		info, err = git.Pull(repoPath)
-	}
+	info, err := git.UpdateTracked(repoPath, force)
 	if err != nil {
 		spinner.Warn(fmt.Sprintf("%s %v", repo, err))
 		return false, nil
@@ -153,7 +228,7 @@ func updateTrackedRepoQuick(repo, repoPath, progress string, dryRun, force bool)
 }
 
 // updateSkillFromMeta updates a skill using its metadata
-func updateSkillFromMeta(skill, skillPath, progress string, dryRun bool) (updated bool) {
+func updateSkillFromMeta(sourceDir, skill, skillPath, progress string, dryRun bool) (updated bool) {
 	if dryRun {
 		ui.ListItem("info", skill, "[dry-run] would reinstall from source")
 		return false
@@ -168,7 +243,7 @@ func updateSkillFromMeta(skill, skillPath, progress string, dryRun bool) (update
 		return false
 	}
 
-	opts := install.InstallOptions{Force: true, Update: true}
+	opts := install.InstallOptions{Force: true, Update: true, SourceDir: sourceDir}
 	if _, err = install.Install(source, skillPath, opts); err != nil {
 		spinner.Warn(fmt.Sprintf("%s %v", skill, err))
 		return false
@@ -178,21 +253,23 @@ func updateSkillFromMeta(skill, skillPath, progress string, dryRun bool) (update
 	return true
 }
 
-func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
+// updateAllTrackedRepos updates every tracked repo and skill with metadata
+// and returns the ones it updated
+func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) ([]string, error) {
 	repos, err := install.GetTrackedRepos(cfg.Source)
 	if err != nil {
-		return fmt.Errorf("failed to get tracked repos: %w", err)
+		return nil, fmt.Errorf("failed to get tracked repos: %w", err)
 	}
 
 	skills, err := install.GetUpdatableSkills(cfg.Source)
 	if err != nil {
-		return fmt.Errorf("failed to get updatable skills: %w", err)
+		return nil, fmt.Errorf("failed to get updatable skills: %w", err)
 	}
 
 	if len(repos) == 0 && len(skills) == 0 {
 		ui.Info("No tracked repositories or updatable skills found")
 		ui.Info("Use 'skillshare install <repo> --track' to add a tracked repository")
-		return nil
+		return nil, nil
 	}
 
 	total := len(repos) + len(skills)
@@ -201,6 +278,7 @@ func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
 	fmt.Println()
 
 	var result updateResult
+	var changed []string
 
 	// Update tracked repos
 	for i, repo := range repos {
@@ -208,6 +286,7 @@ func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
 		progress := fmt.Sprintf("[%d/%d]", i+1, total)
 		if updated, _ := updateTrackedRepoQuick(repo, repoPath, progress, dryRun, force); updated {
 			result.updated++
+			changed = append(changed, repo)
 		} else {
 			result.skipped++
 		}
@@ -217,8 +296,9 @@ func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
 	for i, skill := range skills {
 		skillPath := filepath.Join(cfg.Source, skill)
 		progress := fmt.Sprintf("[%d/%d]", len(repos)+i+1, total)
-		if updateSkillFromMeta(skill, skillPath, progress, dryRun) {
+		if updateSkillFromMeta(cfg.Source, skill, skillPath, progress, dryRun) {
 			result.updated++
+			changed = append(changed, skill)
 		} else {
 			result.skipped++
 		}
@@ -237,10 +317,12 @@ func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
 		ui.Info("Run 'skillshare sync' to distribute changes")
 	}
 
-	return nil
+	return changed, nil
 }
 
-func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool) error {
+// updateSkillOrRepo updates the tracked repo or skill name resolves to and
+// returns its path relative to the source directory
+func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool, pin pinUpdate) (string, error) {
 	// Try tracked repo first (with _ prefix)
 	repoName := name
 	if !strings.HasPrefix(repoName, "_") {
@@ -249,28 +331,28 @@ func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool) erro
 	repoPath := filepath.Join(cfg.Source, repoName)
 
 	if install.IsGitRepo(repoPath) {
-		return updateTrackedRepo(cfg, repoName, dryRun, force)
+		return repoName, updateTrackedRepo(cfg, repoName, dryRun, force, pin)
 	}
 
 	// Try as regular skill (exact path)
 	skillPath := filepath.Join(cfg.Source, name)
 	if meta, err := install.ReadMeta(skillPath); err == nil && meta != nil {
-		return updateRegularSkill(cfg, name, dryRun, force)
+		return name, updateRegularSkill(cfg, name, dryRun, force, pin)
 	}
 
 	// Check if it's a nested path that exists as git repo
 	if install.IsGitRepo(skillPath) {
-		return updateTrackedRepo(cfg, name, dryRun, force)
+		return name, updateTrackedRepo(cfg, name, dryRun, force, pin)
 	}
 
 	// Fallback: search by basename in nested skills and repos
 	if match, err := resolveByBasename(cfg.Source, name); err == nil {
 		if match.isRepo {
-			return updateTrackedRepo(cfg, match.relPath, dryRun, force)
+			return match.relPath, updateTrackedRepo(cfg, match.relPath, dryRun, force, pin)
 		}
-		return updateRegularSkill(cfg, match.relPath, dryRun, force)
+		return match.relPath, updateRegularSkill(cfg, match.relPath, dryRun, force, pin)
 	} else {
-		return err
+		return "", err
 	}
 }
 
@@ -316,7 +398,7 @@ func resolveByBasename(sourceDir, name string) (resolvedMatch, error) {
 	return resolvedMatch{}, fmt.Errorf("%s", strings.Join(lines, "\n"))
 }
 
-func updateTrackedRepo(cfg *config.Config, repoName string, dryRun, force bool) error {
+func updateTrackedRepo(cfg *config.Config, repoName string, dryRun, force bool, pin pinUpdate) error {
 	repoPath := filepath.Join(cfg.Source, repoName)
 
 	// Header box
@@ -357,23 +439,17 @@ func updateTrackedRepo(cfg *config.Config, repoName string, dryRun, force bool)
 
 	if dryRun {
 		spinner.Stop()
-		ui.Warning("[dry-run] Would run: git pull")
+		ui.Warning("[dry-run] Would %s", pin.describe(repoPath))
 		return nil
 	}
 
 	spinner.Update("Fetching from origin...")
 
-	// Use ForcePull if --force to handle force push
-	var info *git.UpdateInfo
-	var err error
-	if force {
-		info, err = git.ForcePull(repoPath)
-	} else {
-		info, err = git.Pull(repoPath)
-	}
+	// Pinned repos check out their ref; --force resets to origin to handle force push
+	info, err := pin.updateTracked(repoPath, force)
 	if err != nil {
 		spinner.Fail("Failed to update")
-		return fmt.Errorf("git pull failed: %w", err)
+		return fmt.Errorf("update failed: %w", err)
 	}
 
 	if info.UpToDate {
@@ -414,7 +490,7 @@ func updateTrackedRepo(cfg *config.Config, repoName string, dryRun, force bool)
 	return nil
 }
 
-func updateRegularSkill(cfg *config.Config, skillName string, dryRun, force bool) error {
+func updateRegularSkill(cfg *config.Config, skillName string, dryRun, force bool, pin pinUpdate) error {
 	skillPath := filepath.Join(cfg.Source, skillName)
 
 	// Read metadata to get source
@@ -426,27 +502,35 @@ func updateRegularSkill(cfg *config.Config, skillName string, dryRun, force bool
 		return fmt.Errorf("skill '%s' has no source metadata, cannot update", skillName)
 	}
 
+	// Parse source, keeping or moving its pin
+	source, err := install.ParseSource(meta.Source)
+	if err != nil {
+		return fmt.Errorf("invalid source in metadata: %w", err)
+	}
+	if source, err = pin.apply(source); err != nil {
+		return err
+	}
+
 	// Header box
 	ui.HeaderBox("skillshare update",
-		fmt.Sprintf("Updating: %s\nSource: %s", skillName, meta.Source))
+		fmt.Sprintf("Updating: %s\nSource: %s", skillName, source.Spec()))
 	fmt.Println()
 
 	if dryRun {
-		ui.Warning("[dry-run] Would reinstall from: %s", meta.Source)
+		ui.Warning("[dry-run] Would reinstall from: %s", source.Spec())
 		return nil
 	}
 
-	// Parse source and reinstall
-	source, err := install.ParseSource(meta.Source)
-	if err != nil {
-		return fmt.Errorf("invalid source in metadata: %w", err)
+	spinnerText := "Cloning source repository..."
+	if source.IsArchive() {
+		spinnerText = "Fetching archive..."
 	}
-
-	spinner := ui.StartSpinner("Cloning source repository...")
+	spinner := ui.StartSpinner(spinnerText)
 
 	opts := install.InstallOptions{
-		Force:  true,
-		Update: true,
+		Force:     true,
+		Update:    true,
+		SourceDir: cfg.Source,
 	}
 
 	result, err := install.Install(source, skillPath, opts)
@@ -455,7 +539,14 @@ func updateRegularSkill(cfg *config.Config, skillName string, dryRun, force bool
 		return fmt.Errorf("update failed: %w", err)
 	}
 
-	spinner.Success(fmt.Sprintf("Updated %s", skillName))
+	switch {
+	case pin.unpin:
+		spinner.Success(fmt.Sprintf("Updated %s, now following the default branch", skillName))
+	case source.Ref != "":
+		spinner.Success(fmt.Sprintf("Updated %s, pinned to %s", skillName, source.Ref))
+	default:
+		spinner.Success(fmt.Sprintf("Updated %s", skillName))
+	}
 
 	for _, warning := range result.Warnings {
 		ui.Warning("%s", warning)
@@ -480,8 +571,9 @@ func printUpdateHelp() {
 
 Update a skill or tracked repository.
 
-For tracked repos (_repo-name): runs git pull
+For tracked repos (_repo-name): runs git pull, or checks out the pinned ref
 For regular skills: reinstalls from stored source metadata
+Pinned skills and repos (installed with @ref) stay at their ref unless --to or --unpin
 
 Safety: Tracked repos with uncommitted changes are skipped by default.
 Use --force to discard local changes and update.
@@ -491,6 +583,8 @@ Arguments:
 
 Options:
   --all, -a           Update all tracked repos + skills with metadata
+  --to <ref>          Pin the skill to another branch, tag or commit
+  --unpin             Drop the pin and follow the default branch
   --force, -f         Discard local changes and force update
   --dry-run, -n       Preview without making changes
   --project, -p       Use project-level config in current directory
@@ -503,5 +597,7 @@ Examples:
   skillshare update team-skills           # _ prefix is optional for repos
   skillshare update --all                 # Update all tracked repos + skills
   skillshare update --all --dry-run       # Preview updates
+  skillshare update my-skill --to v2.0.0  # Move a pinned skill to v2.0.0
+  skillshare update my-skill --unpin      # Follow the latest commit again
   skillshare update _team --force         # Discard changes and update`)
 }
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"skillshare/internal/config"
 	"skillshare/internal/git"
 	"skillshare/internal/install"
 	"skillshare/internal/ui"
@@ -17,12 +18,21 @@ func cmdUpdateProject(args []string, root string) error {
 	var updateAll bool
 	var dryRun bool
 	var force bool
+	var pin pinUpdate
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		switch {
 		case arg == "--all" || arg == "-a":
 			updateAll = true
+		case arg == "--to":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--to requires a ref")
+			}
+			i++
+			pin.to = args[i]
+		case arg == "--unpin":
+			pin.unpin = true
 		case arg == "--dry-run" || arg == "-n":
 			dryRun = true
 		case arg == "--force" || arg == "-f":
@@ -43,6 +53,9 @@ func cmdUpdateProject(args []string, root string) error {
 	if name == "" && !updateAll {
 		updateAll = true
 	}
+	if err := pin.validate(updateAll); err != nil {
+		return err
+	}
 
 	if !projectConfigExists(root) {
 		if err := performProjectInit(root, projectInitOptions{}); err != nil {
@@ -51,15 +64,23 @@ func cmdUpdateProject(args []string, root string) error {
 	}
 
 	sourcePath := filepath.Join(root, ".skillshare", "skills")
+	var changed []string
+	if !dryRun {
+		defer func() { refreshLockfile(config.ProjectLockfilePath(root), sourcePath, changed, false) }()
+	}
 
+	var err error
 	if updateAll {
-		return updateAllProjectSkills(sourcePath, dryRun, force)
+		changed, err = updateAllProjectSkills(sourcePath, dryRun, force)
+	} else {
+		changed, err = updateSingleProjectSkill(sourcePath, name, dryRun, force, pin)
 	}
-
-	return updateSingleProjectSkill(sourcePath, name, dryRun, force)
+	return err
 }
 
-func updateSingleProjectSkill(sourcePath, name string, dryRun, force bool) error {
+// updateSingleProjectSkill updates one tracked repo or skill and returns it
+// when it was updated
+func updateSingleProjectSkill(sourcePath, name string, dryRun, force bool, pin pinUpdate) ([]string, error) {
 	// Normalize _ prefix for tracked repos
 	repoName := name
 	if !strings.HasPrefix(repoName, "_") {
@@ -72,73 +93,76 @@ func updateSingleProjectSkill(sourcePath, name string, dryRun, force bool) error
 
 	// Try as tracked repo first
 	if install.IsGitRepo(repoPath) {
-		return updateProjectTrackedRepo(repoName, repoPath, dryRun, force)
+		updated, err := updateProjectTrackedRepo(repoName, repoPath, dryRun, force, pin)
+		if !updated {
+			return nil, err
+		}
+		return []string{filepath.ToSlash(repoName)}, err
 	}
 
 	// Regular skill with metadata
 	skillPath := filepath.Join(sourcePath, name)
 	if _, err := os.Stat(skillPath); err != nil {
-		return fmt.Errorf("skill '%s' not found", name)
+		return nil, fmt.Errorf("skill '%s' not found", name)
 	}
 
 	meta, err := install.ReadMeta(skillPath)
 	if err != nil || meta == nil {
-		return fmt.Errorf("%s is a local skill, nothing to update", name)
+		return nil, fmt.Errorf("%s is a local skill, nothing to update", name)
 	}
 
 	source, err := install.ParseSource(meta.Source)
 	if err != nil {
-		return fmt.Errorf("invalid source for %s: %w", name, err)
+		return nil, fmt.Errorf("invalid source for %s: %w", name, err)
+	}
+	if source, err = pin.apply(source); err != nil {
+		return nil, err
 	}
 
 	if dryRun {
-		ui.Info("[dry-run] would update %s", name)
-		return nil
+		ui.Info("[dry-run] would update %s from %s", name, source.Spec())
+		return nil, nil
 	}
 
 	spinner := ui.StartSpinner(fmt.Sprintf("Updating %s...", name))
-	opts := install.InstallOptions{Force: true, Update: true}
+	opts := install.InstallOptions{Force: true, Update: true, SourceDir: sourcePath}
 	if _, err := install.Install(source, skillPath, opts); err != nil {
 		spinner.Fail(fmt.Sprintf("%s failed: %v", name, err))
-		return nil
+		return nil, nil
 	}
 	spinner.Success(fmt.Sprintf("Updated %s", name))
 	fmt.Println()
 	ui.Info("Run 'skillshare sync' to distribute changes")
-	return nil
+	return []string{filepath.ToSlash(name)}, nil
 }
 
-func updateProjectTrackedRepo(repoName, repoPath string, dryRun, force bool) error {
+// updateProjectTrackedRepo pulls a tracked repo and reports whether it ran
+// the update
+func updateProjectTrackedRepo(repoName, repoPath string, dryRun, force bool, pin pinUpdate) (bool, error) {
 	// Check for uncommitted changes
 	if isDirty, _ := git.IsDirty(repoPath); isDirty {
 		if !force {
 			ui.Warning("%s has uncommitted changes (use --force to discard)", repoName)
-			return fmt.Errorf("uncommitted changes in %s", repoName)
+			return false, fmt.Errorf("uncommitted changes in %s", repoName)
 		}
 		if !dryRun {
 			if err := git.Restore(repoPath); err != nil {
-				return fmt.Errorf("failed to discard changes: %w", err)
+				return false, fmt.Errorf("failed to discard changes: %w", err)
 			}
 		}
 	}
 
 	if dryRun {
-		ui.Info("[dry-run] would git pull %s", repoName)
-		return nil
+		ui.Info("[dry-run] would %s in %s", pin.describe(repoPath), repoName)
+		return false, nil
 	}
 
 	spinner := ui.StartSpinner(fmt.Sprintf("Updating %s...", repoName))
 
-	var info *git.UpdateInfo
-	var err error
-	if force {
-		info, err = git.ForcePull(repoPath)
-	} else {
-		info, err = git.Pull(repoPath)
-	}
+	info, err := pin.updateTracked(repoPath, force)
 	if err != nil {
 		spinner.Fail(fmt.Sprintf("%s failed: %v", repoName, err))
-		return nil
+		return false, nil
 	}
 
 	if info.UpToDate {
@@ -148,13 +172,15 @@ func updateProjectTrackedRepo(repoName, repoPath string, dryRun, force bool) err
 	}
 	fmt.Println()
 	ui.Info("Run 'skillshare sync' to distribute changes")
-	return nil
+	return true, nil
 }
 
-func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
+// updateAllProjectSkills updates every tracked repo and skill with metadata
+// and returns the ones it updated
+func updateAllProjectSkills(sourcePath string, dryRun, force bool) ([]string, error) {
 	entries, err := os.ReadDir(sourcePath)
 	if err != nil {
-		return fmt.Errorf("failed to read project skills: %w", err)
+		return nil, fmt.Errorf("failed to read project skills: %w", err)
 	}
 
 	if dryRun {
@@ -162,6 +188,7 @@ func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
 	}
 
 	updated := 0
+	var changed []string
 	for _, entry := range entries {
 		if !entry.IsDir() || utils.IsHidden(entry.Name()) {
 			continue
@@ -172,10 +199,13 @@ func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
 
 		// Tracked repo: git pull
 		if install.IsGitRepo(skillPath) {
-			if err := updateProjectTrackedRepo(skillName, skillPath, dryRun, force); err != nil {
+			if pulled, err := updateProjectTrackedRepo(skillName, skillPath, dryRun, force, pinUpdate{}); err != nil {
 				ui.Warning("%s: %v", skillName, err)
 			} else {
 				updated++
+				if pulled {
+					changed = append(changed, skillName)
+				}
 			}
 			continue
 		}
@@ -198,12 +228,13 @@ func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
 		}
 
 		spinner := ui.StartSpinner(fmt.Sprintf("Updating %s...", skillName))
-		if _, err := install.Install(source, skillPath, install.InstallOptions{Force: true, Update: true}); err != nil {
+		if _, err := install.Install(source, skillPath, install.InstallOptions{Force: true, Update: true, SourceDir: sourcePath}); err != nil {
 			spinner.Fail(fmt.Sprintf("%s failed: %v", skillName, err))
 			continue
 		}
 		spinner.Success(fmt.Sprintf("Updated %s", skillName))
 		updated++
+		changed = append(changed, skillName)
 	}
 
 	if updated > 0 && !dryRun {
@@ -211,5 +242,5 @@ func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
 		ui.Info("Run 'skillshare sync' to distribute changes")
 	}
 
-	return nil
+	return changed, nil
 }
@@ -15,7 +15,6 @@ import (
 	"runtime"
 	"strings"
 
-	"skillshare/internal/config"
 	"skillshare/internal/install"
 	"skillshare/internal/ui"
 	versionpkg "skillshare/internal/version"
@@ -172,7 +171,7 @@ func upgradeSkillshareSkill(dryRun, force bool) error {
 	// Step 1: Show skill info
 	ui.StepStart("Skill", "skillshare")
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("config not found: run 'skillshare init' first")
 	}
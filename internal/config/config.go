@@ -12,8 +12,21 @@ import (
 
 // TargetConfig holds configuration for a single target
 type TargetConfig struct {
-	Path string `yaml:"path"`
-	Mode string `yaml:"mode,omitempty"` // symlink (default), copy
+	Path      string   `yaml:"path"`
+	Mode      string   `yaml:"mode,omitempty"`       // merge (default), symlink, copy
+	Include   []string `yaml:"include,omitempty"`    // Glob patterns over skill paths/flat names; empty means all
+	Exclude   []string `yaml:"exclude,omitempty"`    // Glob patterns removed after include
+	Format    string   `yaml:"format,omitempty"`     // skill-dir (default), cursor-mdc, single-markdown, agents-md
+	Index     string   `yaml:"index,omitempty"`      // Markdown file that gets a managed list of the target's skills
+	LinkStyle string   `yaml:"link_style,omitempty"` // absolute or relative; overrides the top-level link_style
+
+	When   *TargetCondition `yaml:"when,omitempty"`   // Only active on matching machines
+	Naming *NamingConfig    `yaml:"naming,omitempty"` // Overrides the top-level naming policy
+
+	defaultNaming    *NamingConfig // Top-level naming, set on load
+	defaultFormat    string        // Format from the target registry, set on load
+	defaultLinkStyle string        // Top-level link_style, set on load
+	name             string        // Key in the config's targets map, set on load
 }
 
 // AuditConfig holds security audit policy settings.
@@ -36,18 +49,37 @@ type HubConfig struct {
 
 // Config holds the application configuration
 type Config struct {
-	Source  string                  `yaml:"source"`
-	Mode    string                  `yaml:"mode,omitempty"` // default mode: symlink
-	Targets map[string]TargetConfig `yaml:"targets"`
-	Ignore  []string                `yaml:"ignore,omitempty"`
-	Audit   AuditConfig             `yaml:"audit,omitempty"`
-	Hub     HubConfig               `yaml:"hub,omitempty"`
+	Version   int                     `yaml:"version"` // schema version, see CurrentConfigVersion
+	Source    string                  `yaml:"source"`
+	Sources   []string                `yaml:"sources,omitempty"` // ordered layers; later layers override earlier ones by skill name
+	Mode      string                  `yaml:"mode,omitempty"`    // default mode: symlink
+	Targets   map[string]TargetConfig `yaml:"targets"`
+	Ignore    []string                `yaml:"ignore,omitempty"`     // gitignore-style patterns, relative to source
+	Naming    NamingConfig            `yaml:"naming,omitempty"`     // target names of nested skills
+	LinkStyle string                  `yaml:"link_style,omitempty"` // absolute (default) or relative links in merge and symlink mode
+	Audit     AuditConfig             `yaml:"audit,omitempty"`
+	Hub       HubConfig               `yaml:"hub,omitempty"`
+	Forges    map[string]string       `yaml:"forges,omitempty"` // self-hosted git host -> github, gitlab, bitbucket or gitea
+
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"` // named overlays, see ActiveProfile
+
+	raw      *Config                 // Values as written, before ${VAR} / ~ expansion
+	inactive map[string]TargetConfig // Targets skipped by their when: condition
 }
 
 const defaultAuditBlockThreshold = "CRITICAL"
 
-// ConfigPath returns the config file path, respecting SKILLSHARE_CONFIG env var
+// ConfigPath returns the config file of the active profile: config.yaml, or
+// profiles/<name>.yaml when a profile is selected
 func ConfigPath() string {
+	if name := ActiveProfile(); name != "" {
+		return ProfilePath(name)
+	}
+	return BaseConfigPath()
+}
+
+// BaseConfigPath returns the shared config.yaml path, respecting SKILLSHARE_CONFIG env var
+func BaseConfigPath() string {
 	// Allow override for testing
 	if envPath := os.Getenv("SKILLSHARE_CONFIG"); envPath != "" {
 		return envPath
@@ -56,9 +88,21 @@ func ConfigPath() string {
 	return filepath.Join(home, ".config", "skillshare", "config.yaml")
 }
 
-// Load reads the config from the default location
+// LockPath returns the advisory lock file taken by every command and UI
+// request that writes to the config, the source directory or targets
+func LockPath() string {
+	return filepath.Join(filepath.Dir(BaseConfigPath()), "write.lock")
+}
+
+// LockfilePath returns the skillshare.lock path that pins global installs.
+// Each profile keeps its own lock, since each can point at its own source.
+func LockfilePath() string {
+	return filepath.Join(ProfileDir(filepath.Dir(BaseConfigPath())), "skillshare.lock")
+}
+
+// Load reads config.yaml and applies the active profile, if any
 func Load() (*Config, error) {
-	path := ConfigPath()
+	path := BaseConfigPath()
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -67,43 +111,94 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
+	data, err = migrateOnLoad(data, globalMigrations)
+	if err != nil {
+		return nil, err
+	}
+
 	var cfg Config
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	if name := ActiveProfile(); name != "" {
+		if err := applyProfile(&cfg, name); err != nil {
+			return nil, err
+		}
+	}
+
 	threshold, err := normalizeAuditBlockThreshold(cfg.Audit.BlockThreshold)
 	if err != nil {
 		return nil, fmt.Errorf("invalid audit.block_threshold: %w", err)
 	}
 	cfg.Audit.BlockThreshold = threshold
 
-	// Expand ~ in paths
-	cfg.Source = expandPath(cfg.Source)
+	// Expand ${VAR} and ~, and drop targets whose when: does not match
+	if err := cfg.resolve(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.resolveSourceLayers(); err != nil {
+		return nil, err
+	}
+
+	if err := validateNaming(cfg.Naming, cfg.Targets); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateTargetFormats(cfg.Targets, cfg.Mode); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateIndexes(cfg.Targets, cfg.Mode); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateLinkStyles(cfg.LinkStyle, cfg.Targets); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateForges(cfg.Forges); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 	for name, target := range cfg.Targets {
-		target.Path = expandPath(target.Path)
-		cfg.Targets[name] = target
+		if known, ok := LookupGlobalTarget(name); ok {
+			mode := target.Mode
+			if mode == "" {
+				mode = cfg.Mode
+			}
+			target = target.WithDefaultFormat(registryFormat(known.defaultFormat, mode))
+		}
+		cfg.Targets[name] = target.WithDefaultNaming(cfg.Naming).WithDefaultLinkStyle(cfg.LinkStyle).WithName(name)
 	}
 
 	return &cfg, nil
 }
 
-// Save writes the config to the default location
+// Save writes the config to the default location. With a profile active,
+// the profile's settings go to profiles/<name>.yaml instead.
 func (c *Config) Save() error {
-	path := ConfigPath()
+	path := BaseConfigPath()
+	if name := ActiveProfile(); name != "" {
+		if _, err := os.Stat(path); err == nil {
+			return c.saveProfile(name)
+		}
+	}
+	return c.writeTo(path)
+}
 
+func (c *Config) writeTo(path string) error {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(c)
+	c.Version = CurrentConfigVersion
+	data, err := yaml.Marshal(c.unexpanded())
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
+	if err := backupOutdated(path); err != nil {
+		return err
+	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := utils.WriteFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -0,0 +1,378 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"skillshare/internal/utils"
+)
+
+// Document is a config file held as a YAML node tree, so dotted-key edits
+// keep comments, key order and ${VAR} / ~ values as written.
+type Document struct {
+	Path string
+	spec *fieldSpec
+	root *yaml.Node // Top-level mapping
+}
+
+// LoadDocument opens config.yaml for editing.
+func LoadDocument(path string) (*Document, error) {
+	return loadDocument(path, globalSpec, globalMigrations)
+}
+
+// LoadProjectDocument opens the project's .skillshare/config.yaml for editing.
+func LoadProjectDocument(projectRoot string) (*Document, error) {
+	return loadDocument(ProjectConfigPath(projectRoot), projectSpec, projectMigrations)
+}
+
+// LoadProfileDocument opens the profiles/<name>.yaml overlay for editing.
+func LoadProfileDocument(name string) (*Document, error) {
+	return loadDocument(ProfilePath(name), profileSpec, nil)
+}
+
+// ProfileOverrides reports whether a profile can override key, that is
+// whether its first segment is a profile field.
+func ProfileOverrides(key string) bool {
+	top, _, _ := strings.Cut(key, ".")
+	return profileSpec.props[top] != nil
+}
+
+// loadDocument reads the config at path, migrated in memory; saving it
+// persists the migration. Profile overlays carry no version and are never
+// migrated.
+func loadDocument(path string, spec *fieldSpec, migrations []migration) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if spec != profileSpec {
+		if data, err = migrateOnLoad(data, migrations); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	root, err := parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &Document{Path: path, spec: spec, root: root}, nil
+}
+
+// Get returns the node at key, or nil if the key is not set.
+func (d *Document) Get(key string) (*yaml.Node, error) {
+	parts, err := d.resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	node := d.root
+	for _, p := range parts {
+		node = p.lookup(node)
+		if node == nil {
+			return nil, nil
+		}
+	}
+	return node, nil
+}
+
+// Set stores value at key, creating parent mappings as needed. Scalars are
+// stored as written; list and mapping keys accept YAML such as [a, b].
+func (d *Document) Set(key, value string) error {
+	parts, err := d.resolveKey(key)
+	if err != nil {
+		return err
+	}
+	last := parts[len(parts)-1]
+	newNode, err := last.spec.parseValue(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	node := d.root
+	for _, p := range parts[:len(parts)-1] {
+		child := p.lookup(node)
+		if child == nil {
+			child = p.spec.emptyNode()
+			if err := p.insert(node, child); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		if child.Kind == yaml.ScalarNode && p.spec.kind == kindTargetEntry {
+			// Expand "name" shorthand into {name: ...} to hold more keys
+			name := child.Value
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(child, "name", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+		}
+		if child.Kind != yaml.MappingNode && child.Kind != yaml.SequenceNode {
+			return fmt.Errorf("%s: %s is not a mapping or list", key, p.field)
+		}
+		node = child
+	}
+
+	if existing := last.lookup(node); existing != nil {
+		newNode.HeadComment, newNode.LineComment = existing.HeadComment, existing.LineComment
+		*existing = *newNode
+		return nil
+	}
+	return last.insert(node, newNode)
+}
+
+// Unset removes key. It reports whether the key was present.
+func (d *Document) Unset(key string) (bool, error) {
+	parts, err := d.resolveKey(key)
+	if err != nil {
+		return false, err
+	}
+	node := d.root
+	for _, p := range parts[:len(parts)-1] {
+		node = p.lookup(node)
+		if node == nil {
+			return false, nil
+		}
+	}
+	return parts[len(parts)-1].remove(node), nil
+}
+
+// Validate checks the document as it would be written.
+func (d *Document) Validate() []FieldError {
+	data, err := d.Bytes()
+	if err != nil {
+		return []FieldError{{Message: err.Error()}}
+	}
+	switch d.spec {
+	case projectSpec:
+		return ValidateProjectConfigData(data)
+	case profileSpec:
+		return ValidateProfileData(data)
+	}
+	return ValidateConfigData(data)
+}
+
+// Bytes encodes the document.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{d.root}}
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	enc.Close()
+	return buf.Bytes(), nil
+}
+
+// Save writes the document back to Path.
+func (d *Document) Save() error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+	if d.spec != profileSpec {
+		if err := backupOutdated(d.Path); err != nil {
+			return err
+		}
+	}
+	if err := utils.WriteFileAtomic(d.Path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// keyPart is one segment of a dotted key with the spec of its value.
+type keyPart struct {
+	name   string
+	field  string // Dotted key up to and including this segment
+	parent *fieldSpec
+	spec   *fieldSpec
+}
+
+// resolveKey splits key on dots and checks each segment against the schema.
+func (d *Document) resolveKey(key string) ([]keyPart, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, fmt.Errorf("config key cannot be empty")
+	}
+
+	var parts []keyPart
+	spec := d.spec
+	field := ""
+	for _, name := range strings.Split(key, ".") {
+		field = joinField(field, name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid config key %q", key)
+		}
+
+		var child *fieldSpec
+		switch spec.kind {
+		case kindObject, kindTargetEntry:
+			child = spec.props[name]
+			if child == nil {
+				msg := fmt.Sprintf("unknown config key %q", field)
+				if hint := closestKey(name, spec.keys()); hint != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", strings.TrimSuffix(field, name)+hint)
+				}
+				return nil, fmt.Errorf("%s", msg)
+			}
+		case kindMap:
+			if spec.keyCheck != nil {
+				if err := spec.keyCheck(name); err != nil {
+					return nil, fmt.Errorf("%s: %w", field, err)
+				}
+			}
+			child = spec.elem
+		case kindList:
+			if _, err := strconv.Atoi(name); err != nil && spec.keyField == "" {
+				return nil, fmt.Errorf("%s: list index must be a number", field)
+			}
+			child = spec.elem
+		default:
+			return nil, fmt.Errorf("unknown config key %q (%s is not a mapping)", field, strings.TrimSuffix(field, "."+name))
+		}
+
+		parts = append(parts, keyPart{name: name, field: field, parent: spec, spec: child})
+		spec = child
+	}
+	return parts, nil
+}
+
+// lookup returns the child node for this segment, or nil.
+func (p keyPart) lookup(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		return mappingValue(node, p.name)
+	case yaml.SequenceNode:
+		if i := p.index(node); i >= 0 {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// index finds a list item by position or by its key field (e.g. target name).
+func (p keyPart) index(seq *yaml.Node) int {
+	if i, err := strconv.Atoi(p.name); err == nil {
+		if i >= 0 && i < len(seq.Content) {
+			return i
+		}
+		return -1
+	}
+	for i, item := range seq.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			if item.Value == p.name {
+				return i
+			}
+		case yaml.MappingNode:
+			if v := mappingValue(item, p.parent.keyField); v != nil && v.Value == p.name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// insert adds child under this segment's name.
+func (p keyPart) insert(node, child *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		setMappingValue(node, p.name, child)
+		return nil
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(p.name); err == nil {
+			if i != len(node.Content) {
+				return fmt.Errorf("index %d out of range (list has %d items)", i, len(node.Content))
+			}
+			node.Content = append(node.Content, child)
+			return nil
+		}
+		// New item addressed by name: {<keyField>: name, ...}
+		if child.Kind != yaml.MappingNode {
+			child.Kind, child.Tag, child.Value, child.Content = yaml.MappingNode, "!!map", "", nil
+		}
+		setMappingValue(child, p.parent.keyField, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: p.name})
+		node.Content = append(node.Content, child)
+		return nil
+	}
+	return fmt.Errorf("%s is not a mapping or list", p.field)
+}
+
+// remove deletes this segment from node and reports whether it existed.
+func (p keyPart) remove(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == p.name {
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		if i := p.index(node); i >= 0 {
+			node.Content = append(node.Content[:i], node.Content[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// emptyNode returns the node created for a missing parent key.
+func (f *fieldSpec) emptyNode() *yaml.Node {
+	if f.kind == kindList {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// parseValue converts a command-line value to a node of this spec's kind.
+func (f *fieldSpec) parseValue(value string) (*yaml.Node, error) {
+	switch f.kind {
+	case kindString:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case kindInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}, nil
+	case kindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false, got %q", value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML value: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return f.emptyNode(), nil
+	}
+	node := doc.Content[0]
+	if f.kind == kindList && node.Kind == yaml.ScalarNode {
+		// a,b,c shorthand
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range strings.Split(value, ",") {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: strings.TrimSpace(item)})
+		}
+		return seq, nil
+	}
+	node.Style = 0
+	return node, nil
+}
+
+// setMappingValue sets key in a mapping node, appending it if missing.
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
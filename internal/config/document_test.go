@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDocument(t *testing.T, content string) *Document {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(content), 0644)
+	doc, err := LoadDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDocument_SetKeepsCommentsAndVariables(t *testing.T) {
+	doc := writeDocument(t, `version: 1
+# where skills live
+source: ${SKILLS_HOME:-~/skills}
+targets:
+  claude:
+    path: ~/.claude/skills # main agent
+`)
+
+	if err := doc.Set("targets.claude.mode", "copy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("targets.cursor.path", "~/.cursor/skills"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("ignore", "drafts/, wip-*"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(doc.Path)
+	out := string(data)
+	for _, want := range []string{
+		"# where skills live",
+		"source: ${SKILLS_HOME:-~/skills}",
+		"path: ~/.claude/skills # main agent",
+		"mode: copy",
+		"cursor:\n    path: ~/.cursor/skills",
+		"- drafts/\n  - wip-*",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestDocument_GetUnset(t *testing.T) {
+	doc := writeDocument(t, "source: /skills\nsources: [/a, /b]\ntargets:\n  claude:\n    path: /c\n    mode: copy\n")
+
+	node, err := doc.Get("sources.1")
+	if err != nil || node == nil || node.Value != "/b" {
+		t.Fatalf("Get(sources.1) = %v, %v", node, err)
+	}
+	if node, _ := doc.Get("targets.claude.exclude"); node != nil {
+		t.Error("unset key should return nil")
+	}
+
+	found, err := doc.Unset("targets.claude.mode")
+	if err != nil || !found {
+		t.Fatalf("Unset = %v, %v", found, err)
+	}
+	if node, _ := doc.Get("targets.claude.mode"); node != nil {
+		t.Error("mode should be removed")
+	}
+	if found, _ := doc.Unset("targets.claude.mode"); found {
+		t.Error("second unset should report not found")
+	}
+}
+
+func TestDocument_UnknownKey(t *testing.T) {
+	doc := writeDocument(t, "source: /skills\n")
+
+	err := doc.Set("targets.claude.mdoe", "copy")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "targets.claude.mode"`) {
+		t.Fatalf("got %v", err)
+	}
+	if err := doc.Set("version", "abc"); err == nil {
+		t.Error("non-integer version should be rejected")
+	}
+}
+
+func TestProjectDocument_TargetsByName(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".skillshare"), 0755)
+	os.WriteFile(ProjectConfigPath(root), []byte("targets:\n  - claude-code\n  - name: cursor\n    mode: copy\n"), 0644)
+
+	doc, err := LoadProjectDocument(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("targets.claude-code.mode", "symlink"); err != nil {
+		t.Fatal(err)
+	}
+	if node, _ := doc.Get("targets.0.mode"); node == nil || node.Value != "symlink" {
+		t.Errorf("shorthand target should be expanded, got %v", node)
+	}
+	if node, _ := doc.Get("targets.cursor.mode"); node == nil || node.Value != "copy" {
+		t.Errorf("targets.cursor.mode = %v", node)
+	}
+	if errs := doc.Validate(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
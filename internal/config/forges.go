@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ForgeTypes lists the valid values of a forges: entry.
+var ForgeTypes = []string{"github", "gitlab", "bitbucket", "gitea"}
+
+// validateForges checks that each declared host names a known forge type.
+func validateForges(forges map[string]string) error {
+	for host, kind := range forges {
+		if !slices.Contains(ForgeTypes, strings.ToLower(strings.TrimSpace(kind))) {
+			return fmt.Errorf("forges.%s: unsupported type %q (use %s)", host, kind, strings.Join(ForgeTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// ProjectForges returns the forges a project's sources are parsed with: the
+// global config's, if there is one, with the project's entries winning host
+// by host.
+func ProjectForges(project map[string]string) (map[string]string, error) {
+	forges := make(map[string]string)
+	global, err := Load()
+	if err != nil {
+		if _, statErr := os.Stat(BaseConfigPath()); statErr == nil {
+			return nil, err
+		}
+	} else {
+		mergeForges(forges, global.Forges)
+	}
+	mergeForges(forges, project)
+	return forges, nil
+}
+
+// mergeForges copies src into dst, keyed by normalized host name
+func mergeForges(dst, src map[string]string) {
+	for host, kind := range src {
+		dst[strings.ToLower(strings.TrimSpace(host))] = kind
+	}
+}
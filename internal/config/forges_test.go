@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateForges(t *testing.T) {
+	if err := validateForges(map[string]string{"git.company.com": " GitLab ", "code.company.com": "gitea"}); err != nil {
+		t.Errorf("validateForges() error = %v", err)
+	}
+	if err := validateForges(map[string]string{"git.company.com": "sourcehut"}); err == nil {
+		t.Error("validateForges() should reject an unknown forge type")
+	}
+}
+
+func TestProjectForges_MergesGlobal(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("SKILLSHARE_CONFIG", cfgPath)
+
+	// Without a global config only the project's forges apply
+	got, err := ProjectForges(map[string]string{"git.team.io": "gitea"})
+	if err != nil {
+		t.Fatalf("ProjectForges() error = %v", err)
+	}
+	if want := map[string]string{"git.team.io": "gitea"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectForges() = %v, want %v", got, want)
+	}
+
+	raw := "source: /tmp/skills\ntargets: {}\nforges:\n  git.company.com: gitlab\n  Git.Team.io: gitlab\n"
+	if err := os.WriteFile(cfgPath, []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ProjectForges(map[string]string{"git.team.io": "gitea"})
+	if err != nil {
+		t.Fatalf("ProjectForges() error = %v", err)
+	}
+	want := map[string]string{"git.company.com": "gitlab", "git.team.io": "gitea"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectForges() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatSkillDir is the default target format: one SKILL.md folder per skill.
+const FormatSkillDir = "skill-dir"
+
+// TargetFormats lists the valid values of a target's format.
+var TargetFormats = []string{FormatSkillDir, "cursor-mdc", "single-markdown", "agents-md"}
+
+// OutputFormat returns the format sync writes into the target: its own
+// format:, else the one its registry entry declares, else skill-dir.
+func (t TargetConfig) OutputFormat() string {
+	switch {
+	case t.Format != "":
+		return t.Format
+	case t.defaultFormat != "":
+		return t.defaultFormat
+	}
+	return FormatSkillDir
+}
+
+// WithDefaultFormat returns the target with format as the one it uses when
+// it sets no format: of its own.
+func (t TargetConfig) WithDefaultFormat(format string) TargetConfig {
+	t.defaultFormat = format
+	return t
+}
+
+// registryFormat returns the registry's format for a target synced in mode.
+// Symlink mode links the source directory itself, so it keeps skill-dir.
+func registryFormat(format, mode string) string {
+	if mode == "symlink" {
+		return ""
+	}
+	return format
+}
+
+// validateFormat checks a format value and that it can be used in mode.
+func validateFormat(format, mode string) error {
+	if format == "" {
+		return nil
+	}
+	valid := false
+	for _, f := range TargetFormats {
+		if format == f {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("format: unsupported value %q (use %s)", format, strings.Join(TargetFormats, ", "))
+	}
+	if format != FormatSkillDir && mode == "symlink" {
+		return fmt.Errorf("format %s cannot be used in symlink mode (use merge or copy)", format)
+	}
+	return nil
+}
+
+// validateTargetFormats checks the format of each target.
+func validateTargetFormats(targets map[string]TargetConfig, defaultMode string) error {
+	for name, target := range targets {
+		mode := target.Mode
+		if mode == "" {
+			mode = defaultMode
+		}
+		if err := validateFormat(target.Format, mode); err != nil {
+			return fmt.Errorf("targets.%s.%w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import "testing"
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		mode    string
+		wantErr bool
+	}{
+		{"unset", "", "symlink", false},
+		{"skill-dir in symlink mode", "skill-dir", "symlink", false},
+		{"cursor-mdc", "cursor-mdc", "merge", false},
+		{"agents-md copy", "agents-md", "copy", false},
+		{"unknown", "mdc", "merge", true},
+		{"rendered in symlink mode", "single-markdown", "symlink", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFormat(tt.format, tt.mode); (err != nil) != tt.wantErr {
+				t.Errorf("validateFormat(%q, %q) = %v, wantErr %v", tt.format, tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTargetConfigOutputFormat(t *testing.T) {
+	if got := (TargetConfig{Path: "/x"}).OutputFormat(); got != FormatSkillDir {
+		t.Errorf("default format = %q, want %s", got, FormatSkillDir)
+	}
+	target := TargetConfig{Path: "/x"}.WithDefaultFormat("single-markdown")
+	if got := target.OutputFormat(); got != "single-markdown" {
+		t.Errorf("registry format = %q, want single-markdown", got)
+	}
+	target.Format = "skill-dir"
+	if got := target.OutputFormat(); got != "skill-dir" {
+		t.Errorf("own format = %q, want skill-dir", got)
+	}
+
+	augment, ok := LookupGlobalTarget("augment")
+	if !ok || augment.OutputFormat() != "single-markdown" {
+		t.Errorf("augment format = %q, want single-markdown", augment.OutputFormat())
+	}
+}
+
+func TestRegistryFormat(t *testing.T) {
+	if got := registryFormat("single-markdown", "merge"); got != "single-markdown" {
+		t.Errorf("merge mode = %q, want single-markdown", got)
+	}
+	if got := registryFormat("single-markdown", "symlink"); got != "" {
+		t.Errorf("symlink mode = %q, want no registry format", got)
+	}
+}
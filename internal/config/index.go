@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// IndexPath returns the absolute path of the markdown file whose managed
+// block lists the target's skills, or "" when the target keeps no index.
+// Relative paths are resolved against the target directory.
+func (t TargetConfig) IndexPath() string {
+	if t.Index == "" {
+		return ""
+	}
+	if filepath.IsAbs(t.Index) {
+		return filepath.Clean(t.Index)
+	}
+	return filepath.Join(t.Path, filepath.FromSlash(t.Index))
+}
+
+// validateIndexes checks that index files are only shared by targets that
+// sync the same directory, so every writer produces the same block, and that
+// symlink-mode targets keep none.
+func validateIndexes(targets map[string]TargetConfig, defaultMode string) error {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owners := make(map[string]string)
+	for _, name := range names {
+		target := targets[name]
+		index := target.IndexPath()
+		if index == "" {
+			continue
+		}
+		mode := target.Mode
+		if mode == "" {
+			mode = defaultMode
+		}
+		if mode == "symlink" {
+			return fmt.Errorf("targets.%s.index cannot be used in symlink mode (use merge or copy)", name)
+		}
+		if owner, ok := owners[index]; ok && filepath.Clean(targets[owner].Path) != filepath.Clean(target.Path) {
+			return fmt.Errorf("targets %s and %s both keep their index in %s; give each its own file", owner, name, index)
+		}
+		owners[index] = name
+	}
+	return nil
+}
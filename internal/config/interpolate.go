@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// TargetCondition restricts a target to matching machines. Every field that
+// is set must match; a target without `when:` is always active.
+type TargetCondition struct {
+	Host   string `yaml:"host,omitempty"`   // Hostname glob, e.g. "work-*"
+	OS     string `yaml:"os,omitempty"`     // runtime.GOOS (linux, darwin, windows) or "macos"
+	Exists string `yaml:"exists,omitempty"` // Path that must exist; supports ~ and ${VAR}
+}
+
+// hostname is replaceable in tests.
+var hostname = os.Hostname
+
+// Matches reports whether the condition holds on this machine.
+func (w *TargetCondition) Matches() (bool, error) {
+	if w == nil {
+		return true, nil
+	}
+
+	if w.Host != "" {
+		host, err := hostname()
+		if err != nil {
+			return false, fmt.Errorf("cannot determine hostname: %w", err)
+		}
+		if !matchHost(w.Host, host) {
+			return false, nil
+		}
+	}
+
+	if w.OS != "" && !MatchOS(w.OS) {
+		return false, nil
+	}
+
+	if w.Exists != "" {
+		p, err := expandValue(w.Exists)
+		if err != nil {
+			return false, err
+		}
+		if _, err := os.Stat(p); err != nil {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchHost matches pattern against the full and the short hostname, ignoring case.
+func matchHost(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+	candidates := []string{host}
+	if short, _, ok := strings.Cut(host, "."); ok {
+		candidates = append(candidates, short)
+	}
+	for _, c := range candidates {
+		if ok, _ := path.Match(pattern, c); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// interpolate replaces ${VAR} and ${VAR:-default} with environment values.
+// An unset variable without a default is an error.
+func interpolate(s string) (string, error) {
+	return interpolateWith(s, os.LookupEnv)
+}
+
+func interpolateWith(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	rest := s
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated ${ in %q", s)
+		}
+		b.WriteString(rest[:start])
+
+		expr := rest[start+2 : start+end]
+		name, def, hasDefault := strings.Cut(expr, ":-")
+		if !validEnvName(name) {
+			return "", fmt.Errorf("invalid variable ${%s} in %q", expr, s)
+		}
+		value, ok := lookup(name)
+		switch {
+		case ok && value != "":
+			b.WriteString(value)
+		case hasDefault:
+			b.WriteString(def)
+		case ok:
+			// Set but empty, no default
+		default:
+			return "", fmt.Errorf("environment variable %s is not set (use ${%s:-default})", name, name)
+		}
+		rest = rest[start+end+1:]
+	}
+}
+
+func validEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// expandValue interpolates environment variables and then expands ~.
+func expandValue(s string) (string, error) {
+	v, err := interpolate(s)
+	if err != nil {
+		return "", err
+	}
+	return expandPath(v), nil
+}
+
+// resolve interpolates and expands paths and hub URLs, and sets aside targets
+// whose `when:` condition does not match. The written form is kept in c.raw
+// so Save can restore it.
+func (c *Config) resolve() error {
+	raw := &Config{
+		Source:  c.Source,
+		Sources: append([]string(nil), c.Sources...),
+		Targets: make(map[string]TargetConfig, len(c.Targets)),
+		Hub:     HubConfig{Default: c.Hub.Default, Hubs: append([]HubEntry(nil), c.Hub.Hubs...)},
+	}
+	for name, target := range c.Targets {
+		raw.Targets[name] = target
+	}
+	c.raw = raw
+
+	var err error
+	if c.Source, err = expandValue(c.Source); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	for i, src := range c.Sources {
+		if c.Sources[i], err = expandValue(src); err != nil {
+			return fmt.Errorf("sources[%d]: %w", i, err)
+		}
+	}
+
+	c.inactive = nil
+	for name, target := range c.Targets {
+		ok, err := target.When.Matches()
+		if err != nil {
+			return fmt.Errorf("targets.%s.when: %w", name, err)
+		}
+		if !ok {
+			if c.inactive == nil {
+				c.inactive = map[string]TargetConfig{}
+			}
+			c.inactive[name] = target
+			delete(c.Targets, name)
+			continue
+		}
+		if target.Path, err = expandValue(target.Path); err != nil {
+			return fmt.Errorf("targets.%s.path: %w", name, err)
+		}
+		if target.Index, err = expandValue(target.Index); err != nil {
+			return fmt.Errorf("targets.%s.index: %w", name, err)
+		}
+		c.Targets[name] = target
+	}
+
+	for i, hub := range c.Hub.Hubs {
+		if c.Hub.Hubs[i].URL, err = interpolate(hub.URL); err != nil {
+			return fmt.Errorf("hub %s: %w", hub.Label, err)
+		}
+	}
+
+	return nil
+}
+
+// InactiveTargets returns the sorted names of targets skipped by their `when:` condition.
+func (c *Config) InactiveTargets() []string {
+	names := make([]string, 0, len(c.inactive))
+	for name := range c.inactive {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unexpanded returns a copy of c for writing: values still equal to the
+// expansion of what was loaded keep their ${VAR} / ~ form, and targets
+// skipped by `when:` are put back.
+func (c *Config) unexpanded() *Config {
+	out := *c
+	raw := c.raw
+	if raw == nil {
+		return &out
+	}
+
+	if expanded, err := expandValue(raw.Source); err == nil && expanded == c.Source {
+		out.Source = raw.Source
+	} else if raw.Source == "" && len(c.Sources) > 0 && c.Source == c.Sources[len(c.Sources)-1] {
+		// Default write layer, not written explicitly
+		out.Source = ""
+	}
+
+	if len(c.Sources) > 0 {
+		out.Sources = make([]string, len(c.Sources))
+		for i, src := range c.Sources {
+			out.Sources[i] = src
+			if i < len(raw.Sources) {
+				if expanded, err := expandValue(raw.Sources[i]); err == nil && expanded == src {
+					out.Sources[i] = raw.Sources[i]
+				}
+			}
+		}
+	}
+
+	out.Targets = make(map[string]TargetConfig, len(c.Targets)+len(c.inactive))
+	for name, target := range c.inactive {
+		out.Targets[name] = target
+	}
+	for name, target := range c.Targets {
+		if prev, ok := raw.Targets[name]; ok {
+			if expanded, err := expandValue(prev.Path); err == nil && expanded == target.Path {
+				target.Path = prev.Path
+			}
+			if expanded, err := expandValue(prev.Index); err == nil && expanded == target.Index {
+				target.Index = prev.Index
+			}
+		}
+		out.Targets[name] = target
+	}
+
+	if len(c.Hub.Hubs) > 0 {
+		out.Hub.Hubs = make([]HubEntry, len(c.Hub.Hubs))
+		for i, hub := range c.Hub.Hubs {
+			for _, prev := range raw.Hub.Hubs {
+				if prev.Label != hub.Label {
+					continue
+				}
+				if expanded, err := interpolate(prev.URL); err == nil && expanded == hub.URL {
+					hub.URL = prev.URL
+				}
+				break
+			}
+			out.Hub.Hubs[i] = hub
+		}
+	}
+
+	return &out
+}
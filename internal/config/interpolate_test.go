@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("SKS_SET", "/opt/skills")
+	t.Setenv("SKS_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"no variables", "~/skills", "~/skills", false},
+		{"set variable", "${SKS_SET}/team", "/opt/skills/team", false},
+		{"default unused", "${SKS_SET:-/fallback}", "/opt/skills", false},
+		{"default for unset", "${SKS_UNSET:-/fallback}", "/fallback", false},
+		{"default for empty", "${SKS_EMPTY:-/fallback}", "/fallback", false},
+		{"empty without default", "a${SKS_EMPTY}b", "ab", false},
+		{"multiple", "${SKS_SET}:${SKS_UNSET:-x}", "/opt/skills:x", false},
+		{"unset without default", "${SKS_UNSET}", "", true},
+		{"unterminated", "${SKS_SET", "", true},
+		{"invalid name", "${1BAD}", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := interpolate(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("interpolate(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("interpolate(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetCondition_Matches(t *testing.T) {
+	orig := hostname
+	hostname = func() (string, error) { return "Work-Laptop.corp.example", nil }
+	t.Cleanup(func() { hostname = orig })
+
+	existing := t.TempDir()
+	otherOS := "windows"
+	if runtime.GOOS == "windows" {
+		otherOS = "linux"
+	}
+
+	tests := []struct {
+		name string
+		when *TargetCondition
+		want bool
+	}{
+		{"no condition", nil, true},
+		{"host glob", &TargetCondition{Host: "work-*"}, true},
+		{"short host", &TargetCondition{Host: "work-laptop"}, true},
+		{"host mismatch", &TargetCondition{Host: "home-*"}, false},
+		{"current os", &TargetCondition{OS: runtime.GOOS}, true},
+		{"other os", &TargetCondition{OS: otherOS}, false},
+		{"path exists", &TargetCondition{Exists: existing}, true},
+		{"path missing", &TargetCondition{Exists: filepath.Join(existing, "nope")}, false},
+		{"all must match", &TargetCondition{Host: "work-*", OS: otherOS}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.when.Matches()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSave_KeepsUnexpandedValues(t *testing.T) {
+	t.Setenv("SKS_ROOT", "/data")
+	dir := setupProfileConfig(t, `version: 1
+source: ${SKS_ROOT}/skills
+targets:
+  claude:
+    path: ~/.claude/skills
+  cursor:
+    path: ${SKS_CURSOR:-/cursor}/skills
+    when:
+      exists: /definitely/not/here
+hub:
+  hubs:
+    - label: team
+      url: ${SKS_HUB:-https://example.com}/index.json
+`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Source != "/data/skills" {
+		t.Errorf("Source = %q", cfg.Source)
+	}
+	if _, ok := cfg.Targets["cursor"]; ok {
+		t.Error("cursor should be inactive")
+	}
+	if got := cfg.InactiveTargets(); len(got) != 1 || got[0] != "cursor" {
+		t.Errorf("InactiveTargets() = %v", got)
+	}
+	if cfg.Hub.Hubs[0].URL != "https://example.com/index.json" {
+		t.Errorf("hub URL = %q", cfg.Hub.Hubs[0].URL)
+	}
+
+	cfg.Mode = "copy"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	saved := string(data)
+	for _, want := range []string{
+		"source: ${SKS_ROOT}/skills",
+		"path: ~/.claude/skills",
+		"path: ${SKS_CURSOR:-/cursor}/skills",
+		"exists: /definitely/not/here",
+		"url: ${SKS_HUB:-https://example.com}/index.json",
+		"mode: copy",
+	} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config missing %q:\n%s", want, saved)
+		}
+	}
+}
+
+func TestLoadSave_KeepsUnexpandedIndex(t *testing.T) {
+	dir := setupProfileConfig(t, `version: 1
+source: /skills
+targets:
+  codex:
+    path: /codex/skills
+    index: ${SKS_IDX:-AGENTS.md}
+`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Targets["codex"].Index; got != "AGENTS.md" {
+		t.Errorf("Index = %q, want AGENTS.md", got)
+	}
+	cfg.Targets["extra"] = TargetConfig{Path: "/extra"}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if !strings.Contains(string(data), "index: ${SKS_IDX:-AGENTS.md}") {
+		t.Errorf("saved config lost the index variable:\n%s", data)
+	}
+	again, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := again.Targets["codex"].Index; got != "AGENTS.md" {
+		t.Errorf("reloaded Index = %q, want AGENTS.md", got)
+	}
+}
+
+func TestLoad_UnsetVariableFails(t *testing.T) {
+	setupProfileConfig(t, "version: 1\nsource: ${SKS_NOT_SET}/skills\n")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "SKS_NOT_SET") {
+		t.Fatalf("expected error naming SKS_NOT_SET, got %v", err)
+	}
+}
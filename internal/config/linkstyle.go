@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Link styles sync can write links in.
+const (
+	LinkAbsolute = "absolute" // Links hold the absolute source path (default)
+	LinkRelative = "relative" // Links hold the source path relative to the link
+)
+
+// LinkStyles lists the valid values of link_style.
+var LinkStyles = []string{LinkAbsolute, LinkRelative}
+
+// SymlinkStyle returns the style of the links sync makes in the target: its
+// own link_style:, else the top-level one, else absolute.
+func (t TargetConfig) SymlinkStyle() string {
+	switch {
+	case t.LinkStyle != "":
+		return t.LinkStyle
+	case t.defaultLinkStyle != "":
+		return t.defaultLinkStyle
+	}
+	return LinkAbsolute
+}
+
+// WithDefaultLinkStyle returns the target with style as the one it uses when
+// it sets no link_style: of its own.
+func (t TargetConfig) WithDefaultLinkStyle(style string) TargetConfig {
+	t.defaultLinkStyle = style
+	return t
+}
+
+// validateLinkStyle checks a link_style value.
+func validateLinkStyle(style string) error {
+	if style == "" {
+		return nil
+	}
+	for _, s := range LinkStyles {
+		if style == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("link_style: unsupported value %q (use %s)", style, strings.Join(LinkStyles, ", "))
+}
+
+// validateLinkStyles checks the top-level and per-target link styles.
+func validateLinkStyles(top string, targets map[string]TargetConfig) error {
+	if err := validateLinkStyle(top); err != nil {
+		return err
+	}
+	for name, target := range targets {
+		if err := validateLinkStyle(target.LinkStyle); err != nil {
+			return fmt.Errorf("targets.%s.%w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+	"skillshare/internal/utils"
+)
+
+// CurrentConfigVersion is the schema version written by this build for both
+// the global config.yaml and the project .skillshare/config.yaml.
+// Files without a version field are version 0.
+const CurrentConfigVersion = 1
+
+// migration upgrades a config document from version From to From+1.
+// Apply edits the top-level mapping node in place so comments survive.
+type migration struct {
+	From        int
+	Description string
+	Apply       func(doc *yaml.Node) error
+}
+
+// globalMigrations and projectMigrations are applied in order, one version at a time.
+// To change the schema, bump CurrentConfigVersion and append a step to each list.
+var (
+	globalMigrations = []migration{
+		{From: 0, Description: "add schema version field", Apply: func(*yaml.Node) error { return nil }},
+	}
+	projectMigrations = []migration{
+		{From: 0, Description: "add schema version field", Apply: func(*yaml.Node) error { return nil }},
+	}
+)
+
+// MigrationResult describes the outcome of migrating one config file.
+type MigrationResult struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	Steps       []string // Descriptions of the applied steps, in order
+	Before      []byte
+	After       []byte
+	BackupPath  string // Empty for dry runs or when nothing changed
+}
+
+// Pending reports whether the file needed migrating.
+func (r *MigrationResult) Pending() bool {
+	return r.FromVersion < r.ToVersion
+}
+
+// MigrateConfigFile upgrades the global config at path to CurrentConfigVersion.
+// Unless dryRun is set, the previous file is kept as <path>.v<N>.bak.
+func MigrateConfigFile(path string, dryRun bool) (*MigrationResult, error) {
+	return migrateFile(path, globalMigrations, dryRun)
+}
+
+// MigrateProjectConfigFile upgrades the project config of projectRoot to CurrentConfigVersion.
+func MigrateProjectConfigFile(projectRoot string, dryRun bool) (*MigrationResult, error) {
+	return migrateFile(ProjectConfigPath(projectRoot), projectMigrations, dryRun)
+}
+
+func migrateFile(path string, migrations []migration, dryRun bool) (*MigrationResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := migrateData(data, migrations)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	result.Path = path
+	if dryRun || !result.Pending() {
+		return result, nil
+	}
+
+	backupPath := fmt.Sprintf("%s.v%d.bak", path, result.FromVersion)
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to back up config before migration: %w", err)
+	}
+	if err := utils.WriteFileAtomic(path, result.After, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write migrated config: %w", err)
+	}
+	result.BackupPath = backupPath
+	return result, nil
+}
+
+// migrateData runs every pending migration over a YAML document.
+func migrateData(data []byte, migrations []migration) (*MigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Empty file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config is not a YAML mapping")
+	}
+	root := doc.Content[0]
+
+	from, err := documentVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	result := &MigrationResult{FromVersion: from, ToVersion: CurrentConfigVersion, Before: data, After: data}
+	if from > CurrentConfigVersion {
+		return nil, fmt.Errorf("config version %d is newer than this skillshare supports (%d); upgrade skillshare", from, CurrentConfigVersion)
+	}
+	if from == CurrentConfigVersion {
+		return result, nil
+	}
+
+	for _, m := range migrations {
+		if m.From < from {
+			continue
+		}
+		if err := m.Apply(root); err != nil {
+			return nil, fmt.Errorf("migration v%d -> v%d (%s) failed: %w", m.From, m.From+1, m.Description, err)
+		}
+		result.Steps = append(result.Steps, fmt.Sprintf("v%d -> v%d: %s", m.From, m.From+1, m.Description))
+	}
+	setDocumentVersion(root, CurrentConfigVersion)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode migrated config: %w", err)
+	}
+	enc.Close()
+	result.After = buf.Bytes()
+	return result, nil
+}
+
+// documentVersion reads the top-level version field (0 when absent).
+func documentVersion(root *yaml.Node) (int, error) {
+	if node := mappingValue(root, "version"); node != nil {
+		v, err := strconv.Atoi(node.Value)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("invalid config version %q", node.Value)
+		}
+		return v, nil
+	}
+	return 0, nil
+}
+
+// setDocumentVersion sets the version field, inserting it as the first key if missing.
+func setDocumentVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if node := mappingValue(root, "version"); node != nil {
+		node.Value = value
+		node.Tag = "!!int"
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	root.Content = append([]*yaml.Node{key, val}, root.Content...)
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// migrateOnLoad upgrades an outdated config document in memory before it is
+// decoded. Loading never writes: the file is upgraded by the next save or by
+// 'config migrate', which run under the write lock.
+func migrateOnLoad(data []byte, migrations []migration) ([]byte, error) {
+	result, err := migrateData(data, migrations)
+	if err != nil {
+		return nil, err
+	}
+	return result.After, nil
+}
+
+// backupOutdated keeps the config at path as <path>.v<N>.bak before a save
+// overwrites it with the current schema version. Nothing is written when the
+// file is missing, unreadable as a config or already current.
+func backupOutdated(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	from, err := documentVersion(doc.Content[0])
+	if err != nil || from >= CurrentConfigVersion {
+		return nil
+	}
+	backupPath := fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to back up config before migration: %w", err)
+	}
+	return nil
+}
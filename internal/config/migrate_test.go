@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateData_AddsVersionAndKeepsComments(t *testing.T) {
+	data := []byte(`# team config
+source: ~/skills # shared source
+targets:
+  claude:
+    path: ~/.claude/skills
+`)
+
+	result, err := migrateData(data, globalMigrations)
+	if err != nil {
+		t.Fatalf("migrateData: %v", err)
+	}
+	if result.FromVersion != 0 || result.ToVersion != CurrentConfigVersion {
+		t.Fatalf("versions = %d -> %d", result.FromVersion, result.ToVersion)
+	}
+	if len(result.Steps) != len(globalMigrations) {
+		t.Errorf("steps = %v", result.Steps)
+	}
+
+	out := string(result.After)
+	for _, want := range []string{"# team config", "# shared source", "version: 1", "path: ~/.claude/skills"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("migrated config missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMigrateData_CurrentVersionUnchanged(t *testing.T) {
+	data := []byte("version: 1\nsource: ~/skills\n")
+	result, err := migrateData(data, globalMigrations)
+	if err != nil {
+		t.Fatalf("migrateData: %v", err)
+	}
+	if result.Pending() || string(result.After) != string(data) {
+		t.Errorf("up-to-date config should not change, got:\n%s", result.After)
+	}
+}
+
+func TestMigrateData_NewerVersionFails(t *testing.T) {
+	_, err := migrateData([]byte("version: 99\n"), globalMigrations)
+	if err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Fatalf("expected newer-version error, got %v", err)
+	}
+}
+
+func TestMigrateConfigFile_WritesBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "source: ~/skills\ntargets: {}\n"
+	os.WriteFile(path, []byte(original), 0644)
+
+	dry, err := MigrateConfigFile(path, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if !dry.Pending() || dry.BackupPath != "" {
+		t.Fatalf("dry run result = %+v", dry)
+	}
+	if got, _ := os.ReadFile(path); string(got) != original {
+		t.Fatal("dry run should not modify the file")
+	}
+
+	result, err := MigrateConfigFile(path, false)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if backup, _ := os.ReadFile(result.BackupPath); string(backup) != original {
+		t.Errorf("backup = %q, want original content", backup)
+	}
+	if got, _ := os.ReadFile(path); !strings.HasPrefix(string(got), "version: 1\n") {
+		t.Errorf("migrated file = %q", got)
+	}
+}
+
+func TestLoad_MigratesOldConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "source: /tmp/skills\ntargets: {}\n"
+	os.WriteFile(path, []byte(original), 0644)
+	t.Setenv("SKILLSHARE_CONFIG", path)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Version != CurrentConfigVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentConfigVersion)
+	}
+
+	// Loading migrates in memory only
+	if got, _ := os.ReadFile(path); string(got) != original {
+		t.Errorf("Load should not rewrite the file, got %q", got)
+	}
+	if _, err := os.Stat(path + ".v0.bak"); !os.IsNotExist(err) {
+		t.Error("Load should not write a backup")
+	}
+
+	// The next save persists the migration and keeps the old file
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if backup, _ := os.ReadFile(path + ".v0.bak"); string(backup) != original {
+		t.Errorf("backup = %q, want original content", backup)
+	}
+	if got, _ := os.ReadFile(path); !strings.HasPrefix(string(got), "version: 1\n") {
+		t.Errorf("saved config = %q", got)
+	}
+}
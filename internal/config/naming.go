@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamingStrategies lists the valid values of naming.strategy.
+var NamingStrategies = []string{"full", "last", "last-n", "prefix"}
+
+// NamingCollisions lists the valid values of naming.collisions.
+var NamingCollisions = []string{"error", "suffix", "layer"}
+
+// NamingConfig controls the entry names nested skills get in targets.
+//
+//	full    _team/frontend/hooks -> _team__frontend__hooks (default)
+//	last    _team/frontend/hooks -> hooks
+//	last-n  _team/frontend/hooks -> frontend__hooks (segments: 2)
+//	prefix  _team/frontend/hooks -> fe-hooks (prefixes: {_team/frontend: fe-})
+type NamingConfig struct {
+	Strategy   string            `yaml:"strategy,omitempty"`
+	Segments   int               `yaml:"segments,omitempty"`   // last-n: trailing path segments kept
+	Prefixes   map[string]string `yaml:"prefixes,omitempty"`   // prefix: folder -> prefix for skills under it
+	Aliases    map[string]string `yaml:"aliases,omitempty"`    // Skill path -> exact name, for any strategy
+	Collisions string            `yaml:"collisions,omitempty"` // error (default), suffix, layer
+}
+
+// IsDefault reports whether every skill keeps its full-path flat name.
+func (n NamingConfig) IsDefault() bool {
+	return (n.Strategy == "" || n.Strategy == "full") && len(n.Aliases) == 0
+}
+
+// Validate checks the strategy, its settings and the alias names.
+func (n NamingConfig) Validate() error {
+	switch n.Strategy {
+	case "", "full", "last", "prefix":
+	case "last-n":
+		if n.Segments < 1 {
+			return fmt.Errorf("naming.segments must be at least 1 for strategy last-n")
+		}
+	default:
+		return fmt.Errorf("naming.strategy: unsupported value %q (use %s)", n.Strategy, strings.Join(NamingStrategies, ", "))
+	}
+	switch n.Collisions {
+	case "", "error", "suffix", "layer":
+	default:
+		return fmt.Errorf("naming.collisions: unsupported value %q (use %s)", n.Collisions, strings.Join(NamingCollisions, ", "))
+	}
+	for skill, alias := range n.Aliases {
+		if err := validateEntryName(alias); err != nil {
+			return fmt.Errorf("naming.aliases.%s: %w", skill, err)
+		}
+	}
+	for folder, prefix := range n.Prefixes {
+		if strings.ContainsAny(prefix, `/\`) {
+			return fmt.Errorf("naming.prefixes.%s: prefix must not contain path separators", folder)
+		}
+	}
+	return nil
+}
+
+func validateEntryName(name string) error {
+	switch {
+	case name == "" || name == "." || name == "..":
+		return fmt.Errorf("invalid name %q", name)
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("name %q must not contain path separators", name)
+	case name[0] == '.':
+		return fmt.Errorf("name %q must not start with a dot", name)
+	}
+	return nil
+}
+
+// NamingPolicy returns the naming policy of the target: its own naming:
+// block, else the top-level one it was loaded with, else full-path names.
+func (t TargetConfig) NamingPolicy() NamingConfig {
+	switch {
+	case t.Naming != nil:
+		return *t.Naming
+	case t.defaultNaming != nil:
+		return *t.defaultNaming
+	}
+	return NamingConfig{}
+}
+
+// WithDefaultNaming returns the target with n as the policy it uses when it
+// has no naming: block of its own.
+func (t TargetConfig) WithDefaultNaming(n NamingConfig) TargetConfig {
+	t.defaultNaming = &n
+	return t
+}
+
+// validateNaming checks the top-level and per-target naming policies.
+func validateNaming(top NamingConfig, targets map[string]TargetConfig) error {
+	if err := top.Validate(); err != nil {
+		return err
+	}
+	for name, target := range targets {
+		if target.Naming == nil {
+			continue
+		}
+		if err := target.Naming.Validate(); err != nil {
+			return fmt.Errorf("targets.%s.%w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import "testing"
+
+func TestNamingConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  NamingConfig
+		wantErr bool
+	}{
+		{"default", NamingConfig{}, false},
+		{"last-n", NamingConfig{Strategy: "last-n", Segments: 2}, false},
+		{"last-n without segments", NamingConfig{Strategy: "last-n"}, true},
+		{"unknown strategy", NamingConfig{Strategy: "short"}, true},
+		{"unknown collisions", NamingConfig{Collisions: "merge"}, true},
+		{"alias with slash", NamingConfig{Aliases: map[string]string{"a/b": "x/y"}}, true},
+		{"hidden alias", NamingConfig{Aliases: map[string]string{"a/b": ".x"}}, true},
+		{"prefix with slash", NamingConfig{Strategy: "prefix", Prefixes: map[string]string{"a": "x/"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.naming.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTargetConfigNamingPolicy(t *testing.T) {
+	top := NamingConfig{Strategy: "last"}
+	target := TargetConfig{Path: "/x"}.WithDefaultNaming(top)
+	if got := target.NamingPolicy().Strategy; got != "last" {
+		t.Errorf("inherited strategy = %q, want last", got)
+	}
+	target.Naming = &NamingConfig{Strategy: "full"}
+	if got := target.NamingPolicy().Strategy; got != "full" {
+		t.Errorf("own strategy = %q, want full", got)
+	}
+}
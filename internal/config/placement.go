@@ -0,0 +1,52 @@
+package config
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Name returns the target's name in the config, empty for targets that were
+// not loaded from one.
+func (t TargetConfig) Name() string {
+	return t.name
+}
+
+// WithName returns a copy of t that knows its name in the config. Skills
+// match their frontmatter targets: list against it.
+func (t TargetConfig) WithName(name string) TargetConfig {
+	t.name = name
+	return t
+}
+
+// MatchOS reports whether an os value names the current platform. Values
+// are runtime.GOOS names, case-insensitive; "macos" is accepted for darwin.
+func MatchOS(name string) bool {
+	want := strings.ToLower(strings.TrimSpace(name))
+	if want == "macos" {
+		want = "darwin"
+	}
+	return want == runtime.GOOS
+}
+
+// TargetAliases returns the names a target is known by: the name itself and,
+// when the registry knows it, its global and project names. This lets a
+// skill that says "claude" match the project target "claude-code".
+func TargetAliases(name string) []string {
+	aliases := []string{name}
+	specs, err := loadTargetSpecs()
+	if err != nil {
+		return aliases
+	}
+	for _, spec := range specs {
+		if !strings.EqualFold(spec.GlobalName, name) && !strings.EqualFold(spec.ProjectName, name) {
+			continue
+		}
+		for _, alias := range []string{spec.GlobalName, spec.ProjectName} {
+			if alias != "" && !strings.EqualFold(alias, name) {
+				aliases = append(aliases, alias)
+			}
+		}
+		break
+	}
+	return aliases
+}
@@ -0,0 +1,339 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"skillshare/internal/utils"
+)
+
+const (
+	// ProfilesDirName is the directory next to config.yaml holding profile
+	// overlay files (<name>.yaml) and per-profile state (<name>/).
+	ProfilesDirName = "profiles"
+
+	// DefaultProfile names the plain config.yaml without any overlay.
+	DefaultProfile = "default"
+
+	// activeProfileFile stores the selection made by 'skillshare profile use'.
+	activeProfileFile = "active-profile"
+)
+
+// ProfileConfig is a named overlay applied on top of config.yaml.
+// Fields left empty keep the base value; targets and ignore replace the base lists.
+type ProfileConfig struct {
+	Source  string                  `yaml:"source,omitempty"`
+	Sources []string                `yaml:"sources,omitempty"`
+	Mode    string                  `yaml:"mode,omitempty"`
+	Targets map[string]TargetConfig `yaml:"targets,omitempty"`
+	Ignore  []string                `yaml:"ignore,omitempty"`
+	Audit   AuditConfig             `yaml:"audit,omitempty"`
+}
+
+// ProfileInfo describes where a profile is defined.
+type ProfileInfo struct {
+	Name   string
+	Inline bool   // Defined under profiles: in config.yaml
+	File   string // Path of profiles/<name>.yaml, empty if absent
+}
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// profileOverride is set by --profile and the UI server; it wins over
+// SKILLSHARE_PROFILE and the saved selection.
+var (
+	profileOverride    string
+	profileOverrideSet bool
+)
+
+// SetProfile selects the profile for the rest of this process.
+func SetProfile(name string) {
+	profileOverride = name
+	profileOverrideSet = true
+}
+
+// ActiveProfile returns the selected profile name, or "" for the default config.
+// Precedence: SetProfile (--profile), SKILLSHARE_PROFILE, then 'profile use'.
+func ActiveProfile() string {
+	name := SavedProfile()
+	if env := os.Getenv("SKILLSHARE_PROFILE"); env != "" {
+		name = env
+	}
+	if profileOverrideSet {
+		name = profileOverride
+	}
+	name = strings.TrimSpace(name)
+	if name == DefaultProfile {
+		return ""
+	}
+	return name
+}
+
+// SavedProfile returns the profile stored by 'skillshare profile use', or "".
+func SavedProfile() string {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(BaseConfigPath()), activeProfileFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// ValidateProfileName rejects names that cannot be used as file names.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	return nil
+}
+
+// ProfilePath returns the overlay file of a named profile.
+func ProfilePath(name string) string {
+	return filepath.Join(filepath.Dir(BaseConfigPath()), ProfilesDirName, name+".yaml")
+}
+
+// ProfileDir namespaces a state directory (backups, trash) under the active
+// profile: base/profiles/<name>. Returns base unchanged for the default profile.
+func ProfileDir(base string) string {
+	if name := ActiveProfile(); name != "" {
+		return filepath.Join(base, ProfilesDirName, name)
+	}
+	return base
+}
+
+// ListProfiles returns every profile defined inline in config.yaml or as a
+// profiles/<name>.yaml file, sorted by name.
+func ListProfiles() ([]ProfileInfo, error) {
+	byName := map[string]*ProfileInfo{}
+
+	data, err := os.ReadFile(BaseConfigPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	var base struct {
+		Profiles map[string]ProfileConfig `yaml:"profiles"`
+	}
+	if err := yaml.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	for name := range base.Profiles {
+		byName[name] = &ProfileInfo{Name: name, Inline: true}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(filepath.Dir(BaseConfigPath()), ProfilesDirName, "*.yaml"))
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".yaml")
+		if ValidateProfileName(name) != nil {
+			continue
+		}
+		if byName[name] == nil {
+			byName[name] = &ProfileInfo{Name: name}
+		}
+		byName[name].File = file
+	}
+
+	profiles := make([]ProfileInfo, 0, len(byName))
+	for _, info := range byName {
+		profiles = append(profiles, *info)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// UseProfile saves name as the default profile for new shells.
+// "default" clears the selection.
+func UseProfile(name string) error {
+	path := filepath.Join(filepath.Dir(BaseConfigPath()), activeProfileFile)
+	if name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to clear profile: %w", err)
+		}
+		return nil
+	}
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if _, err := findProfile(name); err != nil {
+		return err
+	}
+	if err := utils.WriteFileAtomic(path, []byte(name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
+
+func findProfile(name string) (*ProfileInfo, error) {
+	profiles, err := ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("profile %q not found (define it under profiles: in config.yaml or create %s)", name, ProfilePath(name))
+}
+
+// applyProfile overlays the inline definition and then profiles/<name>.yaml.
+func applyProfile(cfg *Config, name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	inline, found := cfg.Profiles[name]
+	if found {
+		inline.apply(cfg)
+	}
+
+	data, err := os.ReadFile(ProfilePath(name))
+	switch {
+	case err == nil:
+		var file ProfileConfig
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse profile %s: %w", ProfilePath(name), err)
+		}
+		file.apply(cfg)
+		found = true
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	if !found {
+		_, err := findProfile(name)
+		return err
+	}
+	return nil
+}
+
+func (p ProfileConfig) apply(cfg *Config) {
+	switch {
+	case p.Sources != nil:
+		cfg.Sources = p.Sources
+		cfg.Source = p.Source
+	case p.Source != "":
+		cfg.Source = p.Source
+		cfg.Sources = nil
+	}
+	if p.Mode != "" {
+		cfg.Mode = p.Mode
+	}
+	if p.Targets != nil {
+		cfg.Targets = p.Targets
+	}
+	if p.Ignore != nil {
+		cfg.Ignore = p.Ignore
+	}
+	if p.Audit.BlockThreshold != "" {
+		cfg.Audit.BlockThreshold = p.Audit.BlockThreshold
+	}
+}
+
+// saveProfile writes the fields the profile overrides back where the
+// profile is defined: profiles/<name>.yaml, or its entry under profiles: in
+// config.yaml. Fields equal to the config the profile is layered on are left
+// out so later edits to config.yaml still reach the profile. The shared
+// fields (hub) go to config.yaml.
+func (c *Config) saveProfile(name string) error {
+	raw, err := os.ReadFile(BaseConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if raw, err = migrateOnLoad(raw, globalMigrations); err != nil {
+		return err
+	}
+	var base Config
+	if err := yaml.Unmarshal(raw, &base); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	out := c.unexpanded()
+
+	path := ProfilePath(name)
+	inline, isInline := base.Profiles[name]
+	under := base
+	prev := inline
+	fileData, err := os.ReadFile(path)
+	hasFile := err == nil
+	switch {
+	case hasFile:
+		// The file is layered on the base and the inline definition
+		prev = ProfileConfig{}
+		if err := yaml.Unmarshal(fileData, &prev); err != nil {
+			return fmt.Errorf("failed to parse profile %s: %w", path, err)
+		}
+		if isInline {
+			inline.apply(&under)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read profile: %w", err)
+	}
+	overlay := profileOverlay(&under, out, prev)
+
+	writeBase := !hubEqual(base.Hub, out.Hub)
+	base.Hub = out.Hub
+	if isInline && !hasFile {
+		base.Profiles[name] = overlay
+		writeBase = true
+	} else {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create profiles directory: %w", err)
+		}
+		data, err := yaml.Marshal(overlay)
+		if err != nil {
+			return fmt.Errorf("failed to marshal profile: %w", err)
+		}
+		if err := utils.WriteFileAtomic(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write profile: %w", err)
+		}
+	}
+	if !writeBase {
+		return nil
+	}
+	return base.writeTo(BaseConfigPath())
+}
+
+// profileOverlay returns the overlayable fields of out that differ from
+// under, plus those prev already overrides.
+func profileOverlay(under, out *Config, prev ProfileConfig) ProfileConfig {
+	var p ProfileConfig
+	if prev.Source != "" || prev.Sources != nil || out.Source != under.Source || !sameYAML(out.Sources, under.Sources) {
+		p.Source, p.Sources = out.Source, out.Sources
+	}
+	if prev.Mode != "" || out.Mode != under.Mode {
+		p.Mode = out.Mode
+	}
+	if prev.Targets != nil || !sameYAML(out.Targets, under.Targets) {
+		p.Targets = out.Targets
+	}
+	if prev.Ignore != nil || !sameYAML(out.Ignore, under.Ignore) {
+		p.Ignore = out.Ignore
+	}
+	threshold, _ := normalizeAuditBlockThreshold(under.Audit.BlockThreshold)
+	if prev.Audit.BlockThreshold != "" || out.Audit.BlockThreshold != threshold {
+		p.Audit = out.Audit
+	}
+	return p
+}
+
+// sameYAML reports whether a and b are written the same way.
+func sameYAML(a, b any) bool {
+	da, errA := yaml.Marshal(a)
+	db, errB := yaml.Marshal(b)
+	return errA == nil && errB == nil && string(da) == string(db)
+}
+
+func hubEqual(a, b HubConfig) bool {
+	if a.Default != b.Default || len(a.Hubs) != len(b.Hubs) {
+		return false
+	}
+	for i := range a.Hubs {
+		if a.Hubs[i] != b.Hubs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupProfileConfig(t *testing.T, base string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte(base), 0644)
+	t.Setenv("SKILLSHARE_CONFIG", path)
+	t.Setenv("SKILLSHARE_PROFILE", "")
+	t.Cleanup(func() { profileOverride, profileOverrideSet = "", false })
+	return dir
+}
+
+func TestLoad_AppliesInlineThenFileProfile(t *testing.T) {
+	dir := setupProfileConfig(t, `version: 1
+source: /skills/base
+targets:
+  claude:
+    path: /claude
+audit:
+  block_threshold: HIGH
+profiles:
+  work:
+    source: /skills/work
+    targets:
+      cursor:
+        path: /cursor
+`)
+	os.MkdirAll(filepath.Join(dir, "profiles"), 0755)
+	os.WriteFile(filepath.Join(dir, "profiles", "work.yaml"), []byte("audit:\n  block_threshold: low\n"), 0644)
+
+	SetProfile("work")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Source != "/skills/work" {
+		t.Errorf("source = %q", cfg.Source)
+	}
+	if _, ok := cfg.Targets["claude"]; ok || len(cfg.Targets) != 1 {
+		t.Errorf("profile targets should replace base targets, got %v", cfg.Targets)
+	}
+	if cfg.Audit.BlockThreshold != "LOW" {
+		t.Errorf("threshold = %q", cfg.Audit.BlockThreshold)
+	}
+}
+
+func TestActiveProfile_Precedence(t *testing.T) {
+	dir := setupProfileConfig(t, "source: /skills\nprofiles:\n  a: {}\n  b: {}\n  c: {}\n")
+
+	if got := ActiveProfile(); got != "" {
+		t.Fatalf("no selection: got %q", got)
+	}
+	if err := UseProfile("a"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+	if got := ActiveProfile(); got != "a" {
+		t.Errorf("saved: got %q", got)
+	}
+	t.Setenv("SKILLSHARE_PROFILE", "b")
+	if got := ActiveProfile(); got != "b" {
+		t.Errorf("env: got %q", got)
+	}
+	SetProfile("c")
+	if got := ActiveProfile(); got != "c" {
+		t.Errorf("override: got %q", got)
+	}
+	SetProfile(DefaultProfile)
+	if got := ActiveProfile(); got != "" {
+		t.Errorf("default override: got %q", got)
+	}
+
+	if err := UseProfile(DefaultProfile); err != nil {
+		t.Fatalf("UseProfile(default): %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, activeProfileFile)); !os.IsNotExist(err) {
+		t.Error("selecting default should remove the saved profile")
+	}
+}
+
+func TestUseProfile_Unknown(t *testing.T) {
+	setupProfileConfig(t, "source: /skills\n")
+	err := UseProfile("missing")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestSave_WritesInlineProfileBack(t *testing.T) {
+	dir := setupProfileConfig(t, "version: 1\nsource: /skills/base\nprofiles:\n  work:\n    source: /skills/work\n")
+
+	SetProfile("work")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	cfg.Targets = map[string]TargetConfig{"codex": {Path: "/codex"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "profiles", "work.yaml")); !os.IsNotExist(err) {
+		t.Error("an inline profile should not be turned into a file")
+	}
+	SetProfile(DefaultProfile)
+	base, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	work := base.Profiles["work"]
+	if work.Source != "/skills/work" || work.Targets["codex"].Path != "/codex" {
+		t.Errorf("inline profile = %+v", work)
+	}
+	if base.Source != "/skills/base" || len(base.Targets) != 0 {
+		t.Errorf("base config should not receive profile values: %q %v", base.Source, base.Targets)
+	}
+}
+
+func TestSave_ProfileKeepsOnlyOverrides(t *testing.T) {
+	dir := setupProfileConfig(t, `version: 1
+source: /skills/base
+targets:
+  claude:
+    path: /claude
+`)
+	os.MkdirAll(filepath.Join(dir, "profiles"), 0755)
+	profilePath := filepath.Join(dir, "profiles", "work.yaml")
+	os.WriteFile(profilePath, []byte("audit:\n  block_threshold: HIGH\n"), 0644)
+
+	SetProfile("work")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	profile, _ := os.ReadFile(profilePath)
+	if got := string(profile); strings.Contains(got, "/skills/base") || strings.Contains(got, "/claude") || !strings.Contains(got, "HIGH") {
+		t.Errorf("profile should keep only its override, got:\n%s", got)
+	}
+
+	cfg.Targets["extra"] = TargetConfig{Path: "/extra"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	profile, _ = os.ReadFile(profilePath)
+	if got := string(profile); !strings.Contains(got, "/extra") || strings.Contains(got, "/skills/base") {
+		t.Errorf("profile should hold the changed targets but not the base source, got:\n%s", got)
+	}
+}
+
+func TestProfileDir(t *testing.T) {
+	setupProfileConfig(t, "source: /skills\n")
+	if got := ProfileDir("/state"); got != "/state" {
+		t.Errorf("default: got %q", got)
+	}
+	SetProfile("work")
+	if got := ProfileDir("/state"); got != filepath.Join("/state", "profiles", "work") {
+		t.Errorf("work: got %q", got)
+	}
+}
+
+func TestLockfilePath_PerProfile(t *testing.T) {
+	dir := setupProfileConfig(t, "source: /skills\nprofiles:\n  work:\n    source: /skills/work\n")
+	if got := LockfilePath(); got != filepath.Join(dir, "skillshare.lock") {
+		t.Errorf("default: got %q", got)
+	}
+	SetProfile("work")
+	work := LockfilePath()
+	if work != filepath.Join(dir, "profiles", "work", "skillshare.lock") {
+		t.Errorf("work: got %q", work)
+	}
+	SetProfile(DefaultProfile)
+	if got := LockfilePath(); got == work {
+		t.Errorf("switching back to default should not share the work lock %q", got)
+	}
+}
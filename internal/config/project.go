@@ -12,11 +12,16 @@ import (
 
 // ProjectTargetEntry supports both string and object forms in YAML.
 // String: "claude-code"
-// Object: { name: "my-custom-ide", path: ".my-ide/skills/" }
+// Object: { name: "my-custom-ide", path: ".my-ide/skills/", include: ["frontend/**"] }
 type ProjectTargetEntry struct {
-	Name string
-	Path string
-	Mode string // "merge" or "symlink", default "merge"
+	Name      string
+	Path      string
+	Mode      string   // "merge", "symlink" or "copy", default "merge"
+	Include   []string // Glob patterns selecting skills for this target
+	Exclude   []string // Glob patterns removing skills from this target
+	Format    string   // Output format, default from the target registry
+	Index     string   // Markdown file, relative to the project root, listing the target's skills
+	LinkStyle string   // "absolute" or "relative", default the top-level link_style
 }
 
 func (t *ProjectTargetEntry) UnmarshalYAML(value *yaml.Node) error {
@@ -26,9 +31,14 @@ func (t *ProjectTargetEntry) UnmarshalYAML(value *yaml.Node) error {
 	}
 
 	var decoded struct {
-		Name string `yaml:"name"`
-		Path string `yaml:"path"`
-		Mode string `yaml:"mode"`
+		Name      string   `yaml:"name"`
+		Path      string   `yaml:"path"`
+		Mode      string   `yaml:"mode"`
+		Include   []string `yaml:"include"`
+		Exclude   []string `yaml:"exclude"`
+		Format    string   `yaml:"format"`
+		Index     string   `yaml:"index"`
+		LinkStyle string   `yaml:"link_style"`
 	}
 	if err := value.Decode(&decoded); err != nil {
 		return err
@@ -36,24 +46,45 @@ func (t *ProjectTargetEntry) UnmarshalYAML(value *yaml.Node) error {
 	t.Name = strings.TrimSpace(decoded.Name)
 	t.Path = strings.TrimSpace(decoded.Path)
 	t.Mode = strings.TrimSpace(decoded.Mode)
+	t.Include = decoded.Include
+	t.Exclude = decoded.Exclude
+	t.Format = strings.TrimSpace(decoded.Format)
+	t.Index = strings.TrimSpace(decoded.Index)
+	t.LinkStyle = strings.TrimSpace(decoded.LinkStyle)
 	return nil
 }
 
 func (t ProjectTargetEntry) MarshalYAML() (interface{}, error) {
 	hasPath := strings.TrimSpace(t.Path) != ""
 	hasMode := strings.TrimSpace(t.Mode) != ""
+	hasFilters := len(t.Include) > 0 || len(t.Exclude) > 0
 
-	if !hasPath && !hasMode {
+	if !hasPath && !hasMode && !hasFilters && t.Format == "" && t.Index == "" && t.LinkStyle == "" {
 		return t.Name, nil
 	}
 
-	obj := map[string]string{"name": t.Name}
+	obj := map[string]interface{}{"name": t.Name}
 	if hasPath {
 		obj["path"] = t.Path
 	}
 	if hasMode {
 		obj["mode"] = t.Mode
 	}
+	if len(t.Include) > 0 {
+		obj["include"] = t.Include
+	}
+	if len(t.Exclude) > 0 {
+		obj["exclude"] = t.Exclude
+	}
+	if t.Format != "" {
+		obj["format"] = t.Format
+	}
+	if t.Index != "" {
+		obj["index"] = t.Index
+	}
+	if t.LinkStyle != "" {
+		obj["link_style"] = t.LinkStyle
+	}
 	return obj, nil
 }
 
@@ -66,10 +97,15 @@ type ProjectSkill struct {
 
 // ProjectConfig holds project-level config (.skillshare/config.yaml).
 type ProjectConfig struct {
-	Targets []ProjectTargetEntry `yaml:"targets"`
-	Skills  []ProjectSkill       `yaml:"skills,omitempty"`
-	Audit   AuditConfig          `yaml:"audit,omitempty"`
-	Hub     HubConfig            `yaml:"hub,omitempty"`
+	Version   int                  `yaml:"version"` // schema version, see CurrentConfigVersion
+	Targets   []ProjectTargetEntry `yaml:"targets"`
+	Skills    []ProjectSkill       `yaml:"skills,omitempty"`
+	Ignore    []string             `yaml:"ignore,omitempty"`
+	Naming    NamingConfig         `yaml:"naming,omitempty"`
+	Audit     AuditConfig          `yaml:"audit,omitempty"`
+	Hub       HubConfig            `yaml:"hub,omitempty"`
+	LinkStyle string               `yaml:"link_style,omitempty"` // absolute (default) or relative
+	Forges    map[string]string    `yaml:"forges,omitempty"`     // self-hosted git host -> forge type
 }
 
 // ProjectConfigPath returns the project config path for the given root.
@@ -77,6 +113,11 @@ func ProjectConfigPath(projectRoot string) string {
 	return filepath.Join(projectRoot, ".skillshare", "config.yaml")
 }
 
+// ProjectLockfilePath returns the skillshare.lock path for a project.
+func ProjectLockfilePath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".skillshare", "skillshare.lock")
+}
+
 // LoadProject loads the project config from the given root.
 func LoadProject(projectRoot string) (*ProjectConfig, error) {
 	path := ProjectConfigPath(projectRoot)
@@ -88,6 +129,11 @@ func LoadProject(projectRoot string) (*ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to read project config: %w", err)
 	}
 
+	data, err = migrateOnLoad(data, projectMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("project config: %w", err)
+	}
+
 	var cfg ProjectConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse project config: %w", err)
@@ -99,10 +145,26 @@ func LoadProject(projectRoot string) (*ProjectConfig, error) {
 	}
 	cfg.Audit.BlockThreshold = threshold
 
+	if err := cfg.Naming.Validate(); err != nil {
+		return nil, fmt.Errorf("project config: %w", err)
+	}
+	if err := validateLinkStyle(cfg.LinkStyle); err != nil {
+		return nil, fmt.Errorf("project config: %w", err)
+	}
+	if err := validateForges(cfg.Forges); err != nil {
+		return nil, fmt.Errorf("project config: %w", err)
+	}
+
 	for _, target := range cfg.Targets {
 		if strings.TrimSpace(target.Name) == "" {
 			return nil, fmt.Errorf("project config has target with empty name")
 		}
+		if err := validateFormat(target.Format, target.Mode); err != nil {
+			return nil, fmt.Errorf("project config: targets.%s.%w", target.Name, err)
+		}
+		if err := validateLinkStyle(target.LinkStyle); err != nil {
+			return nil, fmt.Errorf("project config: targets.%s.%w", target.Name, err)
+		}
 	}
 	for _, skill := range cfg.Skills {
 		if strings.TrimSpace(skill.Name) == "" {
@@ -124,12 +186,16 @@ func (c *ProjectConfig) Save(projectRoot string) error {
 		return fmt.Errorf("failed to create project config directory: %w", err)
 	}
 
+	c.Version = CurrentConfigVersion
 	data, err := yaml.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("failed to marshal project config: %w", err)
 	}
+	if err := backupOutdated(path); err != nil {
+		return err
+	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := utils.WriteFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write project config: %w", err)
 	}
 
@@ -145,10 +211,11 @@ func ResolveProjectTargets(projectRoot string, cfg *ProjectConfig) (map[string]T
 			continue
 		}
 
+		known, isKnown := LookupProjectTarget(name)
 		var targetPath string
 		if strings.TrimSpace(entry.Path) != "" {
 			targetPath = entry.Path
-		} else if known, ok := LookupProjectTarget(name); ok {
+		} else if isKnown {
 			targetPath = known.Path
 		} else {
 			return nil, fmt.Errorf("unknown target '%s' (missing path)", name)
@@ -162,7 +229,17 @@ func ResolveProjectTargets(projectRoot string, cfg *ProjectConfig) (map[string]T
 			absPath = filepath.Join(projectRoot, filepath.FromSlash(targetPath))
 		}
 
-		resolved[name] = TargetConfig{Path: absPath, Mode: entry.Mode}
+		target := TargetConfig{Path: absPath, Mode: entry.Mode, Include: entry.Include, Exclude: entry.Exclude, Format: entry.Format, LinkStyle: entry.LinkStyle}
+		if entry.Index != "" {
+			target.Index = entry.Index
+			if !filepath.IsAbs(entry.Index) {
+				target.Index = filepath.Join(projectRoot, filepath.FromSlash(entry.Index))
+			}
+		}
+		resolved[name] = target.WithDefaultNaming(cfg.Naming).WithDefaultFormat(registryFormat(known.defaultFormat, entry.Mode)).WithDefaultLinkStyle(cfg.LinkStyle).WithName(name)
+	}
+	if err := validateIndexes(resolved, "merge"); err != nil {
+		return nil, err
 	}
 
 	return resolved, nil
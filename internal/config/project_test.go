@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestProjectTargetEntry_FiltersRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	cfg := &ProjectConfig{Targets: []ProjectTargetEntry{
+		{Name: "claude-code"},
+		{Name: "cursor", Include: []string{"frontend/**"}, Exclude: []string{"frontend/legacy"}},
+	}}
+	if err := cfg.Save(root); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadProject(root)
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Targets, cfg.Targets) {
+		t.Fatalf("targets = %+v, want %+v", loaded.Targets, cfg.Targets)
+	}
+
+	var raw map[string]any
+	data, _ := os.ReadFile(ProjectConfigPath(root))
+	yaml.Unmarshal(data, &raw)
+	if first := raw["targets"].([]any)[0]; first != "claude-code" {
+		t.Errorf("target without filters should stay a plain string, got %v", first)
+	}
+}
+
+func TestResolveProjectTargets_CarriesFilters(t *testing.T) {
+	root := t.TempDir()
+	cfg := &ProjectConfig{Targets: []ProjectTargetEntry{
+		{Name: "claude-code", Exclude: []string{"codex-*"}},
+	}}
+
+	targets, err := ResolveProjectTargets(root, cfg)
+	if err != nil {
+		t.Fatalf("ResolveProjectTargets: %v", err)
+	}
+	got := targets["claude-code"]
+	if got.Path != filepath.Join(root, ".claude", "skills") {
+		t.Errorf("path = %s", got.Path)
+	}
+	if !reflect.DeepEqual(got.Exclude, []string{"codex-*"}) {
+		t.Errorf("exclude = %v, want [codex-*]", got.Exclude)
+	}
+}
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindInt
+	kindBool
+	kindObject
+	kindMap
+	kindList
+	kindTargetEntry // Project target: name string or {name, path, mode, ...}
+)
+
+// fieldSpec describes one config key. It drives 'config validate',
+// dotted-key get/set and the JSON Schema export.
+type fieldSpec struct {
+	kind        fieldKind
+	description string
+	enum        []string
+	foldCase    bool // enum compared case-insensitively
+	path        bool // filesystem path; ~ and ${VAR} allowed
+	pattern     bool // glob pattern
+
+	props    map[string]*fieldSpec // kindObject, object form of kindTargetEntry
+	required []string
+
+	elem     *fieldSpec         // kindMap values, kindList items
+	keyCheck func(string) error // kindMap keys
+	keyField string             // kindList: items can be addressed by this field instead of index
+}
+
+// TargetModes lists the valid values of mode and targets.<name>.mode.
+var TargetModes = []string{"merge", "symlink", "copy"}
+
+var auditThresholds = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO"}
+
+func stringField(description string) *fieldSpec {
+	return &fieldSpec{kind: kindString, description: description}
+}
+
+func pathField(description string) *fieldSpec {
+	return &fieldSpec{kind: kindString, path: true, description: description}
+}
+
+func listOf(elem *fieldSpec, description string) *fieldSpec {
+	return &fieldSpec{kind: kindList, elem: elem, description: description}
+}
+
+func modeField(description string) *fieldSpec {
+	return &fieldSpec{kind: kindString, enum: TargetModes, description: description}
+}
+
+func patternList(description string) *fieldSpec {
+	return listOf(&fieldSpec{kind: kindString, pattern: true}, description)
+}
+
+var (
+	auditSpec = &fieldSpec{kind: kindObject, description: "Security audit policy", props: map[string]*fieldSpec{
+		"block_threshold": {kind: kindString, enum: auditThresholds, foldCase: true,
+			description: "Block installs with findings at or above this severity (default CRITICAL)"},
+	}}
+
+	hubSpec = &fieldSpec{kind: kindObject, description: "Saved skill hubs", props: map[string]*fieldSpec{
+		"default": stringField("Label of the hub used by 'search --hub'"),
+		"hubs": {kind: kindList, keyField: "label", description: "Saved hub sources", elem: &fieldSpec{
+			kind:     kindObject,
+			required: []string{"label", "url"},
+			props: map[string]*fieldSpec{
+				"label":   stringField("Hub name"),
+				"url":     stringField("Index URL or path; ${VAR} allowed"),
+				"builtin": {kind: kindBool, description: "Shipped with skillshare"},
+			},
+		}},
+	}}
+
+	namingSpec = &fieldSpec{kind: kindObject, description: "How nested skills are named in targets", props: map[string]*fieldSpec{
+		"strategy": {kind: kindString, enum: NamingStrategies,
+			description: "full (a__b__c, default), last (c), last-n (last segments), prefix (per-folder prefix + last segment)"},
+		"segments": {kind: kindInt, description: "last-n: number of trailing path segments to keep"},
+		"prefixes": {kind: kindMap, elem: stringField(""), description: "prefix: folder -> prefix for skills under it"},
+		"aliases":  {kind: kindMap, elem: stringField(""), description: "Skill path -> exact target name"},
+		"collisions": {kind: kindString, enum: NamingCollisions,
+			description: "When two skills get the same name: error (default), suffix (-2, -3), layer (later layer wins)"},
+	}}
+
+	formatField = &fieldSpec{kind: kindString, enum: TargetFormats,
+		description: "What sync writes: skill-dir (SKILL.md folders, default), cursor-mdc, single-markdown or agents-md files"}
+
+	linkStyleField = &fieldSpec{kind: kindString, enum: LinkStyles,
+		description: "Links sync makes in merge and symlink mode: absolute (default) or relative to the link"}
+
+	forgesSpec = &fieldSpec{kind: kindMap, elem: &fieldSpec{kind: kindString, enum: ForgeTypes, foldCase: true},
+		description: "Self-hosted git hosts by forge type, so install can parse their web URLs (e.g. git.company.com: gitlab)"}
+
+	targetFieldSpec = &fieldSpec{kind: kindObject, required: []string{"path"}, props: map[string]*fieldSpec{
+		"path":       pathField("Skills directory of the AI CLI; ~ and ${VAR} allowed"),
+		"mode":       modeField("Sync mode for this target (default: top-level mode, then merge)"),
+		"include":    patternList("Only sync skills matching these globs"),
+		"exclude":    patternList("Skip skills matching these globs"),
+		"format":     formatField,
+		"index":      pathField("Markdown file whose managed block lists the synced skills; relative to the target, ~ and ${VAR} allowed"),
+		"naming":     namingSpec,
+		"link_style": linkStyleField,
+		"when": {kind: kindObject, description: "Only activate the target on matching machines", props: map[string]*fieldSpec{
+			"host":   stringField("Hostname glob"),
+			"os":     {kind: kindString, enum: []string{"linux", "darwin", "macos", "windows", "freebsd"}, foldCase: true, description: "Operating system"},
+			"exists": pathField("Path that must exist"),
+		}},
+	}}
+
+	targetsSpec = &fieldSpec{kind: kindMap, elem: targetFieldSpec, keyCheck: validateTargetKey, description: "Sync targets by name"}
+
+	profileSpec = &fieldSpec{kind: kindObject, description: "Overlay applied on top of config.yaml", props: map[string]*fieldSpec{
+		"source":  pathField("Source directory"),
+		"sources": listOf(pathField(""), "Ordered source layers; later layers win"),
+		"mode":    modeField("Default sync mode"),
+		"targets": targetsSpec,
+		"ignore":  listOf(stringField(""), "Gitignore-style patterns, relative to source"),
+		"audit":   auditSpec,
+	}}
+
+	globalSpec = &fieldSpec{kind: kindObject, props: map[string]*fieldSpec{
+		"version":    {kind: kindInt, description: "Config schema version"},
+		"source":     pathField("Source directory (write layer when sources is set)"),
+		"sources":    listOf(pathField(""), "Ordered source layers; later layers win"),
+		"mode":       modeField("Default sync mode"),
+		"targets":    targetsSpec,
+		"ignore":     listOf(stringField(""), "Gitignore-style patterns, relative to source"),
+		"naming":     namingSpec,
+		"audit":      auditSpec,
+		"hub":        hubSpec,
+		"link_style": linkStyleField,
+		"forges":     forgesSpec,
+		"profiles": {kind: kindMap, elem: profileSpec, keyCheck: ValidateProfileName,
+			description: "Named profiles, selected with --profile or SKILLSHARE_PROFILE"},
+	}}
+
+	projectTargetSpec = &fieldSpec{kind: kindTargetEntry, required: []string{"name"}, props: map[string]*fieldSpec{
+		"name":       stringField("Known target name or custom name"),
+		"path":       stringField("Skills directory, relative to the project root"),
+		"mode":       modeField("Sync mode (default merge)"),
+		"include":    patternList("Only sync skills matching these globs"),
+		"exclude":    patternList("Skip skills matching these globs"),
+		"format":     formatField,
+		"index":      stringField("Markdown file (relative to the project root) whose managed block lists the synced skills"),
+		"link_style": linkStyleField,
+	}}
+
+	projectSpec = &fieldSpec{kind: kindObject, props: map[string]*fieldSpec{
+		"version": {kind: kindInt, description: "Config schema version"},
+		"targets": {kind: kindList, keyField: "name", elem: projectTargetSpec, description: "Project targets"},
+		"skills": {kind: kindList, keyField: "name", description: "Remote skills installed into the project", elem: &fieldSpec{
+			kind:     kindObject,
+			required: []string{"name", "source"},
+			props: map[string]*fieldSpec{
+				"name":    stringField("Skill directory name"),
+				"source":  stringField("Install source"),
+				"tracked": {kind: kindBool, description: "Installed as a tracked repository"},
+			},
+		}},
+		"ignore":     listOf(stringField(""), "Gitignore-style patterns, relative to .skillshare/skills"),
+		"naming":     namingSpec,
+		"audit":      auditSpec,
+		"hub":        hubSpec,
+		"link_style": linkStyleField,
+		"forges":     forgesSpec,
+	}}
+)
+
+// ConfigSchema returns a JSON Schema for config.yaml.
+func ConfigSchema() map[string]any {
+	return rootSchema(globalSpec, "skillshare config", "Global skillshare config (~/.config/skillshare/config.yaml)")
+}
+
+// ProjectConfigSchema returns a JSON Schema for .skillshare/config.yaml.
+func ProjectConfigSchema() map[string]any {
+	return rootSchema(projectSpec, "skillshare project config", "Project skillshare config (.skillshare/config.yaml)")
+}
+
+func rootSchema(spec *fieldSpec, title, description string) map[string]any {
+	schema := spec.jsonSchema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = title
+	schema["description"] = description
+	return schema
+}
+
+func (f *fieldSpec) jsonSchema() map[string]any {
+	s := map[string]any{}
+	if f.description != "" {
+		s["description"] = f.description
+	}
+
+	switch f.kind {
+	case kindString:
+		s["type"] = "string"
+		if len(f.enum) > 0 {
+			values := append([]string(nil), f.enum...)
+			if f.foldCase {
+				for _, v := range f.enum {
+					if lower := strings.ToLower(v); lower != v {
+						values = append(values, lower)
+					}
+				}
+			}
+			s["enum"] = values
+		}
+	case kindInt:
+		s["type"] = "integer"
+		s["minimum"] = 0
+	case kindBool:
+		s["type"] = "boolean"
+	case kindObject:
+		f.objectSchema(s)
+	case kindMap:
+		s["type"] = "object"
+		s["additionalProperties"] = f.elem.jsonSchema()
+	case kindList:
+		s["type"] = "array"
+		s["items"] = f.elem.jsonSchema()
+	case kindTargetEntry:
+		object := map[string]any{}
+		f.objectSchema(object)
+		s["oneOf"] = []any{map[string]any{"type": "string"}, object}
+	}
+	return s
+}
+
+func (f *fieldSpec) objectSchema(s map[string]any) {
+	props := map[string]any{}
+	for name, prop := range f.props {
+		props[name] = prop.jsonSchema()
+	}
+	s["type"] = "object"
+	s["properties"] = props
+	s["additionalProperties"] = false
+	if len(f.required) > 0 {
+		s["required"] = f.required
+	}
+}
+
+// keys returns the property names of an object spec, sorted.
+func (f *fieldSpec) keys() []string {
+	names := make([]string, 0, len(f.props))
+	for name := range f.props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+)
+
+// SourceLayers returns the source directories in precedence order, lowest first.
+// A config with only `source:` has a single layer.
+func (c *Config) SourceLayers() []string {
+	if len(c.Sources) > 0 {
+		return c.Sources
+	}
+	return []string{c.Source}
+}
+
+// resolveSourceLayers makes Source the default write layer when `sources:` is used:
+// the last (highest-precedence) layer unless `source:` names one of the layers.
+func (c *Config) resolveSourceLayers() error {
+	if len(c.Sources) == 0 {
+		return nil
+	}
+	if c.Source == "" {
+		c.Source = c.Sources[len(c.Sources)-1]
+		return nil
+	}
+	for _, src := range c.Sources {
+		if filepath.Clean(src) == filepath.Clean(c.Source) {
+			return nil
+		}
+	}
+	return fmt.Errorf("source %s must be one of the sources layers", c.Source)
+}
+
+// ResolveLayer picks a source layer by 1-based index, directory name or path.
+func (c *Config) ResolveLayer(selector string) (string, error) {
+	layers := c.SourceLayers()
+
+	if n, err := strconv.Atoi(selector); err == nil {
+		if n < 1 || n > len(layers) {
+			return "", fmt.Errorf("layer %d out of range (1-%d)", n, len(layers))
+		}
+		return layers[n-1], nil
+	}
+
+	path := filepath.Clean(expandPath(selector))
+	var matches []string
+	for _, layer := range layers {
+		if filepath.Clean(layer) == path {
+			return layer, nil
+		}
+		if filepath.Base(layer) == selector {
+			matches = append(matches, layer)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no source layer matches %q", selector)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("layer %q is ambiguous; use its index or full path", selector)
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveSourceLayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    string
+		wantErr bool
+	}{
+		{"single source", Config{Source: "/skills"}, "/skills", false},
+		{"defaults to last layer", Config{Sources: []string{"/base", "/team"}}, "/team", false},
+		{"explicit write layer", Config{Source: "/base", Sources: []string{"/base", "/team"}}, "/base", false},
+		{"source outside layers", Config{Source: "/other", Sources: []string{"/base", "/team"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.resolveSourceLayers()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveSourceLayers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.cfg.Source != tt.want {
+				t.Errorf("Source = %q, want %q", tt.cfg.Source, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLayer(t *testing.T) {
+	cfg := &Config{Sources: []string{"/org/skills", "/team/skills", "/home/me/personal"}}
+
+	tests := []struct {
+		selector string
+		want     string
+		errMsg   string
+	}{
+		{"1", "/org/skills", ""},
+		{"3", "/home/me/personal", ""},
+		{"4", "", "out of range"},
+		{"personal", "/home/me/personal", ""},
+		{"/team/skills", "/team/skills", ""},
+		{"skills", "", "ambiguous"},
+		{"missing", "", "no source layer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := cfg.ResolveLayer(tt.selector)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("ResolveLayer(%q) error = %v, want %q", tt.selector, err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveLayer(%q) error = %v", tt.selector, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveLayer(%q) = %q, want %q", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
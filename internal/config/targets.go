@@ -2,6 +2,8 @@ package config
 
 import (
 	_ "embed"
+	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
 	"sync"
@@ -11,25 +13,51 @@ import (
 )
 
 type targetSpec struct {
+	Name        string `yaml:"name,omitempty"` // Shorthand for global_name and project_name
 	GlobalName  string `yaml:"global_name"`
 	ProjectName string `yaml:"project_name"`
 	GlobalPath  string `yaml:"global_path"`
 	ProjectPath string `yaml:"project_path"`
+	Format      string `yaml:"format,omitempty"` // Output format sync writes, default skill-dir
+
+	origin string // "builtin" or the registry file that last defined/overrode the spec
 }
 
 type targetsFile struct {
 	Targets []targetSpec `yaml:"targets"`
 }
 
+// TargetsFileName is the name of the user target registry file, read from the
+// global config directory and from a project's .skillshare directory.
+const TargetsFileName = "targets.yaml"
+
+// TargetOriginBuiltin is the origin of specs from the embedded targets.yaml.
+const TargetOriginBuiltin = "builtin"
+
 //go:embed targets.yaml
 var defaultTargetsData []byte
 
 var (
 	loadedTargets   []targetSpec
 	loadTargetsErr  error
+	registryErrs    []error
 	loadTargetsOnce sync.Once
 )
 
+// UserTargetsPath returns the global user target registry path
+// (next to config.yaml, so it follows SKILLSHARE_CONFIG).
+func UserTargetsPath() string {
+	return filepath.Join(filepath.Dir(BaseConfigPath()), TargetsFileName)
+}
+
+// ProjectTargetsPath returns the project target registry path for the given root.
+func ProjectTargetsPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".skillshare", TargetsFileName)
+}
+
+// loadTargetSpecs returns the embedded specs merged with the user registry
+// files. The project registry is looked up in the current working directory,
+// which is the project root for every project-mode command.
 func loadTargetSpecs() ([]targetSpec, error) {
 	loadTargetsOnce.Do(func() {
 		var file targetsFile
@@ -37,12 +65,165 @@ func loadTargetSpecs() ([]targetSpec, error) {
 			loadTargetsErr = err
 			return
 		}
-		loadedTargets = file.Targets
+		specs := file.Targets
+		for i := range specs {
+			specs[i].origin = TargetOriginBuiltin
+		}
+
+		specs = mergeTargetsFile(specs, UserTargetsPath(), false)
+		if cwd, err := os.Getwd(); err == nil {
+			specs = mergeTargetsFile(specs, ProjectTargetsPath(cwd), true)
+		}
+		loadedTargets = specs
 	})
 
 	return loadedTargets, loadTargetsErr
 }
 
+// mergeTargetsFile overlays the specs in a user registry file onto base.
+// A missing file is not an error; a malformed one is recorded in
+// TargetRegistryErrors and skipped so built-in targets keep working.
+// Project registries only define project names and paths.
+func mergeTargetsFile(base []targetSpec, path string, projectOnly bool) []targetSpec {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			registryErrs = append(registryErrs, fmt.Errorf("%s: %w", path, err))
+		}
+		return base
+	}
+
+	var file targetsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		registryErrs = append(registryErrs, fmt.Errorf("%s: %w", path, err))
+		return base
+	}
+
+	for i, spec := range file.Targets {
+		if projectOnly {
+			spec.GlobalName, spec.GlobalPath = "", ""
+		}
+		if spec.Name == "" && spec.GlobalName == "" && spec.ProjectName == "" {
+			registryErrs = append(registryErrs, fmt.Errorf("%s: target #%d has no name", path, i+1))
+			continue
+		}
+		if spec.GlobalPath == "" && spec.ProjectPath == "" {
+			registryErrs = append(registryErrs, fmt.Errorf("%s: target '%s' sets no path", path, specDisplayName(spec)))
+			continue
+		}
+		if err := validateFormat(spec.Format, ""); err != nil {
+			registryErrs = append(registryErrs, fmt.Errorf("%s: target '%s': %w", path, specDisplayName(spec), err))
+			continue
+		}
+		spec.origin = path
+
+		// Known target: override its paths, keep its names
+		if idx := findTargetSpec(base, spec); idx >= 0 {
+			base[idx] = overlayTargetSpec(base[idx], spec)
+			continue
+		}
+
+		// New target: the shorthand name applies to whichever paths are given
+		if spec.Name != "" {
+			if spec.GlobalName == "" && spec.GlobalPath != "" {
+				spec.GlobalName = spec.Name
+			}
+			if spec.ProjectName == "" && spec.ProjectPath != "" {
+				spec.ProjectName = spec.Name
+			}
+		}
+		if (spec.GlobalName == "" || spec.GlobalPath == "") && (spec.ProjectName == "" || spec.ProjectPath == "") {
+			registryErrs = append(registryErrs, fmt.Errorf("%s: new target '%s' needs a name for its path", path, specDisplayName(spec)))
+			continue
+		}
+		if spec.GlobalPath == "" {
+			spec.GlobalName = ""
+		}
+		if spec.ProjectPath == "" {
+			spec.ProjectName = ""
+		}
+		spec.Name = ""
+		base = append(base, spec)
+	}
+	return base
+}
+
+// findTargetSpec returns the index of the spec sharing a global or project
+// name (or the shorthand name), or -1.
+func findTargetSpec(specs []targetSpec, spec targetSpec) int {
+	for i, s := range specs {
+		switch {
+		case spec.GlobalName != "" && s.GlobalName == spec.GlobalName,
+			spec.ProjectName != "" && s.ProjectName == spec.ProjectName,
+			spec.Name != "" && (s.GlobalName == spec.Name || s.ProjectName == spec.Name):
+			return i
+		}
+	}
+	return -1
+}
+
+// overlayTargetSpec replaces the paths of base that override sets.
+func overlayTargetSpec(base, override targetSpec) targetSpec {
+	if override.GlobalPath != "" {
+		base.GlobalPath = override.GlobalPath
+	}
+	if override.ProjectPath != "" {
+		base.ProjectPath = override.ProjectPath
+	}
+	if override.Format != "" {
+		base.Format = override.Format
+	}
+	base.origin = override.origin
+	return base
+}
+
+func specDisplayName(spec targetSpec) string {
+	switch {
+	case spec.Name != "":
+		return spec.Name
+	case spec.GlobalName != "":
+		return spec.GlobalName
+	}
+	return spec.ProjectName
+}
+
+// TargetRegistryErrors returns problems found in user target registry files.
+func TargetRegistryErrors() []error {
+	loadTargetSpecs()
+	return registryErrs
+}
+
+// TargetRegistryEntry describes a known target and where its spec came from.
+type TargetRegistryEntry struct {
+	GlobalName  string
+	ProjectName string
+	GlobalPath  string
+	ProjectPath string
+	Format      string // Empty for skill-dir
+	Origin      string // "builtin" or the registry file path
+}
+
+// TargetRegistry returns every known target spec with its origin, in load order.
+func TargetRegistry() []TargetRegistryEntry {
+	specs, err := loadTargetSpecs()
+	if err != nil {
+		return nil
+	}
+
+	entries := make([]TargetRegistryEntry, 0, len(specs))
+	for _, spec := range specs {
+		entries = append(entries, TargetRegistryEntry{
+			GlobalName:  spec.GlobalName,
+			ProjectName: spec.ProjectName,
+			GlobalPath:  spec.GlobalPath,
+			ProjectPath: spec.ProjectPath,
+			Format:      spec.Format,
+			Origin:      spec.origin,
+		})
+	}
+	return entries
+}
+
 // DefaultTargets returns the well-known CLI skills directories for global mode.
 func DefaultTargets() map[string]TargetConfig {
 	specs, err := loadTargetSpecs()
@@ -56,7 +237,7 @@ func DefaultTargets() map[string]TargetConfig {
 			continue
 		}
 		path := normalizeTargetPath(spec.GlobalPath)
-		targets[spec.GlobalName] = TargetConfig{Path: path}
+		targets[spec.GlobalName] = TargetConfig{Path: path, defaultFormat: spec.Format}
 	}
 
 	return targets
@@ -75,7 +256,7 @@ func ProjectTargets() map[string]TargetConfig {
 			continue
 		}
 		path := normalizeTargetPath(spec.ProjectPath)
-		targets[spec.ProjectName] = TargetConfig{Path: path}
+		targets[spec.ProjectName] = TargetConfig{Path: path, defaultFormat: spec.Format}
 	}
 
 	return targets
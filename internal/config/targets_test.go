@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -90,3 +92,83 @@ func TestGroupedProjectTargets_MembersAreSorted(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeTargetsFile_OverridesAndAdds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TargetsFileName)
+	os.WriteFile(path, []byte(`targets:
+  - name: claude
+    global_path: ~/.claude-fork/skills
+  - name: in-house
+    global_path: ~/.in-house/skills
+    project_path: .in-house/skills
+  - project_name: project-only
+    project_path: .project-only/skills
+`), 0644)
+
+	base := []targetSpec{{
+		GlobalName: "claude", ProjectName: "claude-code",
+		GlobalPath: "~/.claude/skills", ProjectPath: ".claude/skills", origin: TargetOriginBuiltin,
+	}}
+	registryErrs = nil
+	specs := mergeTargetsFile(base, path, false)
+
+	if len(specs) != 3 {
+		t.Fatalf("got %d specs, want 3: %+v", len(specs), specs)
+	}
+	claude := specs[0]
+	if claude.GlobalPath != "~/.claude-fork/skills" || claude.ProjectPath != ".claude/skills" {
+		t.Errorf("claude paths = %q, %q", claude.GlobalPath, claude.ProjectPath)
+	}
+	if claude.ProjectName != "claude-code" || claude.origin != path {
+		t.Errorf("claude project name = %q origin = %q", claude.ProjectName, claude.origin)
+	}
+	if specs[1].GlobalName != "in-house" || specs[1].ProjectName != "in-house" {
+		t.Errorf("shorthand name should set both names, got %+v", specs[1])
+	}
+	if specs[2].GlobalName != "" || specs[2].ProjectName != "project-only" {
+		t.Errorf("project-only spec = %+v", specs[2])
+	}
+	if len(registryErrs) != 0 {
+		t.Errorf("unexpected errors: %v", registryErrs)
+	}
+}
+
+func TestMergeTargetsFile_ProjectRegistryIgnoresGlobalFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TargetsFileName)
+	os.WriteFile(path, []byte(`targets:
+  - name: team-agent
+    global_path: ~/.team/skills
+    project_path: .team/skills
+`), 0644)
+
+	registryErrs = nil
+	specs := mergeTargetsFile(nil, path, true)
+
+	if len(specs) != 1 || specs[0].GlobalName != "" || specs[0].ProjectName != "team-agent" {
+		t.Fatalf("specs = %+v", specs)
+	}
+}
+
+func TestMergeTargetsFile_InvalidEntries(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.yaml")
+	os.WriteFile(broken, []byte("targets: [\n"), 0644)
+	invalid := filepath.Join(dir, TargetsFileName)
+	os.WriteFile(invalid, []byte(`targets:
+  - global_path: ~/.nameless/skills
+  - name: no-path
+`), 0644)
+
+	registryErrs = nil
+	base := []targetSpec{{GlobalName: "claude", GlobalPath: "~/.claude/skills"}}
+	specs := mergeTargetsFile(base, broken, false)
+	specs = mergeTargetsFile(specs, invalid, false)
+	specs = mergeTargetsFile(specs, filepath.Join(dir, "missing.yaml"), false)
+
+	if len(specs) != 1 {
+		t.Errorf("invalid entries should be skipped, got %+v", specs)
+	}
+	if len(registryErrs) != 3 {
+		t.Errorf("got %d errors, want 3: %v", len(registryErrs), registryErrs)
+	}
+}
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"skillshare/internal/validate"
+)
+
+// FieldError is a validation problem at a dotted config key.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidateConfigData checks a config.yaml document: unknown keys, value
+// types, modes, paths and patterns.
+func ValidateConfigData(data []byte) []FieldError {
+	return validateData(data, globalSpec, checkSourceLayers)
+}
+
+// ValidateProjectConfigData checks a .skillshare/config.yaml document.
+func ValidateProjectConfigData(data []byte) []FieldError {
+	return validateData(data, projectSpec, nil)
+}
+
+// ValidateProfileData checks a profiles/<name>.yaml overlay.
+func ValidateProfileData(data []byte) []FieldError {
+	return validateData(data, profileSpec, checkSourceLayers)
+}
+
+func validateData(data []byte, spec *fieldSpec, extra func(*yaml.Node) []FieldError) []FieldError {
+	root, err := parseDocument(data)
+	if err != nil {
+		return []FieldError{{Message: err.Error()}}
+	}
+
+	var errs []FieldError
+	spec.check(root, "", &errs)
+	if spec.props["version"] != nil {
+		if node := mappingValue(root, "version"); node != nil {
+			if v, err := strconv.Atoi(node.Value); err == nil && v > CurrentConfigVersion {
+				errs = append(errs, FieldError{Field: "version", Line: node.Line,
+					Message: fmt.Sprintf("version %d is newer than this skillshare supports (%d)", v, CurrentConfigVersion)})
+			}
+		}
+	}
+	if extra != nil {
+		errs = append(errs, extra(root)...)
+	}
+	return errs
+}
+
+// parseDocument returns the top-level mapping of a YAML document.
+func parseDocument(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if doc.Kind == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config is not a YAML mapping")
+	}
+	return doc.Content[0], nil
+}
+
+// checkSourceLayers requires source to be one of sources when both are set.
+func checkSourceLayers(root *yaml.Node) []FieldError {
+	source := mappingValue(root, "source")
+	sources := mappingValue(root, "sources")
+	if source == nil || source.Value == "" || sources == nil || sources.Kind != yaml.SequenceNode || len(sources.Content) == 0 {
+		return nil
+	}
+	for _, item := range sources.Content {
+		if filepath.Clean(item.Value) == filepath.Clean(source.Value) {
+			return nil
+		}
+	}
+	return []FieldError{{Field: "source", Line: source.Line, Message: "must be one of the sources layers"}}
+}
+
+func joinField(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func (f *fieldSpec) check(node *yaml.Node, field string, errs *[]FieldError) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Field: field, Line: node.Line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch f.kind {
+	case kindString:
+		if node.Kind != yaml.ScalarNode {
+			fail("expected a string")
+			return
+		}
+		f.checkString(node.Value, fail)
+	case kindInt:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			fail("expected an integer")
+		}
+	case kindBool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			fail("expected true or false")
+		}
+	case kindObject:
+		if node.Kind != yaml.MappingNode {
+			fail("expected a mapping")
+			return
+		}
+		f.checkObject(node, field, errs)
+	case kindMap:
+		if node.Kind != yaml.MappingNode {
+			fail("expected a mapping")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			child := joinField(field, key.Value)
+			if f.keyCheck != nil {
+				if err := f.keyCheck(key.Value); err != nil {
+					*errs = append(*errs, FieldError{Field: child, Line: key.Line, Message: err.Error()})
+				}
+			}
+			f.elem.check(node.Content[i+1], child, errs)
+		}
+	case kindList:
+		if node.Kind != yaml.SequenceNode {
+			fail("expected a list")
+			return
+		}
+		for i, item := range node.Content {
+			f.elem.check(item, joinField(field, strconv.Itoa(i)), errs)
+		}
+	case kindTargetEntry:
+		switch node.Kind {
+		case yaml.ScalarNode:
+			if strings.TrimSpace(node.Value) == "" {
+				fail("target name cannot be empty")
+			}
+		case yaml.MappingNode:
+			f.checkObject(node, field, errs)
+		default:
+			fail("expected a target name or mapping")
+		}
+	}
+}
+
+func (f *fieldSpec) checkString(value string, fail func(string, ...any)) {
+	if len(f.enum) > 0 && !f.allows(value) {
+		fail("invalid value %q (expected one of: %s)", value, strings.Join(f.enum, ", "))
+	}
+	if f.path && value != "" {
+		if err := validate.Path(value); err != nil {
+			fail("%v", err)
+		}
+		// Syntax only: variables may be set on another machine
+		if _, err := interpolateWith(value, func(string) (string, bool) { return "x", true }); err != nil {
+			fail("%v", err)
+		}
+	}
+	if f.pattern {
+		if _, err := path.Match(value, ""); err != nil {
+			fail("invalid glob pattern %q", value)
+		}
+	}
+}
+
+func (f *fieldSpec) allows(value string) bool {
+	for _, v := range f.enum {
+		if v == value || (f.foldCase && strings.EqualFold(v, strings.TrimSpace(value))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fieldSpec) checkObject(node *yaml.Node, field string, errs *[]FieldError) {
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		child := joinField(field, key.Value)
+		prop, ok := f.props[key.Value]
+		if !ok {
+			msg := "unknown key"
+			if hint := closestKey(key.Value, f.keys()); hint != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", hint)
+			}
+			*errs = append(*errs, FieldError{Field: child, Line: key.Line, Message: msg})
+			continue
+		}
+		seen[key.Value] = true
+		prop.check(node.Content[i+1], child, errs)
+	}
+	for _, name := range f.required {
+		if !seen[name] {
+			*errs = append(*errs, FieldError{Field: field, Line: node.Line, Message: fmt.Sprintf("missing required key %q", name)})
+		}
+	}
+}
+
+// closestKey suggests a known key within two edits of name.
+func closestKey(name string, known []string) string {
+	best, bestDist := "", 3
+	for _, k := range known {
+		if d := editDistance(strings.ToLower(name), k); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func validateTargetKey(name string) error {
+	return validate.TargetName(name)
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateConfigData(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		fields []string // Expected error fields, in order
+	}{
+		{"valid", `version: 1
+source: ~/skills
+mode: merge
+targets:
+  claude:
+    path: ${HOME}/.claude/skills
+    mode: copy
+    include: ["team-*"]
+    when:
+      os: macOS
+audit:
+  block_threshold: high
+`, nil},
+		{"unknown top-level key", "sorce: /skills\n", []string{"sorce"}},
+		{"unknown target key", "targets:\n  claude:\n    path: /x\n    mdoe: copy\n", []string{"targets.claude.mdoe"}},
+		{"bad mode", "targets:\n  claude:\n    path: /x\n    mode: mirror\n", []string{"targets.claude.mode"}},
+		{"bad default mode", "mode: links\n", []string{"mode"}},
+		{"missing path", "targets:\n  claude:\n    mode: copy\n", []string{"targets.claude"}},
+		{"bad target name", "targets:\n  9lives:\n    path: /x\n", []string{"targets.9lives"}},
+		{"bad glob", "targets:\n  claude:\n    path: /x\n    exclude: [\"[abc\"]\n", []string{"targets.claude.exclude.0"}},
+		{"bad threshold", "audit:\n  block_threshold: severe\n", []string{"audit.block_threshold"}},
+		{"bad interpolation", "source: ${HOME\n", []string{"source"}},
+		{"wrong type", "ignore: drafts\n", []string{"ignore"}},
+		{"newer version", "version: 99\n", []string{"version"}},
+		{"source outside layers", "source: /c\nsources: [/a, /b]\n", []string{"source"}},
+		{"profile checked", "profiles:\n  work:\n    mode: bogus\n", []string{"profiles.work.mode"}},
+		{"bad naming strategy", "naming:\n  strategy: short\n", []string{"naming.strategy"}},
+		{"bad target naming", "targets:\n  claude:\n    path: /x\n    naming:\n      collisions: merge\n", []string{"targets.claude.naming.collisions"}},
+		{"bad target format", "targets:\n  cursor:\n    path: /x\n    format: mdc\n", []string{"targets.cursor.format"}},
+		{"bad link style", "link_style: symbolic\ntargets:\n  claude:\n    path: /x\n    link_style: hard\n", []string{"link_style", "targets.claude.link_style"}},
+		{"bad forge type", "forges:\n  git.company.com: sourcehut\n", []string{"forges.git.company.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateConfigData([]byte(tt.yaml))
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Field)
+				if e.Line == 0 {
+					t.Errorf("%s: missing line number", e.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.fields) {
+				t.Errorf("error fields = %v, want %v (%v)", got, tt.fields, errs)
+			}
+		})
+	}
+}
+
+func TestValidateConfigData_SuggestsKey(t *testing.T) {
+	errs := ValidateConfigData([]byte("targets:\n  claude:\n    path: /x\n    exlude: [a]\n"))
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `did you mean "exclude"`) {
+		t.Fatalf("got %v", errs)
+	}
+}
+
+func TestValidateProjectConfigData(t *testing.T) {
+	valid := `version: 1
+targets:
+  - claude-code
+  - name: custom
+    path: .custom/skills
+    mode: copy
+skills:
+  - name: pdf
+    source: anthropics/skills/pdf
+`
+	if errs := ValidateProjectConfigData([]byte(valid)); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	errs := ValidateProjectConfigData([]byte("targets:\n  - name: x\n    mode: nope\nskills:\n  - name: pdf\n"))
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	if want := []string{"targets.0.mode", "skills.0"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
+
+func TestValidateConfigData_DefaultTargets(t *testing.T) {
+	cfg := Config{Version: CurrentConfigVersion, Source: "~/skills", Targets: DefaultTargets()}
+	data, err := yaml.Marshal(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := ValidateConfigData(data); len(errs) != 0 {
+		t.Errorf("config with built-in targets should be valid: %v", errs)
+	}
+}
+
+// The schema must cover every YAML key of the config structs.
+func TestSchema_CoversStructFields(t *testing.T) {
+	checkSpecCovers(t, "config", globalSpec, reflect.TypeOf(Config{}))
+	checkSpecCovers(t, "profile", profileSpec, reflect.TypeOf(ProfileConfig{}))
+	checkSpecCovers(t, "project", projectSpec, reflect.TypeOf(ProjectConfig{}))
+}
+
+func checkSpecCovers(t *testing.T, path string, spec *fieldSpec, typ reflect.Type) {
+	t.Helper()
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || spec.props == nil {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		prop, ok := spec.props[tag]
+		if !ok {
+			t.Errorf("%s.%s is missing from the schema", path, tag)
+			continue
+		}
+		if prop.elem != nil {
+			prop = prop.elem
+		}
+		checkSpecCovers(t, path+"."+tag, prop, f.Type)
+	}
+}
+
+func TestConfigSchema_IsJSON(t *testing.T) {
+	for name, schema := range map[string]map[string]any{"global": ConfigSchema(), "project": ProjectConfigSchema()} {
+		data, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !strings.Contains(string(data), `"additionalProperties":false`) {
+			t.Errorf("%s schema should reject unknown keys", name)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package filelock provides an advisory, cross-process lock so that CLI
+// commands and the UI server don't interleave writes to the config, the
+// source directory and targets.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is how long a mutating operation waits for another process.
+const DefaultTimeout = 30 * time.Second
+
+// pollInterval is the delay between attempts while waiting.
+const pollInterval = 100 * time.Millisecond
+
+// errWouldBlock is returned by tryLock when another process holds the lock.
+var errWouldBlock = errors.New("lock held by another process")
+
+// Holder describes the process that holds a lock.
+type Holder struct {
+	PID     int
+	Command string
+	Since   time.Time
+}
+
+func (h Holder) String() string {
+	if h.PID == 0 {
+		return "another skillshare process"
+	}
+	s := fmt.Sprintf("pid %d", h.PID)
+	if h.Command != "" {
+		s += " (" + h.Command + ")"
+	}
+	if !h.Since.IsZero() {
+		s += " since " + h.Since.Format("15:04:05")
+	}
+	return s
+}
+
+// LockedError is returned when the lock is still held after the timeout.
+type LockedError struct {
+	Path   string
+	Holder Holder
+	Waited time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("locked by %s; gave up after %s (set SKILLSHARE_LOCK_TIMEOUT to wait longer)",
+		e.Holder, e.Waited.Round(time.Second))
+}
+
+// Lock is an exclusive lock held through an open file. The OS releases it
+// when the process exits, so a crashed command never leaves a stale lock.
+type Lock struct {
+	file *os.File
+}
+
+// Acquire takes the lock at path, waiting up to timeout. onWait, if not nil,
+// is called once with the current holder when the lock is busy.
+func Acquire(path, command string, timeout time.Duration, onWait func(Holder)) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	start := time.Now()
+	waited := false
+	for {
+		err := tryLock(f)
+		if err == nil {
+			writeHolder(f, command)
+			return &Lock{file: f}, nil
+		}
+		if !errors.Is(err, errWouldBlock) {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+
+		holder := ReadHolder(path)
+		if time.Since(start) >= timeout {
+			f.Close()
+			return nil, &LockedError{Path: path, Holder: holder, Waited: time.Since(start)}
+		}
+		if !waited && onWait != nil {
+			onWait(holder)
+		}
+		waited = true
+		time.Sleep(pollInterval)
+	}
+}
+
+// Release unlocks and closes the lock file.
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	l.file.Truncate(0) //nolint:errcheck
+	err := unlock(l.file)
+	if cerr := l.file.Close(); err == nil {
+		err = cerr
+	}
+	l.file = nil
+	return err
+}
+
+// ReadHolder returns the holder recorded in the lock file, if any.
+func ReadHolder(path string) Holder {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Holder{}
+	}
+	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 3)
+	var h Holder
+	if len(lines) > 0 {
+		h.PID, _ = strconv.Atoi(lines[0])
+	}
+	if len(lines) > 1 {
+		h.Command = lines[1]
+	}
+	if len(lines) > 2 {
+		h.Since, _ = time.Parse(time.RFC3339, lines[2])
+	}
+	return h
+}
+
+func writeHolder(f *os.File, command string) {
+	info := fmt.Sprintf("%d\n%s\n%s\n", os.Getpid(), command, time.Now().Format(time.RFC3339))
+	if err := f.Truncate(0); err == nil {
+		f.WriteAt([]byte(info), 0) //nolint:errcheck
+	}
+}
+
+// TimeoutFromEnv reads SKILLSHARE_LOCK_TIMEOUT (a duration such as "2m",
+// or seconds), falling back to DefaultTimeout.
+func TimeoutFromEnv() time.Duration {
+	v := strings.TrimSpace(os.Getenv("SKILLSHARE_LOCK_TIMEOUT"))
+	if v == "" {
+		return DefaultTimeout
+	}
+	if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+		return d
+	}
+	if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second
+	}
+	return DefaultTimeout
+}
@@ -0,0 +1,81 @@
+package filelock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquire_ExclusiveUntilRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "write.lock")
+
+	first, err := Acquire(path, "skillshare sync", time.Second, nil)
+	if err != nil {
+		t.Fatalf("first Acquire: %v", err)
+	}
+
+	waitedFor := Holder{}
+	_, err = Acquire(path, "skillshare install", 200*time.Millisecond, func(h Holder) { waitedFor = h })
+	var locked *LockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("second Acquire should time out with LockedError, got %v", err)
+	}
+	if locked.Holder.PID != os.Getpid() || locked.Holder.Command != "skillshare sync" {
+		t.Errorf("holder = %+v", locked.Holder)
+	}
+	if waitedFor.PID != os.Getpid() {
+		t.Errorf("onWait holder = %+v", waitedFor)
+	}
+	if !strings.Contains(err.Error(), "locked by pid") {
+		t.Errorf("error = %q", err)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	second, err := Acquire(path, "skillshare install", time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire after release: %v", err)
+	}
+	second.Release()
+}
+
+func TestAcquire_WaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "write.lock")
+	first, err := Acquire(path, "first", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		first.Release()
+	}()
+
+	second, err := Acquire(path, "second", 5*time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire should succeed once the holder releases: %v", err)
+	}
+	second.Release()
+}
+
+func TestTimeoutFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultTimeout},
+		{"2m", 2 * time.Minute},
+		{"5", 5 * time.Second},
+		{"0", 0},
+		{"soon", DefaultTimeout},
+	}
+	for _, tt := range tests {
+		t.Setenv("SKILLSHARE_LOCK_TIMEOUT", tt.value)
+		if got := TimeoutFromEnv(); got != tt.want {
+			t.Errorf("TimeoutFromEnv(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
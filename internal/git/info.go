@@ -269,16 +269,26 @@ func GetCurrentBranch(repoPath string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-// GetBehindCount fetches from origin and returns how many commits local is behind
+// GetBehindCount fetches from origin and returns how many commits local is behind.
+// A pinned repo is compared against its pinned ref rather than its branch.
 func GetBehindCount(repoPath string) (int, error) {
-	if err := Fetch(repoPath); err != nil {
-		return 0, err
-	}
-	branch, err := GetCurrentBranch(repoPath)
-	if err != nil {
-		return 0, err
+	var target string
+	if ref := PinnedRef(repoPath); ref != "" {
+		if err := fetchPinned(repoPath); err != nil {
+			return 0, err
+		}
+		target = pinTarget(repoPath, ref)
+	} else {
+		if err := Fetch(repoPath); err != nil {
+			return 0, err
+		}
+		branch, err := GetCurrentBranch(repoPath)
+		if err != nil {
+			return 0, err
+		}
+		target = "origin/" + branch
 	}
-	cmd := exec.Command("git", "rev-list", "--count", "HEAD..origin/"+branch)
+	cmd := exec.Command("git", "rev-list", "--count", "HEAD.."+target)
 	cmd.Dir = repoPath
 	out, err := cmd.Output()
 	if err != nil {
@@ -307,6 +317,47 @@ func GetRemoteHeadHash(repoURL string) (string, error) {
 	return hash, nil
 }
 
+// GetRemoteRefHash returns the commit a branch or tag of a remote repo
+// points at, without cloning. It returns "" when the remote advertises no
+// such ref, as for a commit hash.
+func GetRemoteRefHash(repoURL, ref string) (string, error) {
+	cmd := exec.Command("git", "ls-remote", repoURL, ref, ref+"^{}")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return parseRemoteRef(string(out), ref), nil
+}
+
+// RefMoved reports whether a remote branch or tag no longer points at
+// version, a possibly short commit hash. A commit ref is not advertised by
+// the remote and never moves.
+func RefMoved(repoURL, ref, version string) (bool, error) {
+	hash, err := GetRemoteRefHash(repoURL, ref)
+	if err != nil {
+		return false, err
+	}
+	return hash != "" && (version == "" || !strings.HasPrefix(hash, version)), nil
+}
+
+// parseRemoteRef picks the commit for ref from ls-remote output: a branch
+// first, then an annotated tag's peeled commit, then a lightweight tag.
+func parseRemoteRef(output, ref string) string {
+	hashes := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) == 2 {
+			hashes[parts[1]] = parts[0]
+		}
+	}
+	for _, name := range []string{"refs/heads/" + ref, "refs/tags/" + ref + "^{}", "refs/tags/" + ref} {
+		if hash, ok := hashes[name]; ok {
+			return hash
+		}
+	}
+	return ""
+}
+
 // ForcePull fetches and resets to origin (handles force push)
 func ForcePull(repoPath string) (*UpdateInfo, error) {
 	info := &UpdateInfo{}
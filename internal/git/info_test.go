@@ -159,3 +159,44 @@ func TestIsDirtyAndGetDirtyFiles(t *testing.T) {
 		t.Error("expected at least one dirty file")
 	}
 }
+
+func TestParseRemoteRef(t *testing.T) {
+	output := "aaa\trefs/heads/main\nbbb\trefs/tags/v1\nccc\trefs/tags/v1^{}\nddd\trefs/tags/light\n"
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"main", "aaa"},
+		{"v1", "ccc"},
+		{"light", "ddd"},
+		{"abc1234", ""},
+	}
+	for _, tt := range tests {
+		if got := parseRemoteRef(output, tt.ref); got != tt.want {
+			t.Errorf("parseRemoteRef(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestPinnedRef(t *testing.T) {
+	dir := initTestRepo(t)
+	if ref := PinnedRef(dir); ref != "" {
+		t.Fatalf("new repo should not be pinned, got %q", ref)
+	}
+	if err := SetPinnedRef(dir, "v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if ref := PinnedRef(dir); ref != "v1.0.0" {
+		t.Errorf("expected v1.0.0, got %q", ref)
+	}
+	if err := SetPinnedRef(dir, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ref := PinnedRef(dir); ref != "" {
+		t.Errorf("pin should be cleared, got %q", ref)
+	}
+	if err := SetPinnedRef(dir, ""); err != nil {
+		t.Errorf("clearing an unpinned repo should succeed: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// pinConfigKey is the repo-local git config key that records the ref a
+// tracked repo was installed or updated at (repo@ref, update --to).
+const pinConfigKey = "skillshare.ref"
+
+// PinnedRef returns the ref a tracked repo is pinned to, or "" when it
+// follows its branch.
+func PinnedRef(repoPath string) string {
+	cmd := exec.Command("git", "config", "--local", "--get", pinConfigKey)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// SetPinnedRef records ref as the tracked repo's pin. An empty ref clears it.
+func SetPinnedRef(repoPath, ref string) error {
+	if ref == "" {
+		if PinnedRef(repoPath) == "" {
+			return nil
+		}
+		cmd := exec.Command("git", "config", "--local", "--unset", pinConfigKey)
+		cmd.Dir = repoPath
+		return cmd.Run()
+	}
+	cmd := exec.Command("git", "config", "--local", pinConfigKey, ref)
+	cmd.Dir = repoPath
+	return cmd.Run()
+}
+
+// DefaultBranch returns the branch origin/HEAD points at
+func DefaultBranch(repoPath string) (string, error) {
+	branch, err := originHead(repoPath)
+	if err == nil {
+		return branch, nil
+	}
+	cmd := exec.Command("git", "remote", "set-head", "origin", "--auto")
+	cmd.Dir = repoPath
+	if cmd.Run() != nil {
+		return "", err
+	}
+	return originHead(repoPath)
+}
+
+func originHead(repoPath string) (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(out)), "origin/"), nil
+}
+
+// fetchPinned fetches branches and tags from origin, letting a moved tag
+// overwrite the local one
+func fetchPinned(repoPath string) error {
+	cmd := exec.Command("git", "fetch", "--quiet", "--tags", "--force", "origin")
+	cmd.Dir = repoPath
+	return cmd.Run()
+}
+
+// pinTarget returns the revision a pinned ref resolves to after a fetch:
+// the remote branch for a branch, the commit itself for a tag or hash.
+func pinTarget(repoPath, ref string) string {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+ref)
+	cmd.Dir = repoPath
+	if cmd.Run() == nil {
+		return "origin/" + ref
+	}
+	return ref + "^{commit}"
+}
+
+// CheckoutRef fetches and checks out ref: a branch is reset to its remote
+// and followed, a tag or commit is checked out detached.
+func CheckoutRef(repoPath, ref string) (*UpdateInfo, error) {
+	info := &UpdateInfo{}
+
+	beforeHash, err := GetCurrentHash(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	info.BeforeHash = beforeHash
+
+	if err := fetchPinned(repoPath); err != nil {
+		return nil, err
+	}
+
+	target := pinTarget(repoPath, ref)
+	args := []string{"checkout", "--quiet", "--detach", target}
+	if strings.HasPrefix(target, "origin/") {
+		args = []string{"checkout", "--quiet", "-B", ref, target}
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	afterHash, err := GetCurrentHash(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	info.AfterHash = afterHash
+
+	if beforeHash == afterHash {
+		info.UpToDate = true
+		return info, nil
+	}
+
+	commits, _ := GetCommitsBetween(repoPath, beforeHash, afterHash)
+	info.Commits = commits
+
+	stats, _ := GetDiffStats(repoPath, beforeHash, afterHash)
+	info.Stats = stats
+
+	return info, nil
+}
+
+// UpdateTracked updates a tracked repo: a pinned repo checks out its pin
+// again, a repo left detached by a dropped pin returns to its default
+// branch, and any other repo pulls (ForcePull when force is set).
+func UpdateTracked(repoPath string, force bool) (*UpdateInfo, error) {
+	if ref := PinnedRef(repoPath); ref != "" {
+		return CheckoutRef(repoPath, ref)
+	}
+	if branch, err := GetCurrentBranch(repoPath); err == nil && branch == "HEAD" {
+		branch, err := DefaultBranch(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		return CheckoutRef(repoPath, branch)
+	}
+	if force {
+		return ForcePull(repoPath)
+	}
+	return Pull(repoPath)
+}
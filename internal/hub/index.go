@@ -31,6 +31,11 @@ type SkillEntry struct {
 	Skill       string   `json:"skill,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
 
+	// Frontmatter placement: the targets and platforms the skill syncs to.
+	Targets        []string `json:"targets,omitempty"`
+	ExcludeTargets []string `json:"excludeTargets,omitempty"`
+	OS             []string `json:"os,omitempty"`
+
 	// Metadata fields — only emitted with --full.
 	FlatName    string `json:"flatName,omitempty"`
 	RelPath     string `json:"relPath,omitempty"`
@@ -44,13 +49,13 @@ type SkillEntry struct {
 // BuildIndex scans the source directory and returns a hub index.
 // If full is true, metadata fields are included; otherwise only
 // name, description, source are populated.
-func BuildIndex(sourcePath string, full bool) (*Index, error) {
+func BuildIndex(sourcePath string, full bool, ignore ...string) (*Index, error) {
 	// Fail fast if source directory does not exist.
 	if _, err := os.Stat(sourcePath); err != nil {
 		return nil, fmt.Errorf("source directory: %w", err)
 	}
 
-	discovered, err := ssync.DiscoverSourceSkills(sourcePath)
+	discovered, err := ssync.DiscoverSourceSkills(sourcePath, ignore...)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +89,9 @@ func BuildIndex(sourcePath string, full bool) (*Index, error) {
 		if tags := readSkillTags(d.SourcePath); len(tags) > 0 {
 			item.Tags = tags
 		}
+		item.Targets = d.Placement.Targets
+		item.ExcludeTargets = d.Placement.ExcludeTargets
+		item.OS = d.Placement.OS
 
 		if full {
 			// Only emit flatName when different from name.
@@ -280,3 +280,23 @@ func TestWriteIndex_NilIndex(t *testing.T) {
 		t.Fatal("expected error for nil index")
 	}
 }
+
+func TestBuildIndex_Placement(t *testing.T) {
+	source := t.TempDir()
+	createSkill(t, source, "placed", "---\nname: placed\ntargets: [claude, cursor]\nexclude_targets:\n  - codex\nos: linux\n---\n# Placed")
+
+	idx, err := BuildIndex(source, false)
+	if err != nil {
+		t.Fatalf("BuildIndex: %v", err)
+	}
+	s := idx.Skills[0]
+	if len(s.Targets) != 2 || s.Targets[0] != "claude" || s.Targets[1] != "cursor" {
+		t.Errorf("targets = %v, want [claude cursor]", s.Targets)
+	}
+	if len(s.ExcludeTargets) != 1 || s.ExcludeTargets[0] != "codex" {
+		t.Errorf("excludeTargets = %v, want [codex]", s.ExcludeTargets)
+	}
+	if len(s.OS) != 1 || s.OS[0] != "linux" {
+		t.Errorf("os = %v, want [linux]", s.OS)
+	}
+}
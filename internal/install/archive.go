@@ -0,0 +1,535 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Archive limits guard against oversized downloads and decompression bombs
+const (
+	maxArchiveBytes   = 100 << 20 // Archive file or download
+	maxExtractedBytes = 500 << 20 // All extracted files together
+	maxArchiveEntries = 20000
+)
+
+// archiveExts are the recognized archive suffixes, matched case-insensitively
+var archiveExts = []string{".tar.gz", ".tgz", ".zip"}
+
+// archiveVersionSuffix trims release versions from archive names:
+// pdf-skill-1.2.0.tar.gz -> pdf-skill
+var archiveVersionSuffix = regexp.MustCompile(`[-_]v?\d+(\.\d+)*$`)
+
+var sha256Hex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// archiveHTTPClient downloads remote archives; tests swap in a TLS test client
+var archiveHTTPClient = &http.Client{Timeout: 5 * time.Minute, CheckRedirect: archiveCheckRedirect}
+
+// archiveCheckRedirect keeps archive downloads on https across redirects
+func archiveCheckRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("archive redirect to non-https URL %s refused", req.URL)
+	}
+	if len(via) >= 10 {
+		return errors.New("too many redirects")
+	}
+	return nil
+}
+
+// IsArchive returns true if this source is a .tar.gz/.tgz/.zip archive
+func (s *Source) IsArchive() bool {
+	return s.Type == SourceTypeArchive
+}
+
+// parseArchive recognizes <archive>[/subdir][#sha256=<hex>] where archive is
+// a local path, a file:// URL or an https:// URL ending in an archive suffix.
+// ok is false when input is not an archive source.
+func parseArchive(input string) (source *Source, ok bool, err error) {
+	location, fragment, hasFragment := strings.Cut(input, "#")
+	end := archiveSuffixEnd(location)
+	if end < 0 {
+		return nil, false, nil
+	}
+	subdir := strings.Trim(location[end:], "/")
+	location = location[:end]
+
+	source = &Source{Type: SourceTypeArchive, Subdir: subdir}
+	if hasFragment {
+		digest, found := strings.CutPrefix(fragment, "sha256=")
+		digest = strings.ToLower(digest)
+		if !found || !sha256Hex.MatchString(digest) {
+			return nil, true, fmt.Errorf("invalid archive digest %q: expected #sha256=<64 hex characters>", fragment)
+		}
+		source.Digest = digest
+	}
+
+	switch {
+	case strings.HasPrefix(location, "https://"):
+		source.URL = location
+	case strings.HasPrefix(location, "http://"):
+		return nil, true, fmt.Errorf("archive URLs must use https: %s", location)
+	case strings.HasPrefix(location, "file://"):
+		source.URL = filepath.Clean(strings.TrimPrefix(location, "file://"))
+	case isLocalPath(location):
+		local, err := parseLocalPath(location, &Source{})
+		if err != nil {
+			return nil, true, err
+		}
+		source.URL = local.Path
+	default:
+		return nil, false, nil
+	}
+
+	source.Raw = source.URL
+	if subdir != "" {
+		source.Raw += "/" + subdir
+		source.Name = path.Base(subdir)
+	} else {
+		source.Name = archiveBaseName(location)
+	}
+	return source, true, nil
+}
+
+// archiveSuffixEnd returns the index just past the first archive suffix that
+// ends s or is followed by "/", or -1
+func archiveSuffixEnd(s string) int {
+	lower := strings.ToLower(s)
+	best := -1
+	for _, ext := range archiveExts {
+		for from := 0; ; {
+			i := strings.Index(lower[from:], ext)
+			if i < 0 {
+				break
+			}
+			end := from + i + len(ext)
+			if end == len(s) || s[end] == '/' {
+				if best < 0 || end < best {
+					best = end
+				}
+				break
+			}
+			from = end
+		}
+	}
+	return best
+}
+
+// archiveBaseName derives a skill name from an archive file name
+func archiveBaseName(location string) string {
+	name := path.Base(filepath.ToSlash(location))
+	lower := strings.ToLower(name)
+	for _, ext := range archiveExts {
+		if strings.HasSuffix(lower, ext) {
+			name = name[:len(name)-len(ext)]
+			break
+		}
+	}
+	if trimmed := archiveVersionSuffix.ReplaceAllString(name, ""); trimmed != "" {
+		name = trimmed
+	}
+	return name
+}
+
+// DiscoverFromArchive fetches and extracts an archive and discovers the
+// skills in it (or in its subdir). An archive whose entries all sit under
+// one top-level directory, as release tarballs do, is rooted at that
+// directory.
+func DiscoverFromArchive(source *Source) (*DiscoveryResult, error) {
+	tempDir, err := os.MkdirTemp("", "skillshare-archive-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	fail := func(err error) (*DiscoveryResult, error) {
+		os.RemoveAll(tempDir)
+		return nil, err
+	}
+
+	archivePath, digest, err := fetchArchive(source, tempDir)
+	if err != nil {
+		return fail(err)
+	}
+	if source.Digest != "" && digest != source.Digest {
+		return fail(fmt.Errorf("archive digest mismatch: expected sha256:%s, got sha256:%s", source.Digest, digest))
+	}
+
+	extractDir := filepath.Join(tempDir, "extract")
+	if err := extractArchive(archivePath, extractDir); err != nil {
+		return fail(err)
+	}
+	if err := os.Rename(archiveRoot(extractDir), filepath.Join(tempDir, "repo")); err != nil {
+		return fail(fmt.Errorf("failed to prepare archive contents: %w", err))
+	}
+
+	base := filepath.Join(tempDir, "repo")
+	if source.HasSubdir() {
+		base = filepath.Join(base, filepath.FromSlash(source.Subdir))
+		if info, err := os.Stat(base); err != nil || !info.IsDir() {
+			return fail(fmt.Errorf("subdirectory '%s' does not exist in archive", source.Subdir))
+		}
+	}
+
+	skills := discoverSkills(base, true)
+	for i := range skills {
+		if skills[i].Path == "." {
+			skills[i].Name = source.Name
+			break
+		}
+	}
+
+	return &DiscoveryResult{
+		RepoPath: tempDir,
+		Skills:   skills,
+		Source:   source,
+		Digest:   "sha256:" + digest,
+	}, nil
+}
+
+// archiveRoot returns the single top-level directory of an extracted archive,
+// or dir itself when the archive has several top-level entries
+func archiveRoot(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// ArchiveDigest returns the current "sha256:<hex>" digest of an archive
+// location (a local path or https URL) without extracting it
+func ArchiveDigest(location string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "skillshare-archive-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	_, digest, err := fetchArchive(&Source{URL: location}, tempDir)
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + digest, nil
+}
+
+// fetchArchive makes the archive available as a local file and returns its
+// path with its hex sha256. Remote archives are downloaded into tempDir.
+func fetchArchive(source *Source, tempDir string) (string, string, error) {
+	if !strings.HasPrefix(source.URL, "https://") {
+		f, err := os.Open(source.URL)
+		if err != nil {
+			return "", "", fmt.Errorf("cannot open archive: %w", err)
+		}
+		defer f.Close()
+		digest, err := hashLimited(io.Discard, f)
+		return source.URL, digest, err
+	}
+
+	resp, err := archiveHTTPClient.Get(source.URL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to download archive: %s", resp.Status)
+	}
+	if resp.ContentLength > maxArchiveBytes {
+		return "", "", fmt.Errorf("archive is larger than %d MB", maxArchiveBytes>>20)
+	}
+
+	name := "archive.tar.gz"
+	if strings.HasSuffix(strings.ToLower(source.URL), ".zip") {
+		name = "archive.zip"
+	}
+	archivePath := filepath.Join(tempDir, name)
+	out, err := os.Create(archivePath)
+	if err != nil {
+		return "", "", err
+	}
+	digest, err := hashLimited(out, resp.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return archivePath, digest, nil
+}
+
+// hashLimited copies r to w, enforcing maxArchiveBytes, and returns the hex
+// sha256 of what was read
+func hashLimited(w io.Writer, r io.Reader) (string, error) {
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), io.LimitReader(r, maxArchiveBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read archive: %w", err)
+	}
+	if n > maxArchiveBytes {
+		return "", fmt.Errorf("archive is larger than %d MB", maxArchiveBytes>>20)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// extractArchive unpacks a .tar.gz/.tgz or .zip file into dest
+func extractArchive(archivePath, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	x := &extractor{dest: dest}
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		return x.zip(archivePath)
+	}
+	return x.tarGz(archivePath)
+}
+
+// extractor writes archive entries under dest, refusing anything that would
+// land outside it and enforcing the entry and size limits
+type extractor struct {
+	dest    string
+	entries int
+	written int64
+}
+
+func (x *extractor) tarGz(archivePath string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("invalid gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid tar archive: %w", err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = x.dir(hdr.Name)
+		case tar.TypeReg:
+			err = x.file(hdr.Name, hdr.FileInfo().Mode(), tr)
+		case tar.TypeSymlink:
+			err = x.symlink(hdr.Name, hdr.Linkname)
+		case tar.TypeXGlobalHeader:
+			continue
+		default:
+			err = fmt.Errorf("archive entry %q has an unsupported type", hdr.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (x *extractor) zip(archivePath string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("invalid zip archive: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		if err := x.zipEntry(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *extractor) zipEntry(f *zip.File) error {
+	mode := f.Mode()
+	if mode.IsDir() {
+		return x.dir(f.Name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("invalid zip entry %q: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	if mode&os.ModeSymlink != 0 {
+		target, err := io.ReadAll(io.LimitReader(rc, 4096))
+		if err != nil {
+			return err
+		}
+		return x.symlink(f.Name, string(target))
+	}
+	if !mode.IsRegular() {
+		return fmt.Errorf("archive entry %q has an unsupported type", f.Name)
+	}
+	return x.file(f.Name, mode, rc)
+}
+
+// target validates an entry name and returns where it is extracted to
+func (x *extractor) target(name string) (string, error) {
+	x.entries++
+	if x.entries > maxArchiveEntries {
+		return "", fmt.Errorf("archive has more than %d entries", maxArchiveEntries)
+	}
+	clean := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || filepath.VolumeName(clean) != "" {
+		return "", fmt.Errorf("archive entry %q escapes the extraction directory", name)
+	}
+	return filepath.Join(x.dest, filepath.FromSlash(clean)), nil
+}
+
+func (x *extractor) dir(name string) error {
+	dst, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(dst, 0755)
+}
+
+func (x *extractor) file(name string, mode os.FileMode, r io.Reader) error {
+	dst, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	if err := x.insideDest(filepath.Dir(dst)); err != nil {
+		return fmt.Errorf("archive entry %q escapes the extraction directory", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0644)
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_EXCL, perm)
+	if err != nil {
+		return fmt.Errorf("failed to extract %q: %w", name, err)
+	}
+	defer out.Close()
+
+	remaining := maxExtractedBytes - x.written
+	n, err := io.Copy(out, io.LimitReader(r, remaining+1))
+	x.written += n
+	if err != nil {
+		return fmt.Errorf("failed to extract %q: %w", name, err)
+	}
+	if x.written > maxExtractedBytes {
+		return fmt.Errorf("archive expands to more than %d MB", maxExtractedBytes>>20)
+	}
+	return nil
+}
+
+func (x *extractor) symlink(name, linkname string) error {
+	dst, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	link := strings.ReplaceAll(linkname, "\\", "/")
+	if path.IsAbs(link) || filepath.VolumeName(link) != "" {
+		return fmt.Errorf("archive symlink %q points outside the archive", name)
+	}
+	if err := x.insideDest(filepath.Dir(dst)); err != nil {
+		return fmt.Errorf("archive entry %q escapes the extraction directory", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if !x.linkInside(filepath.Dir(dst), link) {
+		return fmt.Errorf("archive symlink %q points outside the archive", name)
+	}
+	return os.Symlink(filepath.FromSlash(link), dst)
+}
+
+// linkInside reports whether a symlink in dir pointing at link stays inside
+// dest. ".." is only allowed to lead the target and is applied to the real
+// location of dir, so no other symlink in the archive can redirect it.
+func (x *extractor) linkInside(dir, link string) bool {
+	root, err := filepath.EvalSymlinks(x.dest)
+	if err != nil {
+		return false
+	}
+	cur, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false
+	}
+	descended := false
+	for _, part := range strings.Split(link, "/") {
+		switch part {
+		case "", ".":
+		case "..":
+			if descended {
+				return false
+			}
+			cur = filepath.Dir(cur)
+		default:
+			descended = true
+			cur = filepath.Join(cur, part)
+		}
+	}
+	rel, err := filepath.Rel(root, cur)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// insideDest rejects directories that resolve outside dest through a symlink
+// extracted earlier
+func (x *extractor) insideDest(dir string) error {
+	root, err := filepath.EvalSymlinks(x.dest)
+	if err != nil {
+		return err
+	}
+	// Walk up to the deepest directory that already exists
+	existing := dir
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return nil
+		}
+		existing = parent
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("outside extraction directory")
+	}
+	return nil
+}
+
+// CheckArchive compares an installed archive skill with the archive now at
+// its URL. A #sha256= source is pinned and returned as "pinned" with its pin
+// label; otherwise the status is "up_to_date" or "update_available".
+func CheckArchive(meta *SkillMeta) (status, pin string, err error) {
+	if source, err := ParseSource(meta.Source); err == nil && source.Digest != "" {
+		return "pinned", "sha256:" + source.Digest[:12], nil
+	}
+	digest, err := ArchiveDigest(meta.URL)
+	if err != nil {
+		return "", "", err
+	}
+	if digest == meta.Digest {
+		return "up_to_date", "", nil
+	}
+	return "update_available", "", nil
+}
@@ -0,0 +1,353 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tarEntry is one entry written by writeTarGz; a non-empty link makes a symlink
+type tarEntry struct {
+	name, body, link string
+}
+
+func writeTarGz(t *testing.T, path string, entries []tarEntry) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		if e.link != "" {
+			hdr = &tar.Header{Name: e.name, Mode: 0777, Linkname: e.link, Typeflag: tar.TypeSymlink}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if e.link == "" {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fileSHA256(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParseSource_Archive(t *testing.T) {
+	digest := strings.Repeat("ab", 32)
+	tests := []struct {
+		name       string
+		input      string
+		wantURL    string
+		wantSubdir string
+		wantName   string
+		wantDigest string
+	}{
+		{
+			name:     "https tarball",
+			input:    "https://example.com/releases/pdf-skill-1.2.0.tar.gz",
+			wantURL:  "https://example.com/releases/pdf-skill-1.2.0.tar.gz",
+			wantName: "pdf-skill",
+		},
+		{
+			name:     "local zip",
+			input:    "/tmp/downloads/my-skill.zip",
+			wantURL:  "/tmp/downloads/my-skill.zip",
+			wantName: "my-skill",
+		},
+		{
+			name:       "subdir inside archive",
+			input:      "https://example.com/pack.tgz/skills/review",
+			wantURL:    "https://example.com/pack.tgz",
+			wantSubdir: "skills/review",
+			wantName:   "review",
+		},
+		{
+			name:       "pinned digest",
+			input:      "https://example.com/pack.zip#sha256=" + strings.ToUpper(digest),
+			wantURL:    "https://example.com/pack.zip",
+			wantName:   "pack",
+			wantDigest: digest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := ParseSource(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSource() error = %v", err)
+			}
+			if source.Type != SourceTypeArchive {
+				t.Errorf("Type = %v, want archive", source.Type)
+			}
+			if source.URL != tt.wantURL {
+				t.Errorf("URL = %v, want %v", source.URL, tt.wantURL)
+			}
+			if source.Subdir != tt.wantSubdir {
+				t.Errorf("Subdir = %v, want %v", source.Subdir, tt.wantSubdir)
+			}
+			if source.Name != tt.wantName {
+				t.Errorf("Name = %v, want %v", source.Name, tt.wantName)
+			}
+			if source.Digest != tt.wantDigest {
+				t.Errorf("Digest = %v, want %v", source.Digest, tt.wantDigest)
+			}
+		})
+	}
+}
+
+func TestParseSource_ArchiveErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"plain http", "http://example.com/skill.tar.gz", "must use https"},
+		{"short digest", "https://example.com/skill.zip#sha256=abc", "invalid archive digest"},
+		{"other fragment", "https://example.com/skill.zip#md5=abc", "invalid archive digest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSource(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseSource(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSource_Archive_SpecAndWithRef(t *testing.T) {
+	digest := strings.Repeat("0", 64)
+	source, err := ParseSource("https://example.com/pack.tgz/skills/review#sha256=" + digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := source.Spec(), "https://example.com/pack.tgz/skills/review#sha256="+digest; got != want {
+		t.Errorf("Spec() = %q, want %q", got, want)
+	}
+	if _, err := source.WithRef("v2"); err == nil {
+		t.Error("WithRef(v2) should fail for archives")
+	}
+	unpinned, err := source.WithRef("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpinned.Digest != "" || source.Digest == "" {
+		t.Errorf("WithRef(\"\") should drop the digest on a copy")
+	}
+}
+
+func TestDiscoverFromArchive_SingleTopDirAndDigest(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "tools-1.0.0.tar.gz")
+	writeTarGz(t, archive, []tarEntry{
+		{name: "tools-1.0.0/SKILL.md", body: "---\nname: tools\n---\n"},
+		{name: "tools-1.0.0/skills/lint/SKILL.md", body: "---\nname: lint\n---\n"},
+	})
+
+	source, err := ParseSource(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := DiscoverFromArchive(source)
+	if err != nil {
+		t.Fatalf("DiscoverFromArchive() error = %v", err)
+	}
+	defer CleanupDiscovery(result)
+
+	if got, want := result.Digest, "sha256:"+fileSHA256(t, archive); got != want {
+		t.Errorf("Digest = %q, want %q", got, want)
+	}
+	names := map[string]string{}
+	for _, s := range result.Skills {
+		names[s.Path] = s.Name
+	}
+	if names["."] != "tools" || names["skills/lint"] != "lint" {
+		t.Errorf("skills = %v, want root 'tools' and skills/lint", names)
+	}
+}
+
+func TestDiscoverFromArchive_DigestMismatch(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "skill.zip")
+	writeZip(t, archive, map[string]string{"SKILL.md": "---\nname: skill\n---\n"})
+
+	source, err := ParseSource(archive + "#sha256=" + strings.Repeat("0", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscoverFromArchive(source); err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Fatalf("DiscoverFromArchive() error = %v, want digest mismatch", err)
+	}
+}
+
+func TestDiscoverFromArchive_HTTPS(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "remote.zip")
+	writeZip(t, archive, map[string]string{"remote/SKILL.md": "---\nname: remote\n---\n"})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/remote.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, archive)
+	}))
+	defer server.Close()
+
+	prev := archiveHTTPClient
+	archiveHTTPClient = server.Client()
+	defer func() { archiveHTTPClient = prev }()
+
+	source, err := ParseSource(server.URL + "/remote.zip#sha256=" + fileSHA256(t, archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := DiscoverFromArchive(source)
+	if err != nil {
+		t.Fatalf("DiscoverFromArchive() error = %v", err)
+	}
+	defer CleanupDiscovery(result)
+	if len(result.Skills) != 1 || result.Skills[0].Name != "remote" {
+		t.Errorf("skills = %+v, want the root skill 'remote'", result.Skills)
+	}
+
+	if _, err := ArchiveDigest(server.URL + "/missing.zip"); err == nil {
+		t.Error("ArchiveDigest() should fail on 404")
+	}
+}
+
+func TestInstall_ArchiveRedirectToHTTPRefused(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "remote.zip")
+	writeZip(t, archive, map[string]string{"remote/SKILL.md": "---\nname: remote\n---\n"})
+
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, archive)
+	}))
+	defer plain.Close()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/remote.zip", http.StatusFound)
+	}))
+	defer server.Close()
+
+	prev := archiveHTTPClient
+	archiveHTTPClient = server.Client()
+	archiveHTTPClient.CheckRedirect = archiveCheckRedirect
+	defer func() { archiveHTTPClient = prev }()
+
+	source, err := ParseSource(server.URL + "/remote.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(t.TempDir(), "remote")
+	_, err = Install(source, dest, InstallOptions{SkipAudit: true})
+	if err == nil || !strings.Contains(err.Error(), "non-https") {
+		t.Fatalf("Install() error = %v, want the http redirect refused", err)
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Error("nothing should be installed after a refused redirect")
+	}
+}
+
+func TestExtractArchive_RejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{"parent traversal", []tarEntry{{name: "../evil.txt", body: "x"}}},
+		{"nested traversal", []tarEntry{{name: "skill/../../evil.txt", body: "x"}}},
+		{"absolute path", []tarEntry{{name: "/tmp/evil.txt", body: "x"}}},
+		{"symlink outside", []tarEntry{{name: "skill/link", link: "../../etc"}}},
+		{"absolute symlink", []tarEntry{{name: "skill/link", link: "/etc"}}},
+		{"write through symlink", []tarEntry{
+			{name: "skill/SKILL.md", body: "x"},
+			{name: "skill/up", link: "."},
+			{name: "skill/out", link: "up/../.."},
+		}},
+		{"link placed through symlink", []tarEntry{
+			{name: "skill/SKILL.md", body: "x"},
+			{name: "skill/up", link: "."},
+			{name: "skill/up/out", link: "../.."},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archive := filepath.Join(dir, "bad.tar.gz")
+			writeTarGz(t, archive, tt.entries)
+			dest := filepath.Join(dir, "out")
+
+			if err := extractArchive(archive, dest); err == nil {
+				t.Fatal("extractArchive() should fail")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "evil.txt")); err == nil {
+				t.Error("file was written outside the extraction directory")
+			}
+		})
+	}
+}
+
+func TestExtractArchive_KeepsInternalSymlink(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "ok.tar.gz")
+	writeTarGz(t, archive, []tarEntry{
+		{name: "skill/docs/guide.md", body: "guide"},
+		{name: "skill/GUIDE.md", link: "docs/guide.md"},
+	})
+	dest := filepath.Join(dir, "out")
+
+	if err := extractArchive(archive, dest); err != nil {
+		t.Fatalf("extractArchive() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "skill", "GUIDE.md"))
+	if err != nil || string(data) != "guide" {
+		t.Errorf("GUIDE.md = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,199 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Forge is a git hosting service whose web URLs can be parsed into a clone
+// URL, ref and subdirectory
+type Forge string
+
+const (
+	ForgeGitHub    Forge = "github"    // GitHub Enterprise hosts; github.com has its own parser
+	ForgeGitLab    Forge = "gitlab"    // Nested groups, /-/tree/<ref>/path
+	ForgeBitbucket Forge = "bitbucket" // /src/<ref>/path
+	ForgeGitea     Forge = "gitea"     // Gitea, Forgejo and Codeberg: /src/branch/<ref>/path
+)
+
+// ForgeTypes lists the values accepted by the forges: config
+var ForgeTypes = []string{string(ForgeGitHub), string(ForgeGitLab), string(ForgeBitbucket), string(ForgeGitea)}
+
+// knownForges are public instances recognized without configuration
+var knownForges = map[string]Forge{
+	"gitlab.com":    ForgeGitLab,
+	"bitbucket.org": ForgeBitbucket,
+	"gitea.com":     ForgeGitea,
+	"codeberg.org":  ForgeGitea,
+}
+
+var (
+	forgeHostsMu sync.RWMutex
+	forgeHosts   = map[string]Forge{} // Self-hosted instances from the forges: config
+)
+
+// SetForgeHosts declares self-hosted instances (host -> forge type),
+// replacing any declared before. Callers pass the forges: of the config
+// they loaded.
+func SetForgeHosts(hosts map[string]string) error {
+	declared := make(map[string]Forge, len(hosts))
+	for host, kind := range hosts {
+		forge := Forge(strings.ToLower(strings.TrimSpace(kind)))
+		if !slices.Contains(ForgeTypes, string(forge)) {
+			return fmt.Errorf("forges.%s: unsupported type %q (use %s)", host, kind, strings.Join(ForgeTypes, ", "))
+		}
+		declared[strings.ToLower(strings.TrimSpace(host))] = forge
+	}
+
+	forgeHostsMu.Lock()
+	forgeHosts = declared
+	forgeHostsMu.Unlock()
+	return nil
+}
+
+// forgeFor returns the forge serving host: a declared instance, a known
+// public one, or a guess from the host name. Empty means unknown.
+func forgeFor(host string) Forge {
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+
+	forgeHostsMu.RLock()
+	forge, ok := forgeHosts[host]
+	if !ok {
+		if name, _, hasPort := strings.Cut(host, ":"); hasPort {
+			forge, ok = forgeHosts[name]
+		}
+	}
+	forgeHostsMu.RUnlock()
+	if ok {
+		return forge
+	}
+	if forge, ok := knownForges[host]; ok {
+		return forge
+	}
+
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return ForgeGitLab
+	case strings.Contains(host, "bitbucket"):
+		return ForgeBitbucket
+	case strings.Contains(host, "gitea"), strings.Contains(host, "forgejo"):
+		return ForgeGitea
+	case strings.HasPrefix(host, "github."), strings.HasSuffix(host, ".github.com"):
+		return ForgeGitHub
+	}
+	return ""
+}
+
+// splitForgePath splits the path of an HTTPS source (everything after the
+// host) into the repository path, subdirectory and a ref taken from a web
+// URL. "//" or ".git/" always ends the repository path. Otherwise GitLab
+// repositories run up to /-/ so nested groups work, and every other forge
+// uses owner/repo.
+func splitForgePath(forge Forge, rest string) (repo, subdir, ref string, err error) {
+	rest, _, _ = strings.Cut(rest, "?")
+	rest = strings.Trim(rest, "/")
+
+	if before, after, ok := strings.Cut(rest, "//"); ok {
+		return strings.TrimSuffix(before, ".git"), strings.Trim(after, "/"), "", nil
+	}
+	if before, after, ok := strings.Cut(rest, ".git/"); ok {
+		return before, after, "", nil
+	}
+	rest = strings.TrimSuffix(rest, ".git")
+
+	// GitLab marks the end of the project path with /-/, on any host
+	if before, after, ok := strings.Cut(rest, "/-/"); ok && (forge == ForgeGitLab || forge == "") {
+		kind, pathRef, _ := strings.Cut(after, "/")
+		if kind != "tree" && kind != "blob" {
+			return "", "", "", fmt.Errorf("unsupported GitLab URL: /-/%s is not a tree or blob link", after)
+		}
+		ref, subdir, _ = strings.Cut(pathRef, "/")
+		return before, subdir, ref, nil
+	}
+	if forge == ForgeGitLab {
+		return path.Clean(rest), "", "", nil
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 2 {
+		return "", "", "", fmt.Errorf("expected owner/repo in %q", rest)
+	}
+	repo = parts[0] + "/" + parts[1]
+	if len(parts) == 3 {
+		subdir = parts[2]
+	}
+
+	switch forge {
+	case ForgeGitHub:
+		subdir, ref = stripGitHubBranchPrefix(subdir)
+	case ForgeBitbucket:
+		// src/<ref>/path
+		if tail, ok := strings.CutPrefix(subdir, "src/"); ok {
+			ref, subdir, _ = strings.Cut(tail, "/")
+		}
+	case ForgeGitea:
+		// src/branch/<ref>/path, src/tag/<ref>/path, src/commit/<sha>/path
+		if tail, ok := strings.CutPrefix(subdir, "src/"); ok {
+			kind, pathRef, _ := strings.Cut(tail, "/")
+			if kind == "branch" || kind == "tag" || kind == "commit" {
+				ref, subdir, _ = strings.Cut(pathRef, "/")
+			} else {
+				// Older Gitea: src/<ref>/path
+				ref, subdir, _ = strings.Cut(tail, "/")
+			}
+		}
+	}
+	return repo, subdir, ref, nil
+}
+
+// resolveNestedGroup settles a GitLab path deeper than group/project that
+// has no "//": gitlab.com/team/repo/skills/pdf names a project in nested
+// groups, or a project and its subdir as it did before nested groups were
+// read. The nested project is kept when it exists; otherwise, when the
+// shorter project does, the source becomes that project with the rest of
+// the path as its subdir, spelled with "//" so it is recorded unambiguously.
+// The fallback is only taken when the server says the nested project does
+// not exist; any other probe failure (offline, auth) is returned.
+func (s *Source) resolveNestedGroup() error {
+	if !s.nestedGuess {
+		return nil
+	}
+	err := probeRemote(s.CloneURL)
+	if !errors.Is(err, errRemoteNotFound) {
+		if err != nil {
+			return fmt.Errorf("failed to reach %s: %w", s.CloneURL, err)
+		}
+		s.nestedGuess = false
+		return nil
+	}
+	s.nestedGuess = false
+
+	scheme, afterScheme, _ := strings.Cut(strings.TrimSuffix(s.CloneURL, ".git"), "://")
+	parts := strings.SplitN(afterScheme, "/", 4) // host, group, project, subdir
+	if len(parts) < 4 {
+		return nil
+	}
+	legacy, err := ParseSource(scheme + "://" + strings.Join(parts[:3], "/") + "//" + parts[3])
+	if err != nil {
+		return nil
+	}
+	if err := probeRemote(legacy.CloneURL); err != nil {
+		if errors.Is(err, errRemoteNotFound) {
+			// Neither exists: the nested reading stands and cloning reports it
+			return nil
+		}
+		return fmt.Errorf("failed to reach %s: %w", legacy.CloneURL, err)
+	}
+	s.Raw = legacy.Raw
+	s.CloneURL = legacy.CloneURL
+	s.Subdir = legacy.Subdir
+	s.Name = legacy.Name
+	return nil
+}
@@ -3,6 +3,7 @@ package install
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"skillshare/internal/audit"
+	"skillshare/internal/git"
 )
 
 // InstallOptions configures the install behavior
@@ -28,6 +30,7 @@ type InstallOptions struct {
 	SkipAudit        bool     // Skip security audit entirely
 	AuditThreshold   string   // Block threshold: CRITICAL/HIGH/MEDIUM/LOW/INFO
 	AuditProjectRoot string   // Project root for project-mode audit rule resolution
+	SourceDir        string   // Source directory requires: entries resolve against (empty records no edges)
 }
 
 // ShouldInstallAll returns true if all discovered skills should be installed without prompting.
@@ -60,13 +63,14 @@ type DiscoveryResult struct {
 	RepoPath string      // Temp directory where repo was cloned
 	Skills   []SkillInfo // Discovered skills
 	Source   *Source     // Original source
+	Digest   string      // sha256 of the fetched archive (archive sources)
 }
 
 // Install executes the installation from source to destination
 func Install(source *Source, destPath string, opts InstallOptions) (*InstallResult, error) {
 	result := &InstallResult{
 		SkillName: source.Name,
-		Source:    source.Raw,
+		Source:    source.Spec(),
 	}
 
 	// Check if destination exists
@@ -78,7 +82,7 @@ func Install(source *Source, destPath string, opts InstallOptions) (*InstallResu
 			return handleUpdate(source, destPath, result, opts)
 		}
 		if !opts.Force {
-			return nil, fmt.Errorf("skill '%s' already exists. To overwrite:\n       skillshare install %s --force", source.Name, source.Raw)
+			return nil, fmt.Errorf("skill '%s' already exists. To overwrite:\n       skillshare install %s --force", source.Name, source.Spec())
 		}
 		// Force mode: remove existing
 		if !opts.DryRun {
@@ -98,6 +102,8 @@ func Install(source *Source, destPath string, opts InstallOptions) (*InstallResu
 		return installFromLocal(source, destPath, result, opts)
 	case SourceTypeGitHub, SourceTypeGitHTTPS, SourceTypeGitSSH:
 		return installFromGit(source, destPath, result, opts)
+	case SourceTypeArchive:
+		return installFromArchive(source, destPath, result, opts)
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", source.Type)
 	}
@@ -133,6 +139,7 @@ func installFromLocal(source *Source, destPath string, result *InstallResult, op
 
 	// Write metadata
 	meta := NewMetaFromSource(source)
+	recordRequires(meta, destPath, opts)
 	if err := WriteMeta(destPath, meta); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
 	}
@@ -150,6 +157,10 @@ func installFromGit(source *Source, destPath string, result *InstallResult, opts
 		return nil, fmt.Errorf("git is not installed or not in PATH")
 	}
 
+	if err := source.resolveNestedGroup(); err != nil {
+		return nil, err
+	}
+
 	// If subdir is specified, install directly
 	if source.HasSubdir() {
 		return installFromGitSubdir(source, destPath, result, opts)
@@ -163,16 +174,14 @@ func installFromGit(source *Source, destPath string, result *InstallResult, opts
 	}
 
 	// Clone the repository
-	if err := cloneRepo(source.CloneURL, destPath, true); err != nil {
+	if err := cloneRepoAt(source.CloneURL, destPath, source.checkoutRef()); err != nil {
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
 
 	// Write metadata
 	meta := NewMetaFromSource(source)
-	// Try to get the commit hash
-	if hash, err := getGitCommit(destPath); err == nil {
-		meta.Version = hash
-	}
+	recordCommit(meta, destPath)
+	recordRequires(meta, destPath, opts)
 	if err := WriteMeta(destPath, meta); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
 	}
@@ -184,6 +193,22 @@ func installFromGit(source *Source, destPath string, result *InstallResult, opts
 	return result, nil
 }
 
+// Discover fetches a git or archive source and discovers the skills in it,
+// limited to the source's subdirectory when it has one
+func Discover(source *Source) (*DiscoveryResult, error) {
+	if err := source.resolveNestedGroup(); err != nil {
+		return nil, err
+	}
+	switch {
+	case source.IsArchive():
+		return DiscoverFromArchive(source)
+	case source.HasSubdir():
+		return DiscoverFromGitSubdir(source)
+	default:
+		return DiscoverFromGit(source)
+	}
+}
+
 // DiscoverFromGit clones a repo and discovers available skills
 func DiscoverFromGit(source *Source) (*DiscoveryResult, error) {
 	if !isGitInstalled() {
@@ -197,7 +222,7 @@ func DiscoverFromGit(source *Source) (*DiscoveryResult, error) {
 	}
 
 	repoPath := filepath.Join(tempDir, "repo")
-	if err := cloneRepo(source.CloneURL, repoPath, true); err != nil {
+	if err := cloneRepoAt(source.CloneURL, repoPath, source.checkoutRef()); err != nil {
 		os.RemoveAll(tempDir)
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
@@ -280,7 +305,7 @@ func DiscoverFromGitSubdir(source *Source) (*DiscoveryResult, error) {
 	}
 
 	repoPath := filepath.Join(tempDir, "repo")
-	if err := cloneRepo(source.CloneURL, repoPath, true); err != nil {
+	if err := cloneRepoAt(source.CloneURL, repoPath, source.checkoutRef()); err != nil {
 		os.RemoveAll(tempDir)
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
@@ -339,15 +364,27 @@ func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath
 		fullSubdir = skill.Path
 	}
 
+	source := &Source{
+		Type:     discovery.Source.Type,
+		Raw:      fullSource,
+		CloneURL: discovery.Source.CloneURL,
+		Subdir:   fullSubdir,
+		Name:     skill.Name,
+		Ref:      discovery.Source.Ref,
+		Commit:   discovery.Source.Commit,
+		URL:      discovery.Source.URL,
+		Digest:   discovery.Source.Digest,
+	}
+
 	result := &InstallResult{
 		SkillName: skill.Name,
-		Source:    fullSource,
+		Source:    source.Spec(),
 	}
 
 	// Check if destination exists
 	if _, err := os.Stat(destPath); err == nil {
 		if !opts.Force {
-			return nil, fmt.Errorf("already exists. To overwrite:\n       skillshare install %s --force", fullSource)
+			return nil, fmt.Errorf("already exists. To overwrite:\n       skillshare install %s --force", source.Spec())
 		}
 		if !opts.DryRun {
 			if err := os.RemoveAll(destPath); err != nil {
@@ -363,21 +400,7 @@ func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath
 		return result, nil
 	}
 
-	// Determine source path in temp repo
-	var srcPath string
-	if discovery.Source.HasSubdir() {
-		// Subdir discovery: paths are relative to the subdir
-		if skill.Path == "." {
-			srcPath = filepath.Join(discovery.RepoPath, "repo", discovery.Source.Subdir)
-		} else {
-			srcPath = filepath.Join(discovery.RepoPath, "repo", discovery.Source.Subdir, skill.Path)
-		}
-	} else {
-		// Whole-repo discovery: paths are relative to repo root
-		srcPath = filepath.Join(discovery.RepoPath, "repo", skill.Path)
-	}
-
-	if err := copyDir(srcPath, destPath); err != nil {
+	if err := copyDir(DiscoveredSkillPath(discovery, skill), destPath); err != nil {
 		return nil, fmt.Errorf("failed to copy skill: %w", err)
 	}
 
@@ -387,17 +410,12 @@ func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath
 	}
 
 	// Write metadata
-	source := &Source{
-		Type:     discovery.Source.Type,
-		Raw:      fullSource,
-		CloneURL: discovery.Source.CloneURL,
-		Subdir:   fullSubdir,
-		Name:     skill.Name,
-	}
 	meta := NewMetaFromSource(source)
-	if hash, err := getGitCommit(filepath.Join(discovery.RepoPath, "repo")); err == nil {
-		meta.Version = hash
+	if source.IsGit() {
+		recordCommit(meta, filepath.Join(discovery.RepoPath, "repo"))
 	}
+	meta.Digest = discovery.Digest
+	recordRequires(meta, destPath, opts)
 	if err := WriteMeta(destPath, meta); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
 	}
@@ -406,6 +424,46 @@ func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath
 	return result, nil
 }
 
+// DiscoveredSkillPath returns where a discovered skill sits in the
+// discovery's temp checkout
+func DiscoveredSkillPath(discovery *DiscoveryResult, skill SkillInfo) string {
+	if discovery.Source.HasSubdir() {
+		// Subdir discovery: paths are relative to the subdir
+		return filepath.Join(discovery.RepoPath, "repo", discovery.Source.Subdir, skill.Path)
+	}
+	// Whole-repo discovery: paths are relative to repo root
+	return filepath.Join(discovery.RepoPath, "repo", skill.Path)
+}
+
+// installFromArchive installs the skill at the root of an archive (or of its
+// subdir); archives holding several skills go through Discover instead
+func installFromArchive(source *Source, destPath string, result *InstallResult, opts InstallOptions) (*InstallResult, error) {
+	if opts.DryRun {
+		result.Action = "would download and extract"
+		return result, nil
+	}
+
+	discovery, err := DiscoverFromArchive(source)
+	if err != nil {
+		return nil, err
+	}
+	defer CleanupDiscovery(discovery)
+
+	for _, skill := range discovery.Skills {
+		if skill.Path != "." {
+			continue
+		}
+		installed, err := InstallFromDiscovery(discovery, skill, destPath, opts)
+		if err != nil {
+			return nil, err
+		}
+		installed.SkillName = result.SkillName
+		installed.Action = "extracted"
+		return installed, nil
+	}
+	return nil, fmt.Errorf("no SKILL.md at the root of %s (%d skill(s) inside; add the skill's path after the archive name)", source.Spec(), len(discovery.Skills))
+}
+
 func installFromGitSubdir(source *Source, destPath string, result *InstallResult, opts InstallOptions) (*InstallResult, error) {
 	if opts.DryRun {
 		result.Action = "would clone and extract"
@@ -420,7 +478,7 @@ func installFromGitSubdir(source *Source, destPath string, result *InstallResult
 	defer os.RemoveAll(tempDir)
 
 	tempRepoPath := filepath.Join(tempDir, "repo")
-	if err := cloneRepo(source.CloneURL, tempRepoPath, true); err != nil {
+	if err := cloneRepoAt(source.CloneURL, tempRepoPath, source.checkoutRef()); err != nil {
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
 
@@ -449,10 +507,8 @@ func installFromGitSubdir(source *Source, destPath string, result *InstallResult
 
 	// Write metadata
 	meta := NewMetaFromSource(source)
-	// Try to get the commit hash from temp repo
-	if hash, err := getGitCommit(tempRepoPath); err == nil {
-		meta.Version = hash
-	}
+	recordCommit(meta, tempRepoPath)
+	recordRequires(meta, destPath, opts)
 	if err := WriteMeta(destPath, meta); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
 	}
@@ -467,8 +523,9 @@ func installFromGitSubdir(source *Source, destPath string, result *InstallResult
 func handleUpdate(source *Source, destPath string, result *InstallResult, opts InstallOptions) (*InstallResult, error) {
 	result.SkillPath = destPath
 
-	// For git repos without subdir, try git pull
-	if source.IsGit() && !source.HasSubdir() && isGitRepo(destPath) {
+	// For git repos without subdir that follow the default branch, try git
+	// pull; a pin (old or new) needs a fresh checkout instead
+	if source.IsGit() && !source.HasSubdir() && isGitRepo(destPath) && source.Ref == "" && !isPinned(destPath) {
 		if opts.DryRun {
 			result.Action = "would update (git pull)"
 			return result, nil
@@ -481,9 +538,8 @@ func handleUpdate(source *Source, destPath string, result *InstallResult, opts I
 		// Update metadata timestamp
 		meta, _ := ReadMeta(destPath)
 		if meta != nil {
-			if hash, err := getGitCommit(destPath); err == nil {
-				meta.Version = hash
-			}
+			recordCommit(meta, destPath)
+			recordRequires(meta, destPath, opts)
 			WriteMeta(destPath, meta)
 		}
 
@@ -536,6 +592,12 @@ func handleUpdate(source *Source, destPath string, result *InstallResult, opts I
 	return result, nil
 }
 
+// isPinned reports whether the installed skill's metadata records a ref.
+func isPinned(skillPath string) bool {
+	meta, _ := ReadMeta(skillPath)
+	return meta != nil && meta.Ref != ""
+}
+
 // checkSkillFile adds a warning if SKILL.md is not found
 func checkSkillFile(skillPath string, result *InstallResult) {
 	skillFile := filepath.Join(skillPath, "SKILL.md")
@@ -665,6 +727,31 @@ func runGitCommand(args []string, dir string) error {
 	return nil
 }
 
+// errRemoteNotFound means the server answered that a repository does not
+// exist, as opposed to not answering or refusing access
+var errRemoteNotFound = errors.New("repository not found")
+
+// probeRemote checks that url answers as a git repository, returning
+// errRemoteNotFound when it definitely does not. Tests replace it so they
+// need no network.
+var probeRemote = func(url string) error {
+	err := runGitCommand([]string{"ls-remote", "--quiet", url, "HEAD"}, "")
+	if err != nil && remoteNotFound(err.Error()) {
+		return errRemoteNotFound
+	}
+	return err
+}
+
+// remoteNotFound reports whether git's stderr says the repository does not
+// exist: an HTTP 404, a forge's "not found" reply, or a missing local path
+func remoteNotFound(stderr string) bool {
+	s := strings.ToLower(stderr)
+	return strings.Contains(s, "repository not found") ||
+		(strings.Contains(s, "fatal: repository '") && strings.HasSuffix(strings.TrimSpace(s), "not found")) ||
+		strings.Contains(s, "project you were looking for could not be found") ||
+		strings.Contains(s, "does not appear to be a git repository")
+}
+
 // wrapGitError inspects stderr output to produce actionable error messages.
 func wrapGitError(stderr string, err error) error {
 	s := strings.TrimSpace(stderr)
@@ -689,6 +776,28 @@ func cloneRepo(url, destPath string, shallow bool) error {
 	return runGitCommand(args, "")
 }
 
+// cloneRepoAt clones url checked out at ref. Branches and tags get a
+// shallow clone; a commit needs the full history to check it out.
+func cloneRepoAt(url, destPath, ref string) error {
+	if ref == "" {
+		return cloneRepo(url, destPath, true)
+	}
+	args := []string{"clone", "--quiet", "--depth", "1", "--branch", ref, url, destPath}
+	if err := runGitCommand(args, ""); err == nil {
+		return nil
+	}
+
+	os.RemoveAll(destPath)
+	if err := cloneRepoFull(url, destPath, ""); err != nil {
+		return err
+	}
+	if err := runGitCommand([]string{"checkout", "--quiet", "--detach", ref}, destPath); err != nil {
+		os.RemoveAll(destPath)
+		return fmt.Errorf("ref '%s' not found: %w", ref, err)
+	}
+	return nil
+}
+
 // gitPull performs a git pull (quiet mode)
 func gitPull(repoPath string) error {
 	return runGitCommand([]string{"pull", "--quiet"}, repoPath)
@@ -696,13 +805,34 @@ func gitPull(repoPath string) error {
 
 // getGitCommit returns the current HEAD commit hash
 func getGitCommit(repoPath string) (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
+	return gitOutput(repoPath, "rev-parse", "--short", "HEAD")
+}
+
+// getGitCommitFull returns the full HEAD commit hash
+func getGitCommitFull(repoPath string) (string, error) {
+	return gitOutput(repoPath, "rev-parse", "HEAD")
+}
+
+// gitOutput runs a git command in repoPath and returns its trimmed output
+func gitOutput(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
 	cmd.Dir = repoPath
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
 	}
-	return string(output[:len(output)-1]), nil // Remove trailing newline
+	return strings.TrimSpace(string(output)), nil
+}
+
+// recordCommit stores the checked-out commit in meta: the short hash as
+// Version for display and update checks, the full hash for the lockfile.
+func recordCommit(meta *SkillMeta, repoPath string) {
+	if hash, err := getGitCommit(repoPath); err == nil {
+		meta.Version = hash
+	}
+	if full, err := getGitCommitFull(repoPath); err == nil {
+		meta.Commit = full
+	}
 }
 
 // copyDir copies a directory recursively
@@ -767,6 +897,9 @@ func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (
 	if !source.IsGit() {
 		return nil, fmt.Errorf("--track requires a git repository source")
 	}
+	if err := source.resolveNestedGroup(); err != nil {
+		return nil, err
+	}
 
 	// Determine repo name: opts.Name > TrackName (owner-repo) > source.Name
 	repoName := opts.Name
@@ -802,7 +935,7 @@ func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (
 			return updateTrackedRepo(destPath, result, opts)
 		}
 		if !opts.Force {
-			return nil, fmt.Errorf("tracked repo '%s' already exists. To overwrite:\n       skillshare install %s --track --force", trackedName, source.Raw)
+			return nil, fmt.Errorf("tracked repo '%s' already exists. To overwrite:\n       skillshare install %s --track --force", trackedName, source.Spec())
 		}
 		// Force mode - remove existing
 		if !opts.DryRun {
@@ -818,9 +951,24 @@ func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (
 	}
 
 	// Clone the repository (full clone, not shallow, to support updates)
-	if err := cloneRepoFull(source.CloneURL, destPath); err != nil {
+	if err := cloneTrackedRepo(source.CloneURL, destPath, source.Ref); err != nil {
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
+	// A locked commit is reset to on the cloned branch, so later updates
+	// can still pull
+	if source.Commit != "" {
+		if err := runGitCommand([]string{"reset", "--quiet", "--hard", source.Commit}, destPath); err != nil {
+			os.RemoveAll(destPath)
+			return nil, fmt.Errorf("commit '%s' not found: %w", source.Commit, err)
+		}
+	}
+	// Record the pin so update and check follow it instead of pulling
+	if source.Ref != "" {
+		if err := git.SetPinnedRef(destPath, source.Ref); err != nil {
+			os.RemoveAll(destPath)
+			return nil, fmt.Errorf("failed to record ref '%s': %w", source.Ref, err)
+		}
+	}
 
 	// Discover skills in the cloned repo (exclude root for tracked repos)
 	skills := discoverSkills(destPath, false)
@@ -846,7 +994,7 @@ func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (
 	return result, nil
 }
 
-// updateTrackedRepo performs git pull on an existing tracked repo
+// updateTrackedRepo pulls an existing tracked repo, or checks out its pin again
 func updateTrackedRepo(repoPath string, result *TrackedRepoResult, opts InstallOptions) (*TrackedRepoResult, error) {
 	if !isGitRepo(repoPath) {
 		return nil, fmt.Errorf("'%s' is not a git repository", repoPath)
@@ -857,7 +1005,7 @@ func updateTrackedRepo(repoPath string, result *TrackedRepoResult, opts InstallO
 		return result, nil
 	}
 
-	if err := gitPull(repoPath); err != nil {
+	if _, err := git.UpdateTracked(repoPath, false); err != nil {
 		return nil, fmt.Errorf("failed to update: %w", err)
 	}
 
@@ -872,9 +1020,34 @@ func updateTrackedRepo(repoPath string, result *TrackedRepoResult, opts InstallO
 	return result, nil
 }
 
-// cloneRepoFull performs a full git clone (quiet mode for cleaner output)
-func cloneRepoFull(url, destPath string) error {
-	return runGitCommand([]string{"clone", "--quiet", url, destPath}, "")
+// cloneRepoFull performs a full git clone (quiet mode for cleaner output),
+// checking out branch when one is given
+func cloneRepoFull(url, destPath, branch string) error {
+	args := []string{"clone", "--quiet"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	return runGitCommand(append(args, url, destPath), "")
+}
+
+// cloneTrackedRepo fully clones url at ref. A ref that is not a branch or
+// tag is a commit: the default branch is cloned and the commit checked out
+// detached, like a tag.
+func cloneTrackedRepo(url, destPath, ref string) error {
+	err := cloneRepoFull(url, destPath, ref)
+	if err == nil || ref == "" {
+		return err
+	}
+
+	os.RemoveAll(destPath)
+	if err := cloneRepoFull(url, destPath, ""); err != nil {
+		return err
+	}
+	if err := runGitCommand([]string{"checkout", "--quiet", "--detach", ref}, destPath); err != nil {
+		os.RemoveAll(destPath)
+		return fmt.Errorf("ref '%s' not found: %w", ref, err)
+	}
+	return nil
 }
 
 // GetUpdatableSkills returns skill names that have metadata with a remote source.
@@ -0,0 +1,308 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"skillshare/internal/git"
+	"skillshare/internal/utils"
+)
+
+// LockfileVersion is the skillshare.lock format written by this build
+const LockfileVersion = 1
+
+const lockfileHeader = "# Generated by skillshare. Commit this file; edit with 'skillshare install --update-lock'.\n"
+
+// Lockfile pins every installed skill and tracked repo to the commit and
+// content it was installed with, so another machine can reproduce the
+// source directory exactly
+type Lockfile struct {
+	Version int                     `yaml:"version"`
+	Skills  map[string]*LockedSkill `yaml:"skills"`
+}
+
+// LockedSkill is one entry in skillshare.lock, keyed by its path relative to
+// the source directory
+type LockedSkill struct {
+	Source  string `yaml:"source"`            // Source spec as installed, including any @ref pin
+	Commit  string `yaml:"commit,omitempty"`  // Full commit hash (git sources)
+	Subdir  string `yaml:"subdir,omitempty"`  // Subdirectory in the repo
+	Tracked bool   `yaml:"tracked,omitempty"` // Installed with --track
+	Hash    string `yaml:"hash"`              // Content hash of the installed files
+}
+
+// LockDrift describes how an installed skill differs from its lock entry
+type LockDrift struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "missing", "unlocked", "commit_changed" or "modified"
+}
+
+// ReadLockfile loads a lockfile. A missing file returns nil without error.
+func ReadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var lf Lockfile
+	if err := yaml.Unmarshal(data, &lf); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	if lf.Version > LockfileVersion {
+		return nil, fmt.Errorf("%s has version %d; this skillshare understands up to %d", filepath.Base(path), lf.Version, LockfileVersion)
+	}
+	if lf.Skills == nil {
+		lf.Skills = map[string]*LockedSkill{}
+	}
+	return &lf, nil
+}
+
+// WriteLockfile saves a lockfile atomically
+func WriteLockfile(path string, lf *Lockfile) error {
+	lf.Version = LockfileVersion
+	data, err := yaml.Marshal(lf)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create lockfile directory: %w", err)
+	}
+	return utils.WriteFileAtomic(path, append([]byte(lockfileHeader), data...), 0644)
+}
+
+// HashSkill returns the content hash recorded in the lockfile for an
+// installed skill. Install metadata is left out since it carries timestamps.
+func HashSkill(skillPath string) (string, error) {
+	return utils.HashDir(skillPath, metaFileName)
+}
+
+// ScanLockEntries builds a lock entry for every installed skill with
+// metadata and every tracked repo under sourceDir, from what is on disk
+func ScanLockEntries(sourceDir string) (map[string]*LockedSkill, error) {
+	entries := map[string]*LockedSkill{}
+
+	skills, err := GetUpdatableSkills(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range skills {
+		skillPath := filepath.Join(sourceDir, rel)
+		meta, err := ReadMeta(skillPath)
+		if err != nil || meta == nil {
+			continue
+		}
+		hash, err := HashSkill(skillPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", rel, err)
+		}
+		commit := meta.Commit
+		if commit == "" {
+			// Installed before full hashes were recorded
+			commit = meta.Version
+		}
+		entries[filepath.ToSlash(rel)] = &LockedSkill{
+			Source: meta.Source,
+			Commit: commit,
+			Subdir: meta.Subdir,
+			Hash:   hash,
+		}
+	}
+
+	repos, err := GetTrackedRepos(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range repos {
+		repoPath := filepath.Join(sourceDir, rel)
+		origin, err := gitRemoteURL(repoPath)
+		if err != nil {
+			continue // Local-only repo; nothing to reinstall from
+		}
+		commit, err := getGitCommitFull(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit of %s: %w", rel, err)
+		}
+		hash, err := HashSkill(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", rel, err)
+		}
+		if ref := git.PinnedRef(repoPath); ref != "" {
+			origin += "@" + ref
+		}
+		entries[filepath.ToSlash(rel)] = &LockedSkill{
+			Source:  origin,
+			Commit:  commit,
+			Tracked: true,
+			Hash:    hash,
+		}
+	}
+
+	return entries, nil
+}
+
+// RefreshLockfile rewrites the lockfile at path to match sourceDir. Entries
+// named in changed, which the calling command installed or updated, are
+// re-recorded from disk. Every other entry that still has the same source
+// and commit keeps its recorded hash, so local edits still show up as
+// drift; all re-records every entry. No file is created while nothing is
+// installed.
+func RefreshLockfile(path, sourceDir string, changed []string, all bool) (*Lockfile, error) {
+	old, err := ReadLockfile(path)
+	if err != nil {
+		return nil, err
+	}
+	current, err := ScanLockEntries(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if old == nil && len(current) == 0 {
+		return nil, nil
+	}
+
+	if old != nil && !all {
+		for name, entry := range current {
+			prev := old.Skills[name]
+			if prev == nil || prev.Source != entry.Source || prev.Commit != entry.Commit || prev.Tracked != entry.Tracked {
+				continue
+			}
+			if slices.Contains(changed, name) {
+				continue
+			}
+			current[name] = prev
+		}
+	}
+
+	lf := &Lockfile{Version: LockfileVersion, Skills: current}
+	if err := WriteLockfile(path, lf); err != nil {
+		return nil, err
+	}
+	return lf, nil
+}
+
+// Names returns the lock entry names in sorted order
+func (lf *Lockfile) Names() []string {
+	names := make([]string, 0, len(lf.Skills))
+	for name := range lf.Skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Drift compares sourceDir with the lockfile and reports every entry that
+// no longer matches, sorted by name
+func (lf *Lockfile) Drift(sourceDir string) ([]LockDrift, error) {
+	current, err := ScanLockEntries(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []LockDrift
+	for name, locked := range lf.Skills {
+		entry, ok := current[name]
+		switch {
+		case !ok:
+			drift = append(drift, LockDrift{Name: name, Status: "missing"})
+		case locked.Commit != "" && !sameCommit(locked.Commit, entry.Commit):
+			drift = append(drift, LockDrift{Name: name, Status: "commit_changed"})
+		case locked.Hash != entry.Hash:
+			drift = append(drift, LockDrift{Name: name, Status: "modified"})
+		}
+	}
+	for name := range current {
+		if _, ok := lf.Skills[name]; !ok {
+			drift = append(drift, LockDrift{Name: name, Status: "unlocked"})
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Name < drift[j].Name })
+	return drift, nil
+}
+
+// sameCommit compares commit hashes where either side may be abbreviated
+func sameCommit(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a != "" && strings.HasPrefix(b, a)
+}
+
+// InstallLocked installs one lock entry into sourceDir at its locked commit
+// and returns the installed path. The caller verifies the content with
+// VerifyLocked.
+func InstallLocked(name string, entry *LockedSkill, sourceDir string, opts InstallOptions) (string, error) {
+	source, err := ParseSource(entry.Source)
+	if err != nil {
+		return "", fmt.Errorf("invalid source: %w", err)
+	}
+	source.Commit = entry.Commit
+	source.Name = filepath.Base(filepath.FromSlash(name))
+
+	opts.Name = source.Name
+	opts.Into = ""
+	if dir := filepath.Dir(filepath.FromSlash(name)); dir != "." {
+		opts.Into = dir
+	}
+
+	if entry.Tracked {
+		opts.Track = true
+		result, err := InstallTrackedRepo(source, sourceDir, opts)
+		if err != nil {
+			return "", err
+		}
+		return result.RepoPath, nil
+	}
+
+	destPath := filepath.Join(sourceDir, filepath.FromSlash(name))
+	if source.IsGit() && !source.HasSubdir() {
+		// A whole-repo skill was copied out of a discovery clone without
+		// .git; reproduce that rather than cloning into place
+		return destPath, installLockedRepoRoot(source, destPath, opts)
+	}
+	if _, err := Install(source, destPath, opts); err != nil {
+		return "", err
+	}
+	return destPath, nil
+}
+
+func installLockedRepoRoot(source *Source, destPath string, opts InstallOptions) error {
+	discovery, err := DiscoverFromGit(source)
+	if err != nil {
+		return err
+	}
+	defer CleanupDiscovery(discovery)
+
+	for _, skill := range discovery.Skills {
+		if skill.Path == "." {
+			_, err := InstallFromDiscovery(discovery, skill, destPath, opts)
+			return err
+		}
+	}
+	return fmt.Errorf("no SKILL.md at the root of %s", source.Spec())
+}
+
+// VerifyLocked checks an installed skill's content against its lock entry
+func VerifyLocked(skillPath string, entry *LockedSkill) error {
+	hash, err := HashSkill(skillPath)
+	if err != nil {
+		return fmt.Errorf("failed to hash installed files: %w", err)
+	}
+	if hash != entry.Hash {
+		return fmt.Errorf("content hash mismatch: lock has %s, got %s", entry.Hash, hash)
+	}
+	return nil
+}
+
+// gitRemoteURL returns the origin URL of a repository
+func gitRemoteURL(repoPath string) (string, error) {
+	return gitOutput(repoPath, "remote", "get-url", "origin")
+}
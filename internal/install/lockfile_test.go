@@ -0,0 +1,167 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeLockTestSkill creates an installed skill with metadata under sourceDir
+func writeLockTestSkill(t *testing.T, sourceDir, name, commit string) string {
+	t.Helper()
+	dir := filepath.Join(sourceDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: "+filepath.Base(dir)+"\n---\n"), 0644)
+	meta := &SkillMeta{
+		Source:      "github.com/org/repo/" + name,
+		Type:        "github-subdir",
+		InstalledAt: time.Now().Add(-time.Hour),
+		Commit:      commit,
+	}
+	if err := WriteMeta(dir, meta); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestReadLockfile_Missing(t *testing.T) {
+	lock, err := ReadLockfile(filepath.Join(t.TempDir(), "skillshare.lock"))
+	if err != nil || lock != nil {
+		t.Fatalf("ReadLockfile(missing) = %v, %v; want nil, nil", lock, err)
+	}
+}
+
+func TestReadLockfile_NewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skillshare.lock")
+	os.WriteFile(path, []byte("version: 99\nskills: {}\n"), 0644)
+	if _, err := ReadLockfile(path); err == nil {
+		t.Fatal("expected an error for a newer lockfile version")
+	}
+}
+
+func TestRefreshLockfile_RoundTrip(t *testing.T) {
+	sourceDir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), "skillshare.lock")
+	writeLockTestSkill(t, sourceDir, "pdf", "1111111111111111111111111111111111111111")
+	writeLockTestSkill(t, sourceDir, "frontend/react", "2222222222222222222222222222222222222222")
+
+	if _, err := RefreshLockfile(lockPath, sourceDir, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := ReadLockfile(lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lock.Names(); len(got) != 2 || got[0] != "frontend/react" || got[1] != "pdf" {
+		t.Fatalf("Names() = %v", got)
+	}
+	entry := lock.Skills["pdf"]
+	if entry.Source != "github.com/org/repo/pdf" || entry.Commit != "1111111111111111111111111111111111111111" || entry.Hash == "" {
+		t.Errorf("pdf entry = %+v", entry)
+	}
+
+	drift, err := lock.Drift(sourceDir)
+	if err != nil || len(drift) != 0 {
+		t.Errorf("Drift() = %v, %v; want none", drift, err)
+	}
+}
+
+func TestLockfile_Drift(t *testing.T) {
+	sourceDir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), "skillshare.lock")
+	pdf := writeLockTestSkill(t, sourceDir, "pdf", "1111111")
+	writeLockTestSkill(t, sourceDir, "commit", "2222222")
+	gone := writeLockTestSkill(t, sourceDir, "gone", "3333333")
+	if _, err := RefreshLockfile(lockPath, sourceDir, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Local edit, moved commit, removal and a new install
+	os.WriteFile(filepath.Join(pdf, "SKILL.md"), []byte("edited"), 0644)
+	meta, _ := ReadMeta(filepath.Join(sourceDir, "commit"))
+	meta.Commit = "4444444"
+	WriteMeta(filepath.Join(sourceDir, "commit"), meta)
+	os.RemoveAll(gone)
+	writeLockTestSkill(t, sourceDir, "new", "5555555")
+
+	lock, _ := ReadLockfile(lockPath)
+	drift, err := lock.Drift(sourceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"commit": "commit_changed",
+		"gone":   "missing",
+		"new":    "unlocked",
+		"pdf":    "modified",
+	}
+	if len(drift) != len(want) {
+		t.Fatalf("Drift() = %v, want %v", drift, want)
+	}
+	for _, d := range drift {
+		if want[d.Name] != d.Status {
+			t.Errorf("%s: status %q, want %q", d.Name, d.Status, want[d.Name])
+		}
+	}
+}
+
+func TestRefreshLockfile_KeepsHashOfEditedSkill(t *testing.T) {
+	sourceDir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), "skillshare.lock")
+	pdf := writeLockTestSkill(t, sourceDir, "pdf", "1111111")
+	if _, err := RefreshLockfile(lockPath, sourceDir, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(pdf, "SKILL.md"), []byte("edited"), 0644)
+
+	// A newer lockfile mtime, as after a git checkout, changes nothing
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(lockPath, future, future)
+	lock, _ := RefreshLockfile(lockPath, sourceDir, nil, false)
+	if drift, _ := lock.Drift(sourceDir); len(drift) != 1 || drift[0].Status != "modified" {
+		t.Errorf("after refresh: drift = %v, want pdf modified", drift)
+	}
+
+	lock, _ = RefreshLockfile(lockPath, sourceDir, nil, true)
+	if drift, _ := lock.Drift(sourceDir); len(drift) != 0 {
+		t.Errorf("after refresh all: drift = %v, want none", drift)
+	}
+}
+
+func TestRefreshLockfile_NothingInstalled(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "skillshare.lock")
+	lock, err := RefreshLockfile(lockPath, t.TempDir(), nil, false)
+	if err != nil || lock != nil {
+		t.Fatalf("RefreshLockfile() = %v, %v; want nil, nil", lock, err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Error("lockfile should not be created when nothing is installed")
+	}
+}
+
+func TestRefreshLockfile_RerecordsChangedEntries(t *testing.T) {
+	sourceDir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), "skillshare.lock")
+	pdf := writeLockTestSkill(t, sourceDir, "pdf", "1111111")
+	docx := writeLockTestSkill(t, sourceDir, "docx", "2222222")
+	if _, err := RefreshLockfile(lockPath, sourceDir, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// pdf is reinstalled at the same commit with new content; docx is edited
+	past := time.Now().Add(-24 * time.Hour)
+	os.Chtimes(lockPath, past, past)
+	os.WriteFile(filepath.Join(pdf, "SKILL.md"), []byte("reinstalled"), 0644)
+	os.WriteFile(filepath.Join(docx, "SKILL.md"), []byte("edited"), 0644)
+
+	lock, err := RefreshLockfile(lockPath, sourceDir, []string{"pdf"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift, _ := lock.Drift(sourceDir); len(drift) != 1 || drift[0].Name != "docx" || drift[0].Status != "modified" {
+		t.Errorf("drift = %v, want only docx modified", drift)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"skillshare/internal/utils"
 )
 
 const metaFileName = ".skillshare-meta.json"
@@ -19,6 +21,11 @@ type SkillMeta struct {
 	RepoURL     string    `json:"repo_url,omitempty"` // Git repo URL (for git sources)
 	Subdir      string    `json:"subdir,omitempty"`   // Subdirectory path (for monorepo)
 	Version     string    `json:"version,omitempty"`  // Git commit hash or version
+	Ref         string    `json:"ref,omitempty"`      // Pinned branch, tag or commit (empty follows the default branch)
+	Commit      string    `json:"commit,omitempty"`   // Full commit hash, recorded in the lockfile
+	URL         string    `json:"url,omitempty"`      // Archive location (archive sources)
+	Digest      string    `json:"digest,omitempty"`   // sha256 of the installed archive ("sha256:<hex>")
+	Requires    []string  `json:"requires,omitempty"` // Installed skills it requires, relative to the source directory
 }
 
 // WriteMeta saves metadata to the skill directory
@@ -30,7 +37,7 @@ func WriteMeta(skillPath string, meta *SkillMeta) error {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	if err := os.WriteFile(metaPath, data, 0644); err != nil {
+	if err := utils.WriteFileAtomic(metaPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write metadata: %w", err)
 	}
 
@@ -53,10 +60,36 @@ func ReadMeta(skillPath string) (*SkillMeta, error) {
 	if err := json.Unmarshal(data, &meta); err != nil {
 		return nil, fmt.Errorf("failed to parse metadata: %w", err)
 	}
+	meta.Source = upgradeSubdirSource(&meta)
 
 	return &meta, nil
 }
 
+// upgradeSubdirSource rewrites a source recorded before GitLab paths were
+// read as nested groups: gitlab.com/team/repo/skills/pdf used to mean repo
+// team/repo with subdir skills/pdf and is now written team/repo//skills/pdf.
+// Any other source is returned unchanged.
+func upgradeSubdirSource(meta *SkillMeta) string {
+	spec, ref := splitRef(meta.Source)
+	suffix := "/" + meta.Subdir
+	if meta.RepoURL == "" || meta.Subdir == "" || !strings.HasPrefix(spec, "https://") ||
+		!strings.HasSuffix(spec, suffix) || strings.HasSuffix(spec, "/"+suffix) {
+		return meta.Source
+	}
+	if source, err := ParseSource(meta.Source); err != nil || source.CloneURL == meta.RepoURL {
+		return meta.Source
+	}
+
+	upgraded := strings.TrimSuffix(spec, suffix) + "/" + suffix
+	if ref != "" {
+		upgraded += "@" + ref
+	}
+	if source, err := ParseSource(upgraded); err != nil || source.CloneURL != meta.RepoURL {
+		return meta.Source
+	}
+	return upgraded
+}
+
 // HasMeta checks if a skill directory has metadata
 func HasMeta(skillPath string) bool {
 	metaPath := filepath.Join(skillPath, metaFileName)
@@ -67,14 +100,18 @@ func HasMeta(skillPath string) bool {
 // NewMetaFromSource creates a SkillMeta from a Source
 func NewMetaFromSource(source *Source) *SkillMeta {
 	meta := &SkillMeta{
-		Source:      source.Raw,
+		Source:      source.Spec(),
 		Type:        source.MetaType(),
 		InstalledAt: time.Now(),
+		Ref:         source.Ref,
 	}
 
 	if source.IsGit() {
 		meta.RepoURL = source.CloneURL
 	}
+	if source.IsArchive() {
+		meta.URL = source.URL
+	}
 
 	if source.HasSubdir() {
 		meta.Subdir = strings.ReplaceAll(source.Subdir, "\\", "/")
@@ -62,3 +62,33 @@ func TestInstallFromDiscovery_FullSubdirUsesForwardSlashes(t *testing.T) {
 		t.Errorf("fullSubdir = %q, want %q", fullSubdir, "skills/my-skill")
 	}
 }
+
+func TestReadMeta_UpgradesLegacyGitLabSubdir(t *testing.T) {
+	dir := t.TempDir()
+	meta := &SkillMeta{
+		Source:  "https://gitlab.com/team/repo/skills/pdf@v1",
+		RepoURL: "https://gitlab.com/team/repo.git",
+		Subdir:  "skills/pdf",
+	}
+	if err := WriteMeta(dir, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadMeta(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://gitlab.com/team/repo//skills/pdf@v1"; got.Source != want {
+		t.Errorf("Source = %q, want %q", got.Source, want)
+	}
+
+	// Sources that already parse to their repo are left alone
+	meta.Source = "https://bitbucket.org/team/repo/skills/pdf"
+	meta.RepoURL = "https://bitbucket.org/team/repo.git"
+	if err := WriteMeta(dir, meta); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ReadMeta(dir); got.Source != meta.Source {
+		t.Errorf("Source = %q, want unchanged %q", got.Source, meta.Source)
+	}
+}
@@ -0,0 +1,393 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"skillshare/internal/utils"
+)
+
+// ReadRequires returns the requires: entries of a skill's SKILL.md. Each is a
+// source spec with an optional @ref, or the bare name of an installed skill.
+func ReadRequires(skillPath string) []string {
+	var fields struct {
+		Requires utils.FrontmatterList `yaml:"requires"`
+	}
+	utils.ReadFrontmatter(filepath.Join(skillPath, "SKILL.md"), &fields)
+	return fields.Requires
+}
+
+// isBareRequirement reports whether a requires: entry names an installed
+// skill rather than a source
+func isBareRequirement(spec string) bool {
+	return !strings.ContainsAny(spec, `/\:~`) && !strings.HasPrefix(spec, ".")
+}
+
+// sourceIdentity identifies what a source installs regardless of its ref,
+// so a requirement matches a skill installed from the same place
+func sourceIdentity(source *Source) string {
+	switch {
+	case source.IsGit():
+		return identityKey(source.CloneURL, source.Subdir)
+	case source.IsArchive():
+		return identityKey(source.URL, source.Subdir)
+	default:
+		abs, err := filepath.Abs(source.Path)
+		if err != nil {
+			abs = source.Path
+		}
+		return identityKey(abs, "")
+	}
+}
+
+// metaIdentity is sourceIdentity for an installed skill's metadata
+func metaIdentity(meta *SkillMeta) string {
+	switch {
+	case meta.RepoURL != "":
+		return identityKey(meta.RepoURL, meta.Subdir)
+	case meta.URL != "":
+		return identityKey(meta.URL, meta.Subdir)
+	}
+	source, err := ParseSource(meta.Source)
+	if err != nil {
+		return ""
+	}
+	return sourceIdentity(source)
+}
+
+func identityKey(location, subdir string) string {
+	location = strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+	subdir = strings.Trim(path.Clean(strings.ReplaceAll(subdir, "\\", "/")), "/")
+	if subdir == "." {
+		subdir = ""
+	}
+	return strings.ToLower(location) + "//" + subdir
+}
+
+// installedIndex maps the identity of every installed skill with metadata
+// to its path relative to the source directory
+func installedIndex(sourceDir string) map[string]string {
+	index := map[string]string{}
+	skills, err := GetUpdatableSkills(sourceDir)
+	if err != nil {
+		return index
+	}
+	for _, rel := range skills {
+		meta, err := ReadMeta(filepath.Join(sourceDir, rel))
+		if err != nil || meta == nil {
+			continue
+		}
+		if id := metaIdentity(meta); id != "" {
+			index[id] = filepath.ToSlash(rel)
+		}
+	}
+	return index
+}
+
+// lookupInstalled finds the installed skill a requirement refers to: by
+// source when it has one, otherwise by name in the source directory
+func lookupInstalled(sourceDir string, index map[string]string, source *Source, name string) (string, bool) {
+	if source != nil {
+		if rel, ok := index[sourceIdentity(source)]; ok {
+			return rel, true
+		}
+	}
+	if _, err := os.Stat(filepath.Join(sourceDir, filepath.FromSlash(name), "SKILL.md")); err == nil {
+		return name, true
+	}
+	return "", false
+}
+
+// installedRequires resolves the requires: entries of a freshly installed
+// skill to the installed skills they name. Entries that are not installed
+// are left out.
+func installedRequires(skillPath, sourceDir string) []string {
+	specs := ReadRequires(skillPath)
+	if len(specs) == 0 {
+		return nil
+	}
+	index := installedIndex(sourceDir)
+
+	var names []string
+	for _, spec := range specs {
+		var source *Source
+		name := spec
+		if !isBareRequirement(spec) {
+			parsed, err := ParseSource(spec)
+			if err != nil {
+				continue
+			}
+			source, name = parsed, parsed.Name
+		}
+		if rel, ok := lookupInstalled(sourceDir, index, source, name); ok && !slices.Contains(names, rel) {
+			names = append(names, rel)
+		}
+	}
+	return names
+}
+
+// recordRequires stores the dependency edges of an installed skill in its
+// metadata when the install knows its source directory
+func recordRequires(meta *SkillMeta, destPath string, opts InstallOptions) {
+	if opts.SourceDir != "" {
+		meta.Requires = installedRequires(destPath, opts.SourceDir)
+	}
+}
+
+// DepNode is a skill in a dependency tree
+type DepNode struct {
+	Name      string     // Path relative to the source directory
+	Spec      string     // requires: entry that pulled it in (empty for roots)
+	Installed bool       // Already in the source directory
+	Requires  []*DepNode // Direct dependencies
+
+	identity  string
+	dir       string // Where its SKILL.md is read from
+	source    *Source
+	discovery *DiscoveryResult
+	skill     SkillInfo
+	expanded  bool
+}
+
+// DepResolver resolves requires: entries transitively against the skills
+// installed in a source directory, fetching the ones that are missing
+type DepResolver struct {
+	sourceDir   string
+	index       map[string]string
+	nodes       map[string]*DepNode
+	roots       []*DepNode
+	discoveries []*DiscoveryResult
+}
+
+// NewDepResolver creates a resolver for skills installed in sourceDir
+func NewDepResolver(sourceDir string) *DepResolver {
+	return &DepResolver{
+		sourceDir: sourceDir,
+		index:     installedIndex(sourceDir),
+		nodes:     map[string]*DepNode{},
+	}
+}
+
+// Resolve builds the dependency tree of a skill about to be installed as
+// name, reading its SKILL.md from dir. A cycle or a requirement that cannot
+// be fetched is an error.
+func (r *DepResolver) Resolve(name, dir string) (*DepNode, error) {
+	name = filepath.ToSlash(name)
+	root := r.nodes[name]
+	if root == nil {
+		root = &DepNode{Name: name}
+		r.nodes[name] = root
+	}
+	root.dir = dir
+	root.expanded = true
+	root.Requires = nil
+	if err := r.expand(root, []string{name}); err != nil {
+		return nil, err
+	}
+	r.roots = append(r.roots, root)
+	return root, nil
+}
+
+func (r *DepResolver) expand(node *DepNode, chain []string) error {
+	for _, spec := range ReadRequires(node.dir) {
+		dep, err := r.node(spec)
+		if err != nil {
+			return fmt.Errorf("%s requires %s: %w", node.Name, spec, err)
+		}
+		if slices.Contains(chain, dep.Name) {
+			return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(chain, " -> "), dep.Name)
+		}
+		if !slices.Contains(node.Requires, dep) {
+			node.Requires = append(node.Requires, dep)
+		}
+		if dep.expanded {
+			continue
+		}
+		dep.expanded = true
+		if err := r.expand(dep, append(slices.Clone(chain), dep.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// node returns the tree node for a requires: entry, fetching the source when
+// the skill is not installed yet
+func (r *DepResolver) node(spec string) (*DepNode, error) {
+	var source *Source
+	name := spec
+	if !isBareRequirement(spec) {
+		parsed, err := ParseSource(spec)
+		if err != nil {
+			return nil, err
+		}
+		source, name = parsed, parsed.Name
+	}
+
+	if rel, ok := lookupInstalled(r.sourceDir, r.index, source, name); ok {
+		if n := r.nodes[rel]; n != nil {
+			return n, nil
+		}
+		n := &DepNode{Name: rel, Spec: spec, Installed: true, dir: filepath.Join(r.sourceDir, filepath.FromSlash(rel))}
+		r.nodes[rel] = n
+		return n, nil
+	}
+	if source == nil {
+		return nil, fmt.Errorf("no skill named %q is installed (give a source such as owner/repo/path to install it)", name)
+	}
+
+	identity := sourceIdentity(source)
+	if n := r.nodes[name]; n != nil {
+		// Roots have no source; requiring one is caught as a cycle
+		if n.source != nil && n.identity != identity {
+			return nil, fmt.Errorf("another requirement is also named %q (%s)", name, n.Spec)
+		}
+		return n, nil
+	}
+
+	n := &DepNode{Name: name, Spec: spec, identity: identity, source: source}
+	if err := r.fetch(n); err != nil {
+		return nil, err
+	}
+	r.nodes[name] = n
+	return n, nil
+}
+
+// fetch makes a missing dependency's SKILL.md readable: local sources in
+// place, others by discovering the source into a temp directory
+func (r *DepResolver) fetch(n *DepNode) error {
+	if n.source.Type == SourceTypeLocalPath {
+		if _, err := os.Stat(filepath.Join(n.source.Path, "SKILL.md")); err != nil {
+			return fmt.Errorf("no SKILL.md in %s", n.source.Path)
+		}
+		n.dir = n.source.Path
+		return nil
+	}
+
+	discovery, err := Discover(n.source)
+	if err != nil {
+		return err
+	}
+	r.discoveries = append(r.discoveries, discovery)
+
+	skill, ok := dependencySkill(discovery)
+	if !ok {
+		return fmt.Errorf("%s holds %d skills; require one of them by path", n.source.Spec(), len(discovery.Skills))
+	}
+	n.discovery = discovery
+	n.skill = skill
+	n.dir = DiscoveredSkillPath(discovery, skill)
+	return nil
+}
+
+// dependencySkill picks the skill a required source installs: its root
+// skill, or its only one
+func dependencySkill(discovery *DiscoveryResult) (SkillInfo, bool) {
+	for _, skill := range discovery.Skills {
+		if skill.Path == "." {
+			return skill, true
+		}
+	}
+	if len(discovery.Skills) == 1 {
+		return discovery.Skills[0], true
+	}
+	return SkillInfo{}, false
+}
+
+// Missing returns the dependencies of the resolved roots that still need
+// installing, each after the skills it requires
+func (r *DepResolver) Missing() []*DepNode {
+	var order []*DepNode
+	seen := map[*DepNode]bool{}
+	var visit func(n *DepNode)
+	visit = func(n *DepNode) {
+		if seen[n] {
+			return
+		}
+		seen[n] = true
+		for _, dep := range n.Requires {
+			visit(dep)
+		}
+		if !n.Installed && !slices.Contains(r.roots, n) {
+			order = append(order, n)
+		}
+	}
+	for _, root := range r.roots {
+		visit(root)
+	}
+	return order
+}
+
+// Install installs a missing dependency into the source directory under its
+// name, recording its own dependency edges
+func (r *DepResolver) Install(n *DepNode, opts InstallOptions) (*InstallResult, error) {
+	destPath := filepath.Join(r.sourceDir, filepath.FromSlash(n.Name))
+	opts.Name = ""
+	opts.Into = ""
+	opts.Force = false
+	opts.Update = false
+	opts.SourceDir = r.sourceDir
+
+	var result *InstallResult
+	var err error
+	if n.discovery != nil {
+		result, err = InstallFromDiscovery(n.discovery, n.skill, destPath, opts)
+	} else {
+		result, err = Install(n.source, destPath, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !opts.DryRun {
+		n.Installed = true
+	}
+	return result, nil
+}
+
+// Cleanup removes the temp directories of fetched dependencies
+func (r *DepResolver) Cleanup() {
+	for _, discovery := range r.discoveries {
+		CleanupDiscovery(discovery)
+	}
+	r.discoveries = nil
+}
+
+// DependencyGraph returns the recorded requires edges of every installed
+// skill in sourceDir, keyed by path relative to sourceDir
+func DependencyGraph(sourceDir string) (map[string][]string, error) {
+	skills, err := GetUpdatableSkills(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	graph := make(map[string][]string, len(skills))
+	for _, rel := range skills {
+		meta, err := ReadMeta(filepath.Join(sourceDir, rel))
+		if err != nil || meta == nil {
+			continue
+		}
+		graph[filepath.ToSlash(rel)] = meta.Requires
+	}
+	return graph, nil
+}
+
+// Dependents returns the installed skills that require name or a skill
+// nested under it, sorted. Skills under name itself are left out.
+func Dependents(graph map[string][]string, name string) []string {
+	name = filepath.ToSlash(name)
+	within := func(skill string) bool {
+		return skill == name || strings.HasPrefix(skill, name+"/")
+	}
+	var dependents []string
+	for skill, requires := range graph {
+		if !within(skill) && slices.ContainsFunc(requires, within) {
+			dependents = append(dependents, skill)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
@@ -0,0 +1,165 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeRequiringSkill writes a skill whose frontmatter requires the given entries
+func writeRequiringSkill(t *testing.T, dir string, requires ...string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	body := "---\nname: " + filepath.Base(dir) + "\n"
+	if len(requires) > 0 {
+		body += "requires: [" + strings.Join(requires, ", ") + "]\n"
+	}
+	body += "---\n# " + filepath.Base(dir) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func depNames(nodes []*DepNode) []string {
+	names := make([]string, len(nodes))
+	for i, n := range nodes {
+		names[i] = n.Name
+	}
+	return names
+}
+
+func TestDepResolver_InstallsDependenciesFirst(t *testing.T) {
+	upstream := t.TempDir()
+	sourceDir := t.TempDir()
+
+	base := writeRequiringSkill(t, filepath.Join(upstream, "base"))
+	lint := writeRequiringSkill(t, filepath.Join(upstream, "lint"), base)
+	format := writeRequiringSkill(t, filepath.Join(upstream, "format"), base)
+	app := writeRequiringSkill(t, filepath.Join(upstream, "app"), lint, format)
+
+	r := NewDepResolver(sourceDir)
+	defer r.Cleanup()
+	root, err := r.Resolve("app", app)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := depNames(root.Requires); !reflect.DeepEqual(got, []string{"lint", "format"}) {
+		t.Errorf("app requires %v, want [lint format]", got)
+	}
+	if root.Requires[0].Requires[0] != root.Requires[1].Requires[0] {
+		t.Error("a shared dependency should be a single node")
+	}
+
+	missing := r.Missing()
+	if got := depNames(missing); !reflect.DeepEqual(got, []string{"base", "lint", "format"}) {
+		t.Fatalf("Missing() = %v, want [base lint format]", got)
+	}
+	for _, n := range missing {
+		if _, err := r.Install(n, InstallOptions{}); err != nil {
+			t.Fatalf("Install(%s) error = %v", n.Name, err)
+		}
+	}
+
+	meta, err := ReadMeta(filepath.Join(sourceDir, "lint"))
+	if err != nil || meta == nil {
+		t.Fatalf("ReadMeta(lint) = %v, %v", meta, err)
+	}
+	if !reflect.DeepEqual(meta.Requires, []string{"base"}) {
+		t.Errorf("lint meta requires %v, want [base]", meta.Requires)
+	}
+
+	graph, err := DependencyGraph(sourceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Dependents(graph, "base"); !reflect.DeepEqual(got, []string{"format", "lint"}) {
+		t.Errorf("Dependents(base) = %v, want [format lint]", got)
+	}
+}
+
+func TestDepResolver_Cycle(t *testing.T) {
+	upstream := t.TempDir()
+	a := filepath.Join(upstream, "a")
+	b := filepath.Join(upstream, "b")
+	writeRequiringSkill(t, a, b)
+	writeRequiringSkill(t, b, a)
+
+	r := NewDepResolver(t.TempDir())
+	defer r.Cleanup()
+	_, err := r.Resolve("a", a)
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle: a -> b -> a") {
+		t.Fatalf("Resolve() error = %v, want a cycle through a and b", err)
+	}
+}
+
+func TestDepResolver_BareNames(t *testing.T) {
+	upstream := t.TempDir()
+	sourceDir := t.TempDir()
+	writeRequiringSkill(t, filepath.Join(sourceDir, "conventions"))
+
+	ok := writeRequiringSkill(t, filepath.Join(upstream, "ok"), "conventions")
+	r := NewDepResolver(sourceDir)
+	root, err := r.Resolve("ok", ok)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(root.Requires) != 1 || !root.Requires[0].Installed || len(r.Missing()) != 0 {
+		t.Errorf("an installed bare name should resolve without installing anything")
+	}
+
+	missing := writeRequiringSkill(t, filepath.Join(upstream, "missing"), "absent")
+	if _, err := NewDepResolver(sourceDir).Resolve("missing", missing); err == nil || !strings.Contains(err.Error(), `no skill named "absent"`) {
+		t.Errorf("Resolve() error = %v, want absent to be reported", err)
+	}
+}
+
+func TestInstall_RecordsRequiresOfInstalledSkills(t *testing.T) {
+	upstream := t.TempDir()
+	sourceDir := t.TempDir()
+
+	base := writeRequiringSkill(t, filepath.Join(upstream, "base"))
+	source, err := ParseSource(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(source, filepath.Join(sourceDir, "base"), InstallOptions{SourceDir: sourceDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The source spec matches the installed skill; the bare name does not exist
+	app := writeRequiringSkill(t, filepath.Join(upstream, "app"), base, "unknown")
+	source, err = ParseSource(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(source, filepath.Join(sourceDir, "app"), InstallOptions{SourceDir: sourceDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := ReadMeta(filepath.Join(sourceDir, "app"))
+	if err != nil || meta == nil {
+		t.Fatalf("ReadMeta(app) = %v, %v", meta, err)
+	}
+	if !reflect.DeepEqual(meta.Requires, []string{"base"}) {
+		t.Errorf("app meta requires %v, want [base]", meta.Requires)
+	}
+}
+
+func TestDependents_NestedUnderName(t *testing.T) {
+	graph := map[string][]string{
+		"app":           {"tools/lint"},
+		"tools/format":  {"tools/lint"},
+		"other":         {"toolsmith"},
+		"tools/lint":    nil,
+		"standalone":    nil,
+		"docs/overview": {"tools"},
+	}
+	if got := Dependents(graph, "tools"); !reflect.DeepEqual(got, []string{"app", "docs/overview"}) {
+		t.Errorf("Dependents(tools) = %v, want [app docs/overview]", got)
+	}
+}
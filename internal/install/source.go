@@ -3,6 +3,7 @@ package install
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -17,6 +18,7 @@ const (
 	SourceTypeGitHub
 	SourceTypeGitHTTPS
 	SourceTypeGitSSH
+	SourceTypeArchive
 )
 
 func (t SourceType) String() string {
@@ -29,6 +31,8 @@ func (t SourceType) String() string {
 		return "git-https"
 	case SourceTypeGitSSH:
 		return "git-ssh"
+	case SourceTypeArchive:
+		return "archive"
 	default:
 		return "unknown"
 	}
@@ -42,6 +46,12 @@ type Source struct {
 	Subdir   string // Subdirectory path for monorepo
 	Path     string // Local path (empty for git)
 	Name     string // Derived skill name
+	Ref      string // Branch, tag or commit to check out (empty for the default branch)
+	Commit   string // Exact commit from a lockfile; overrides Ref for checkout but is not recorded as a pin
+	URL      string // Archive location: absolute local path or https URL (archive sources)
+	Digest   string // Expected hex sha256 of the archive from a #sha256= fragment
+
+	nestedGuess bool // GitLab path read as nested groups that may be a project and subdir; see resolveNestedGroup
 }
 
 // GitHub URL pattern: github.com/owner/repo[/path/to/subdir]
@@ -50,7 +60,7 @@ var githubPattern = regexp.MustCompile(`^(?:https?://)?github\.com/([^/]+)/([^/]
 // Git SSH pattern: git@host:owner/repo[.git][//subdir]
 var gitSSHPattern = regexp.MustCompile(`^git@([^:]+):([^/]+)/(.+?)(?:\.git)?(?://(.+))?$`)
 
-// Git HTTPS pattern: https://host/owner/repo[.git]
+// Git HTTPS pattern: https://host/owner/repo[.git][/subdir]; see splitForgePath
 var gitHTTPSPattern = regexp.MustCompile(`^https?://([^/]+)/([^/]+)/([^/]+?)(?:\.git)?(?:/(.+))?$`)
 
 // File URL pattern: file:///path/to/repo
@@ -63,10 +73,24 @@ func ParseSource(input string) (*Source, error) {
 		return nil, fmt.Errorf("source cannot be empty")
 	}
 
+	// Archives: skills.tar.gz[/subdir][#sha256=...], local or https
+	if source, ok, err := parseArchive(input); ok || err != nil {
+		return source, err
+	}
+
+	// Split off a pinned ref: owner/repo@v1.2.0 -> owner/repo + v1.2.0
+	var ref string
+	if !isLocalPath(input) {
+		input, ref = splitRef(input)
+		if err := ValidateRef(ref); err != nil {
+			return nil, err
+		}
+	}
+
 	// Expand GitHub shorthand: owner/repo -> github.com/owner/repo
 	input = expandGitHubShorthand(input)
 
-	source := &Source{Raw: input}
+	source := &Source{Raw: input, Ref: ref}
 
 	// Check for file:// URL (for testing with local git repos)
 	if matches := fileURLPattern.FindStringSubmatch(input); matches != nil {
@@ -96,6 +120,32 @@ func ParseSource(input string) (*Source, error) {
 	return nil, fmt.Errorf("unrecognized source format: %s", input)
 }
 
+// splitRef separates a trailing @ref from a remote source. The @ of
+// git@host:... or https://user@host/... is not a ref: a ref has to follow
+// a path segment.
+func splitRef(input string) (string, string) {
+	i := strings.LastIndex(input, "@")
+	if i <= 0 || i == len(input)-1 {
+		return input, ""
+	}
+	head := input[:i]
+	if _, rest, ok := strings.Cut(head, "://"); ok {
+		head = rest
+	}
+	if !strings.Contains(head, "/") {
+		return input, ""
+	}
+	return input[:i], input[i+1:]
+}
+
+// ValidateRef rejects refs git would read as an option or cannot resolve.
+func ValidateRef(ref string) error {
+	if strings.HasPrefix(ref, "-") || strings.ContainsAny(ref, " \t\n") || strings.Contains(ref, "..") {
+		return fmt.Errorf("invalid ref: %q", ref)
+	}
+	return nil
+}
+
 func isLocalPath(input string) bool {
 	return strings.HasPrefix(input, "/") ||
 		strings.HasPrefix(input, "~") ||
@@ -167,14 +217,26 @@ func parseGitHub(matches []string, source *Source) (*Source, error) {
 	}
 
 	// Handle GitHub web URL format: /tree/{branch}/path or /blob/{branch}/path
-	// Strip the tree/branch or blob/branch prefix to get the actual subdir
-	subdir = stripGitHubBranchPrefix(subdir)
+	// Strip the tree/branch or blob/branch prefix to get the actual subdir;
+	// the branch becomes the ref unless an @ref was given
+	subdir, branch := stripGitHubBranchPrefix(subdir)
 
 	// Normalize "." subdir (explicit root) to empty string
 	if subdir == "." {
 		subdir = ""
 	}
 
+	if branch != "" {
+		if source.Ref == "" {
+			source.Ref = branch
+		}
+		// Keep Raw free of the ref so Spec() can pin it on its own
+		source.Raw = "github.com/" + owner + "/" + matches[2]
+		if subdir != "" {
+			source.Raw += "/" + subdir
+		}
+	}
+
 	source.Type = SourceTypeGitHub
 	source.CloneURL = fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
 
@@ -189,10 +251,11 @@ func parseGitHub(matches []string, source *Source) (*Source, error) {
 	return source, nil
 }
 
-// stripGitHubBranchPrefix removes tree/{branch}/ or blob/{branch}/ from GitHub web URLs
-func stripGitHubBranchPrefix(subdir string) string {
+// stripGitHubBranchPrefix removes tree/{branch}/ or blob/{branch}/ from GitHub
+// web URLs, returning the remaining subdir and the branch
+func stripGitHubBranchPrefix(subdir string) (string, string) {
 	if subdir == "" {
-		return ""
+		return "", ""
 	}
 
 	parts := strings.SplitN(subdir, "/", 3)
@@ -202,13 +265,13 @@ func stripGitHubBranchPrefix(subdir string) string {
 		// parts[1] = branch name (e.g., "main", "master", "v1.0")
 		// parts[2] = actual path (if exists)
 		if len(parts) == 3 {
-			return parts[2]
+			return parts[2], parts[1]
 		}
 		// Only tree/branch, no actual subdir
-		return ""
+		return "", parts[1]
 	}
 
-	return subdir
+	return subdir, ""
 }
 
 func parseGitSSH(matches []string, source *Source) (*Source, error) {
@@ -228,7 +291,8 @@ func parseGitSSH(matches []string, source *Source) (*Source, error) {
 		source.Subdir = subdir
 		source.Name = filepath.Base(subdir)
 	} else {
-		source.Name = repo
+		// repo holds the subgroups of a nested GitLab path
+		source.Name = path.Base(repo)
 	}
 
 	return source, nil
@@ -246,76 +310,52 @@ func parseFileURL(matches []string, source *Source) (*Source, error) {
 }
 
 func parseGitHTTPS(matches []string, source *Source) (*Source, error) {
-	// matches: [full, host, owner, repo, subdir]
+	// matches: [full, host, owner, repo, subdir]; the path is split again
+	// by forge since GitLab repos can sit in nested groups
 	host := matches[1]
-	owner := matches[2]
-	repo := strings.TrimSuffix(matches[3], ".git")
-	subdir := ""
-	if len(matches) > 4 {
-		subdir = matches[4]
+	_, afterScheme, _ := strings.Cut(matches[0], "://")
+	forge, rest := forgeFor(host), strings.TrimPrefix(afterScheme, host+"/")
+	repo, subdir, branch, err := splitForgePath(forge, rest)
+	if err != nil {
+		return nil, err
+	}
+	// Without a //, .git or /-/ marker a deep GitLab path may still mean a
+	// project and its subdir, as it did before nested groups were read
+	if forge == ForgeGitLab && strings.Count(repo, "/") >= 2 {
+		bare, _, _ := strings.Cut(rest, "?")
+		source.nestedGuess = strings.Trim(bare, "/") == repo
 	}
-
-	// Strip platform-specific branch prefixes from web URLs
-	subdir = stripGitBranchPrefix(host, subdir)
 
 	// Normalize "." subdir (explicit root) to empty string
 	if subdir == "." {
 		subdir = ""
 	}
 
+	if branch != "" {
+		if source.Ref == "" {
+			source.Ref = branch
+		}
+		// Keep Raw free of the web URL's ref so Spec() can pin it on its own;
+		// "//" keeps the subdir apart from a nested GitLab group
+		source.Raw = "https://" + host + "/" + repo
+		if subdir != "" {
+			source.Raw += "//" + subdir
+		}
+	}
+
 	source.Type = SourceTypeGitHTTPS
-	source.CloneURL = fmt.Sprintf("https://%s/%s/%s.git", host, owner, repo)
+	source.CloneURL = fmt.Sprintf("https://%s/%s.git", host, repo)
 
 	if subdir != "" {
 		source.Subdir = subdir
 		source.Name = filepath.Base(subdir)
 	} else {
-		source.Name = repo
+		source.Name = path.Base(repo)
 	}
 
 	return source, nil
 }
 
-// stripGitBranchPrefix removes platform-specific branch path segments from web URLs.
-// Bitbucket: src/{branch}/path → path
-// GitLab:    -/tree/{branch}/path → path, -/blob/{branch}/path → path
-func stripGitBranchPrefix(host, subdir string) string {
-	if subdir == "" {
-		return ""
-	}
-
-	subdir = strings.TrimRight(subdir, "/")
-	parts := strings.SplitN(subdir, "/", 3)
-
-	// Bitbucket: src/{branch}/path
-	if strings.Contains(host, "bitbucket") && len(parts) >= 2 && parts[0] == "src" {
-		if len(parts) == 3 {
-			return parts[2]
-		}
-		return ""
-	}
-
-	// GitLab: -/tree/{branch}/path or -/blob/{branch}/path
-	if parts[0] == "-" && len(parts) >= 2 {
-		rest := strings.SplitN(parts[1], "/", 2)
-		if rest[0] == "tree" || rest[0] == "blob" {
-			// subdir is "-/tree/{branch}/path" or "-/blob/{branch}/path"
-			// After SplitN(subdir, "/", 3): parts = ["-", "tree", "{branch}/path"]
-			// Need to further split parts[2] to get past branch
-			if len(parts) == 3 {
-				inner := strings.SplitN(parts[2], "/", 2)
-				// inner[0] = branch, inner[1] = actual path
-				if len(inner) == 2 {
-					return inner[1]
-				}
-			}
-			return ""
-		}
-	}
-
-	return subdir
-}
-
 // HasSubdir returns true if this source requires subdirectory extraction
 func (s *Source) HasSubdir() bool {
 	return s.Subdir != ""
@@ -332,6 +372,7 @@ func (s *Source) IsGit() bool {
 // For GitHub: https://github.com/openai/skills.git → "openai-skills"
 // For SSH:    git@github.com:openai/skills.git    → "openai-skills"
 // For HTTPS:  https://gitlab.com/team/repo.git    → "team-repo"
+// Nested:     https://gitlab.com/org/team/repo.git → "team-repo"
 // Falls back to source.Name if owner cannot be extracted.
 func (s *Source) TrackName() string {
 	url := s.CloneURL
@@ -341,9 +382,9 @@ func (s *Source) TrackName() string {
 
 	// Try SSH format: git@host:owner/repo.git
 	if sshMatches := gitSSHPattern.FindStringSubmatch(s.Raw); sshMatches != nil {
-		owner := sshMatches[2]
-		repo := strings.TrimSuffix(sshMatches[3], ".git")
-		return owner + "-" + repo
+		// Nested GitLab groups keep only the innermost group
+		full := sshMatches[2] + "/" + strings.TrimSuffix(sshMatches[3], ".git")
+		return path.Base(path.Dir(full)) + "-" + path.Base(full)
 	}
 
 	// Try extracting owner/repo from HTTPS clone URL
@@ -362,6 +403,48 @@ func (s *Source) TrackName() string {
 	return s.Name
 }
 
+// Spec returns the source as it can be passed back to install: Raw with
+// an @ref suffix when the source is pinned, or a #sha256= fragment for a
+// digest-pinned archive.
+func (s *Source) Spec() string {
+	spec := s.Raw
+	if s.Ref != "" {
+		spec += "@" + s.Ref
+	}
+	if s.Digest != "" {
+		spec += "#sha256=" + s.Digest
+	}
+	return spec
+}
+
+// WithRef returns a copy of the source pinned to ref, or following the
+// default branch when ref is empty. Archives have no refs; an empty ref
+// drops their digest pin instead.
+func (s *Source) WithRef(ref string) (*Source, error) {
+	if err := ValidateRef(ref); err != nil {
+		return nil, err
+	}
+	pinned := *s
+	if s.IsArchive() {
+		if ref != "" {
+			return nil, fmt.Errorf("archive sources cannot be pinned to a ref; add #sha256=<digest> to the source instead")
+		}
+		pinned.Digest = ""
+		return &pinned, nil
+	}
+	pinned.Ref = ref
+	return &pinned, nil
+}
+
+// checkoutRef returns what to check out after cloning: the locked commit
+// when there is one, otherwise Ref.
+func (s *Source) checkoutRef() string {
+	if s.Commit != "" {
+		return s.Commit
+	}
+	return s.Ref
+}
+
 // MetaType returns the type string for metadata
 func (s *Source) MetaType() string {
 	if s.HasSubdir() {
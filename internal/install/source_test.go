@@ -1,6 +1,8 @@
 package install
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -155,6 +157,47 @@ func TestParseSource_GitHubShorthand(t *testing.T) {
 	}
 }
 
+func TestParseSource_Ref(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantRaw    string
+		wantSubdir string
+		wantRef    string
+		wantSpec   string
+	}{
+		{"shorthand tag", "owner/repo@v1.2.0", "github.com/owner/repo", "", "v1.2.0", "github.com/owner/repo@v1.2.0"},
+		{"shorthand subdir branch", "owner/repo/skills/pdf@feature/x", "github.com/owner/repo/skills/pdf", "skills/pdf", "feature/x", "github.com/owner/repo/skills/pdf@feature/x"},
+		{"tree url", "https://github.com/owner/repo/tree/v2/skills/pdf", "github.com/owner/repo/skills/pdf", "skills/pdf", "v2", "github.com/owner/repo/skills/pdf@v2"},
+		{"explicit ref wins over tree", "https://github.com/owner/repo/tree/main/skills/pdf@abc1234", "github.com/owner/repo/skills/pdf", "skills/pdf", "abc1234", "github.com/owner/repo/skills/pdf@abc1234"},
+		{"ssh user is not a ref", "git@github.com:owner/repo.git", "git@github.com:owner/repo.git", "", "", "git@github.com:owner/repo.git"},
+		{"ssh with ref", "git@github.com:owner/repo.git@v1", "git@github.com:owner/repo.git", "", "v1", "git@github.com:owner/repo.git@v1"},
+		{"https user is not a ref", "https://user@gitlab.com/team/repo", "https://user@gitlab.com/team/repo", "", "", "https://user@gitlab.com/team/repo"},
+		{"file url with ref", "file:///tmp/repo@main", "file:///tmp/repo", "", "main", "file:///tmp/repo@main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := ParseSource(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSource() error = %v", err)
+			}
+			if source.Raw != tt.wantRaw {
+				t.Errorf("Raw = %q, want %q", source.Raw, tt.wantRaw)
+			}
+			if source.Subdir != tt.wantSubdir {
+				t.Errorf("Subdir = %q, want %q", source.Subdir, tt.wantSubdir)
+			}
+			if source.Ref != tt.wantRef {
+				t.Errorf("Ref = %q, want %q", source.Ref, tt.wantRef)
+			}
+			if source.Spec() != tt.wantSpec {
+				t.Errorf("Spec() = %q, want %q", source.Spec(), tt.wantSpec)
+			}
+		})
+	}
+}
+
 func TestParseSource_GitSSH(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -383,6 +426,10 @@ func TestParseSource_Errors(t *testing.T) {
 			name:  "whitespace only",
 			input: "   ",
 		},
+		{
+			name:  "ref that looks like an option",
+			input: "owner/repo@--upload-pack=x",
+		},
 	}
 
 	for _, tt := range tests {
@@ -499,34 +546,173 @@ func TestSource_TrackName(t *testing.T) {
 	}
 }
 
-func TestStripGitBranchPrefix(t *testing.T) {
+func TestSplitForgePath(t *testing.T) {
 	tests := []struct {
-		name   string
-		host   string
-		subdir string
-		want   string
+		name       string
+		host       string
+		rest       string
+		wantRepo   string
+		wantSubdir string
+		wantRef    string
 	}{
-		{"empty", "bitbucket.org", "", ""},
-		{"bitbucket src/main/path", "bitbucket.org", "src/main/learn-and-update", "learn-and-update"},
-		{"bitbucket src/main/nested", "bitbucket.org", "src/develop/a/b/c", "a/b/c"},
-		{"bitbucket src/branch only", "bitbucket.org", "src/main", ""},
-		{"bitbucket trailing slash", "bitbucket.org", "src/main/skill/", "skill"},
-		{"gitlab -/tree/main/path", "gitlab.com", "-/tree/main/path/to/skill", "path/to/skill"},
-		{"gitlab -/blob/main/path", "gitlab.com", "-/blob/main/path/to/skill", "path/to/skill"},
-		{"gitlab -/tree/branch only", "gitlab.com", "-/tree/main", ""},
-		{"non-platform passthrough", "example.com", "some/path", "some/path"},
-		{"bitbucket host variant", "bitbucket.mycompany.com", "src/main/skill", "skill"},
+		{"bitbucket repo only", "bitbucket.org", "team/skills", "team/skills", "", ""},
+		{"bitbucket src/main/path", "bitbucket.org", "team/skills/src/main/learn-and-update", "team/skills", "learn-and-update", "main"},
+		{"bitbucket src/main/nested", "bitbucket.org", "team/skills/src/develop/a/b/c", "team/skills", "a/b/c", "develop"},
+		{"bitbucket src/branch only", "bitbucket.org", "team/skills/src/main", "team/skills", "", "main"},
+		{"bitbucket trailing slash", "bitbucket.org", "team/skills/src/main/skill/", "team/skills", "skill", "main"},
+		{"bitbucket host variant", "bitbucket.mycompany.com", "team/skills/src/main/skill", "team/skills", "skill", "main"},
+		{"gitlab -/tree/main/path", "gitlab.com", "user/repo/-/tree/main/path/to/skill", "user/repo", "path/to/skill", "main"},
+		{"gitlab -/blob/main/path", "gitlab.com", "user/repo/-/blob/main/path/to/skill", "user/repo", "path/to/skill", "main"},
+		{"gitlab -/tree/branch only", "gitlab.com", "user/repo/-/tree/main", "user/repo", "", "main"},
+		{"gitlab nested groups", "gitlab.com", "org/team/sub/repo", "org/team/sub/repo", "", ""},
+		{"gitlab nested groups web URL", "gitlab.com", "org/team/repo/-/tree/v2/skills/pdf?ref_type=heads", "org/team/repo", "skills/pdf", "v2"},
+		{"gitlab // subdir", "gitlab.com", "org/team/repo//skills/pdf", "org/team/repo", "skills/pdf", ""},
+		{"gitlab .git/ subdir", "gitlab.com", "org/team/repo.git/skills/pdf", "org/team/repo", "skills/pdf", ""},
+		{"unknown host with -/tree", "git.example.com", "org/team/repo/-/tree/main/skill", "org/team/repo", "skill", "main"},
+		{"gitea src/branch", "codeberg.org", "owner/repo/src/branch/main/skills/pdf", "owner/repo", "skills/pdf", "main"},
+		{"gitea src/tag", "gitea.com", "owner/repo/src/tag/v1.0.0/pdf", "owner/repo", "pdf", "v1.0.0"},
+		{"gitea src/commit", "codeberg.org", "owner/repo/src/commit/3f2a9c1/pdf", "owner/repo", "pdf", "3f2a9c1"},
+		{"gitea plain subdir", "codeberg.org", "owner/repo/skills/pdf", "owner/repo", "skills/pdf", ""},
+		{"github enterprise tree", "github.mycompany.com", "org/repo/tree/main/skills/pdf", "org/repo", "skills/pdf", "main"},
+		{"non-platform passthrough", "example.com", "owner/repo/some/path", "owner/repo", "some/path", ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := stripGitBranchPrefix(tt.host, tt.subdir)
-			if got != tt.want {
-				t.Errorf("stripGitBranchPrefix(%q, %q) = %q, want %q", tt.host, tt.subdir, got, tt.want)
+			repo, subdir, ref, err := splitForgePath(forgeFor(tt.host), tt.rest)
+			if err != nil {
+				t.Fatalf("splitForgePath(%q) error = %v", tt.rest, err)
+			}
+			if repo != tt.wantRepo || subdir != tt.wantSubdir || ref != tt.wantRef {
+				t.Errorf("splitForgePath(%q) = %q, %q, %q; want %q, %q, %q",
+					tt.rest, repo, subdir, ref, tt.wantRepo, tt.wantSubdir, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestParseSource_Forges(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantCloneURL string
+		wantSubdir   string
+		wantRef      string
+		wantName     string
+		wantRaw      string
+	}{
+		{
+			name:         "gitlab nested group shorthand",
+			input:        "gitlab.com/org/team/repo",
+			wantCloneURL: "https://gitlab.com/org/team/repo.git",
+			wantName:     "repo",
+			wantRaw:      "https://gitlab.com/org/team/repo",
+		},
+		{
+			name:         "gitlab nested group web URL",
+			input:        "https://gitlab.com/org/team/repo/-/tree/main/skills/pdf",
+			wantCloneURL: "https://gitlab.com/org/team/repo.git",
+			wantSubdir:   "skills/pdf",
+			wantRef:      "main",
+			wantName:     "pdf",
+			wantRaw:      "https://gitlab.com/org/team/repo//skills/pdf",
+		},
+		{
+			name:         "explicit @ref wins over web URL ref",
+			input:        "https://gitlab.com/org/repo/-/blob/main/skills/pdf@v2",
+			wantCloneURL: "https://gitlab.com/org/repo.git",
+			wantSubdir:   "skills/pdf",
+			wantRef:      "v2",
+			wantName:     "pdf",
+			wantRaw:      "https://gitlab.com/org/repo//skills/pdf",
+		},
+		{
+			name:         "bitbucket src ref",
+			input:        "https://bitbucket.org/team/skills/src/release/frontend",
+			wantCloneURL: "https://bitbucket.org/team/skills.git",
+			wantSubdir:   "frontend",
+			wantRef:      "release",
+			wantName:     "frontend",
+			wantRaw:      "https://bitbucket.org/team/skills//frontend",
+		},
+		{
+			name:         "codeberg src/branch",
+			input:        "https://codeberg.org/owner/skills/src/branch/main/pdf",
+			wantCloneURL: "https://codeberg.org/owner/skills.git",
+			wantSubdir:   "pdf",
+			wantRef:      "main",
+			wantName:     "pdf",
+			wantRaw:      "https://codeberg.org/owner/skills//pdf",
+		},
+		{
+			name:         "gitea repo only",
+			input:        "gitea.com/owner/skills.git",
+			wantCloneURL: "https://gitea.com/owner/skills.git",
+			wantName:     "skills",
+			wantRaw:      "https://gitea.com/owner/skills.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := ParseSource(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSource(%q) error = %v", tt.input, err)
+			}
+			if source.CloneURL != tt.wantCloneURL {
+				t.Errorf("CloneURL = %q, want %q", source.CloneURL, tt.wantCloneURL)
+			}
+			if source.Subdir != tt.wantSubdir {
+				t.Errorf("Subdir = %q, want %q", source.Subdir, tt.wantSubdir)
+			}
+			if source.Ref != tt.wantRef {
+				t.Errorf("Ref = %q, want %q", source.Ref, tt.wantRef)
+			}
+			if source.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", source.Name, tt.wantName)
+			}
+			if source.Raw != tt.wantRaw {
+				t.Errorf("Raw = %q, want %q", source.Raw, tt.wantRaw)
+			}
+
+			// Spec() must parse back to the same repository, subdir and ref
+			again, err := ParseSource(source.Spec())
+			if err != nil {
+				t.Fatalf("ParseSource(Spec()) error = %v", err)
+			}
+			if again.CloneURL != source.CloneURL || again.Subdir != source.Subdir || again.Ref != source.Ref {
+				t.Errorf("Spec() %q parses to %q %q %q", source.Spec(), again.CloneURL, again.Subdir, again.Ref)
 			}
 		})
 	}
 }
 
+func TestParseSource_DeclaredForge(t *testing.T) {
+	if err := SetForgeHosts(map[string]string{"code.internal": "gitea", "git.corp.example:8443": "gitlab"}); err != nil {
+		t.Fatal(err)
+	}
+	defer SetForgeHosts(nil)
+
+	source, err := ParseSource("https://code.internal/team/skills/src/branch/dev/pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.CloneURL != "https://code.internal/team/skills.git" || source.Subdir != "pdf" || source.Ref != "dev" {
+		t.Errorf("declared gitea: got %q %q %q", source.CloneURL, source.Subdir, source.Ref)
+	}
+
+	source, err = ParseSource("https://git.corp.example:8443/a/b/c/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.CloneURL != "https://git.corp.example:8443/a/b/c/repo.git" || source.Name != "repo" {
+		t.Errorf("declared gitlab: got %q %q", source.CloneURL, source.Name)
+	}
+
+	if err := SetForgeHosts(map[string]string{"x.example": "sourcehut"}); err == nil {
+		t.Error("SetForgeHosts should reject unknown forge types")
+	}
+}
+
 func TestParseSource_DomainShorthand(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -567,12 +753,18 @@ func TestParseSource_DomainShorthand(t *testing.T) {
 		},
 	}
 
+	// user/repo exists, so the deeper GitLab path is a subdir of it
+	fakeGitLab(t, "user/repo")
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			source, err := ParseSource(tt.input)
 			if err != nil {
 				t.Fatalf("ParseSource(%q) error = %v", tt.input, err)
 			}
+			if err := source.resolveNestedGroup(); err != nil {
+				t.Fatalf("resolveNestedGroup() error = %v", err)
+			}
 			if source.Type != tt.wantType {
 				t.Errorf("Type = %v, want %v", source.Type, tt.wantType)
 			}
@@ -589,6 +781,133 @@ func TestParseSource_DomainShorthand(t *testing.T) {
 	}
 }
 
+// stubProbe replaces the remote probe for the rest of the test
+func stubProbe(t *testing.T, probe func(url string) error) {
+	t.Helper()
+	orig := probeRemote
+	probeRemote = probe
+	t.Cleanup(func() { probeRemote = orig })
+}
+
+// fakeGitLab answers the remote probe as gitlab.com would with only the
+// given project paths
+func fakeGitLab(t *testing.T, projects ...string) {
+	t.Helper()
+	stubProbe(t, func(url string) error {
+		for _, project := range projects {
+			if url == "https://gitlab.com/"+project+".git" {
+				return nil
+			}
+		}
+		return errRemoteNotFound
+	})
+}
+
+func TestParseSource_GitLabNestedGroups(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantCloneURL string
+		wantSubdir   string
+		wantName     string
+	}{
+		{"gitlab.com/org/team/repo", "https://gitlab.com/org/team/repo.git", "", "repo"},
+		{"gitlab.com/org/team/repo.git", "https://gitlab.com/org/team/repo.git", "", "repo"},
+		{"gitlab.com/org/team/repo//skills/pdf", "https://gitlab.com/org/team/repo.git", "skills/pdf", "pdf"},
+		{"https://gitlab.com/org/team/repo/-/tree/main/skills/pdf", "https://gitlab.com/org/team/repo.git", "skills/pdf", "pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			source, err := ParseSource(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSource(%q) error = %v", tt.input, err)
+			}
+			if source.CloneURL != tt.wantCloneURL || source.Subdir != tt.wantSubdir || source.Name != tt.wantName {
+				t.Errorf("ParseSource(%q) = %q, %q, %q; want %q, %q, %q", tt.input,
+					source.CloneURL, source.Subdir, source.Name, tt.wantCloneURL, tt.wantSubdir, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestResolveNestedGroup(t *testing.T) {
+	fakeGitLab(t, "org/team/repo", "team/skills")
+
+	// An existing nested project is kept
+	source, _ := ParseSource("gitlab.com/org/team/repo")
+	if err := source.resolveNestedGroup(); err != nil {
+		t.Fatal(err)
+	}
+	if source.CloneURL != "https://gitlab.com/org/team/repo.git" || source.Subdir != "" {
+		t.Errorf("nested project: got %q %q", source.CloneURL, source.Subdir)
+	}
+
+	// A legacy project/subdir path is rewritten with "//" and keeps its ref
+	source, _ = ParseSource("gitlab.com/team/skills/frontend/pdf@v1")
+	if err := source.resolveNestedGroup(); err != nil {
+		t.Fatal(err)
+	}
+	if source.CloneURL != "https://gitlab.com/team/skills.git" || source.Subdir != "frontend/pdf" || source.Ref != "v1" {
+		t.Errorf("legacy subdir: got %q %q %q", source.CloneURL, source.Subdir, source.Ref)
+	}
+	if want := "https://gitlab.com/team/skills//frontend/pdf@v1"; source.Spec() != want {
+		t.Errorf("Spec() = %q, want %q", source.Spec(), want)
+	}
+
+	// Neither exists: the nested reading stands and cloning reports it
+	source, _ = ParseSource("gitlab.com/nobody/none/pdf")
+	if err := source.resolveNestedGroup(); err != nil {
+		t.Errorf("unknown project: error = %v", err)
+	}
+	if source.CloneURL != "https://gitlab.com/nobody/none/pdf.git" {
+		t.Errorf("unknown project: got %q", source.CloneURL)
+	}
+}
+
+func TestResolveNestedGroup_ProbeFailure(t *testing.T) {
+	// Offline or refused: neither reading can be ruled out
+	stubProbe(t, func(string) error { return errors.New("could not resolve host") })
+
+	source, _ := ParseSource("gitlab.com/team/skills/frontend")
+	err := source.resolveNestedGroup()
+	if err == nil || !strings.Contains(err.Error(), "could not resolve host") {
+		t.Fatalf("resolveNestedGroup() error = %v, want the probe error", err)
+	}
+	if source.CloneURL != "https://gitlab.com/team/skills/frontend.git" || source.Subdir != "" {
+		t.Errorf("source changed on probe failure: %q %q", source.CloneURL, source.Subdir)
+	}
+
+	// The nested project is missing but the legacy probe fails
+	stubProbe(t, func(url string) error {
+		if url == "https://gitlab.com/team/skills/frontend.git" {
+			return errRemoteNotFound
+		}
+		return errors.New("authentication required")
+	})
+	source, _ = ParseSource("gitlab.com/team/skills/frontend")
+	if err := source.resolveNestedGroup(); err == nil {
+		t.Error("resolveNestedGroup() should surface the legacy probe error")
+	}
+}
+
+func TestRemoteNotFound(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"remote: Repository not found.\nfatal: repository 'https://github.com/o/r.git/' not found", true},
+		{"fatal: repository 'https://gitlab.com/team/skills/frontend.git/' not found", true},
+		{"ERROR: The project you were looking for could not be found or you don't have permission to view it.", true},
+		{"fatal: '/tmp/x.git' does not appear to be a git repository", true},
+		{"fatal: unable to access 'https://gitlab.com/x.git/': Could not resolve host: gitlab.com", false},
+		{"fatal: could not read Username for 'https://gitlab.com': terminal prompts disabled", false},
+	}
+	for _, tt := range tests {
+		if got := remoteNotFound(tt.stderr); got != tt.want {
+			t.Errorf("remoteNotFound(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+}
+
 func TestParseSource_GitHubEnterprise(t *testing.T) {
 	tests := []struct {
 		name         string
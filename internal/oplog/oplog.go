@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"skillshare/internal/install"
@@ -30,8 +31,14 @@ type Entry struct {
 // LogDir returns the logs directory derived from a config file path.
 // For global mode: ~/.config/skillshare/logs/
 // For project mode: .skillshare/logs/
+// For a profile (profiles/<name>.yaml): profiles/<name>/logs/
 func LogDir(configPath string) string {
-	return filepath.Join(filepath.Dir(configPath), "logs")
+	dir := filepath.Dir(configPath)
+	if filepath.Base(dir) == "profiles" {
+		name := strings.TrimSuffix(filepath.Base(configPath), filepath.Ext(configPath))
+		return filepath.Join(dir, name, "logs")
+	}
+	return filepath.Join(dir, "logs")
 }
 
 // Write appends a single JSONL entry to the named log file.
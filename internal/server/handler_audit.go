@@ -53,7 +53,7 @@ func (s *Server) handleAuditAll(w http.ResponseWriter, r *http.Request) {
 	threshold := s.auditThreshold()
 
 	// Discover all skills
-	discovered, err := sync.DiscoverSourceSkills(source)
+	discovered, _, err := sync.DiscoverLayeredSkills(s.cfg.SourceLayers(), s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -75,11 +75,15 @@ func (s *Server) handleAuditAll(w http.ResponseWriter, r *http.Request) {
 		skills = append(skills, skillEntry{d.FlatName, d.SourcePath})
 	}
 
+	matcher := sync.NewIgnoreMatcher(source, s.cfg.Ignore)
 	entries, _ := os.ReadDir(source)
 	for _, e := range entries {
 		if !e.IsDir() || utils.IsHidden(e.Name()) {
 			continue
 		}
+		if ignored, _ := matcher.Match(e.Name()); ignored {
+			continue
+		}
 		p := filepath.Join(source, e.Name())
 		if !seen[p] {
 			seen[p] = true
@@ -380,7 +384,7 @@ func (s *Server) handlePutAuditRules(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "create directory: "+err.Error())
 		return
 	}
-	if err := os.WriteFile(path, []byte(body.Raw), 0644); err != nil {
+	if err := utils.WriteFileAtomic(path, []byte(body.Raw), 0644); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to write rules: "+err.Error())
 		return
 	}
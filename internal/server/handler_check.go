@@ -13,6 +13,7 @@ type repoCheckResult struct {
 	Status  string `json:"status"`
 	Behind  int    `json:"behind"`
 	Message string `json:"message,omitempty"`
+	Ref     string `json:"ref,omitempty"`
 }
 
 type skillCheckResult struct {
@@ -21,6 +22,7 @@ type skillCheckResult struct {
 	Version     string `json:"version"`
 	Status      string `json:"status"`
 	InstalledAt string `json:"installed_at,omitempty"`
+	Ref         string `json:"ref,omitempty"`
 }
 
 func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
@@ -35,7 +37,7 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 	var repoResults []repoCheckResult
 	for _, repo := range repos {
 		repoPath := filepath.Join(sourceDir, repo)
-		result := repoCheckResult{Name: repo}
+		result := repoCheckResult{Name: repo, Ref: git.PinnedRef(repoPath)}
 
 		if isDirty, _ := git.IsDirty(repoPath); isDirty {
 			result.Status = "dirty"
@@ -59,6 +61,21 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 		result := skillCheckResult{Name: skill}
 
 		meta, err := install.ReadMeta(skillPath)
+		if err == nil && meta != nil && meta.URL != "" {
+			result.Source = meta.Source
+			result.Version = meta.Digest
+			if !meta.InstalledAt.IsZero() {
+				result.InstalledAt = meta.InstalledAt.Format("2006-01-02")
+			}
+			if status, pin, err := install.CheckArchive(meta); err != nil {
+				result.Status = "error"
+			} else {
+				result.Status = status
+				result.Ref = pin
+			}
+			skillResults = append(skillResults, result)
+			continue
+		}
 		if err != nil || meta == nil || meta.RepoURL == "" {
 			result.Status = "local"
 			skillResults = append(skillResults, result)
@@ -71,6 +88,19 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 			result.InstalledAt = meta.InstalledAt.Format("2006-01-02")
 		}
 
+		if meta.Ref != "" {
+			result.Ref = meta.Ref
+			if moved, err := git.RefMoved(meta.RepoURL, meta.Ref, meta.Version); err != nil {
+				result.Status = "error"
+			} else if moved {
+				result.Status = "update_available"
+			} else {
+				result.Status = "pinned"
+			}
+			skillResults = append(skillResults, result)
+			continue
+		}
+
 		remoteHash, err := git.GetRemoteHeadHash(meta.RepoURL)
 		if err != nil {
 			result.Status = "error"
@@ -90,8 +120,16 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 		skillResults = []skillCheckResult{}
 	}
 
-	writeJSON(w, map[string]any{
+	resp := map[string]any{
 		"tracked_repos": repoResults,
 		"skills":        skillResults,
-	})
+	}
+	if lock, err := install.ReadLockfile(s.lockfilePath()); err == nil && lock != nil {
+		drift, _ := lock.Drift(sourceDir)
+		if drift == nil {
+			drift = []install.LockDrift{}
+		}
+		resp["lock_drift"] = drift
+	}
+	writeJSON(w, resp)
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -9,10 +10,15 @@ import (
 
 	"gopkg.in/yaml.v3"
 	"skillshare/internal/config"
+	"skillshare/internal/utils"
 )
 
 func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) {
 	raw, err := os.ReadFile(s.configPath())
+	if os.IsNotExist(err) && !s.IsProjectMode() && config.ActiveProfile() != "" {
+		// Profile defined only inline in config.yaml; saving creates its file
+		raw, err = nil, nil
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to read config: "+err.Error())
 		return
@@ -57,7 +63,12 @@ func (s *Server) handlePutConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := os.WriteFile(s.configPath(), []byte(body.Raw), 0644); err != nil {
+	if errs := s.validateConfigRaw([]byte(body.Raw)); len(errs) > 0 {
+		writeFieldErrors(w, errs)
+		return
+	}
+
+	if err := utils.WriteFileAtomic(s.configPath(), []byte(body.Raw), 0644); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to write config: "+err.Error())
 		return
 	}
@@ -74,6 +85,31 @@ func (s *Server) handlePutConfig(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, map[string]any{"success": true})
 }
 
+// validateConfigRaw checks an edited config file against the schema of
+// whichever file the editor shows: project config, profile overlay or config.yaml.
+func (s *Server) validateConfigRaw(raw []byte) []config.FieldError {
+	switch {
+	case s.IsProjectMode():
+		return config.ValidateProjectConfigData(raw)
+	case config.ActiveProfile() != "":
+		return config.ValidateProfileData(raw)
+	default:
+		return config.ValidateConfigData(raw)
+	}
+}
+
+// writeFieldErrors responds 400 with the first problem as the message and
+// every problem under "errors" so the editor can point at each field.
+func writeFieldErrors(w http.ResponseWriter, errs []config.FieldError) {
+	msg := "invalid config: " + errs[0].Error()
+	if len(errs) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(errs)-1)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]any{"error": msg, "errors": errs})
+}
+
 func (s *Server) handleAvailableTargets(w http.ResponseWriter, r *http.Request) {
 	var defaults map[string]config.TargetConfig
 	if s.IsProjectMode() {
@@ -213,42 +213,13 @@ func (s *Server) handlePull(w http.ResponseWriter, r *http.Request) {
 
 	// Auto-sync to targets (same logic as handleSync)
 	if !info.UpToDate {
-		globalMode := s.cfg.Mode
-		if globalMode == "" {
-			globalMode = "merge"
-		}
-
-		for name, target := range s.cfg.Targets {
-			mode := target.Mode
-			if mode == "" {
-				mode = globalMode
-			}
-
-			res := syncTargetResult{
-				Target:  name,
-				Linked:  make([]string, 0),
-				Updated: make([]string, 0),
-				Skipped: make([]string, 0),
-				Pruned:  make([]string, 0),
+		plan := ssync.NewPlan(s.cfg.Targets, s.cfg.Mode, s.cfg.SourceLayers(), false, s.cfg.Ignore...)
+		for _, o := range plan.Apply(s.journalDir(), ssync.DefaultJobs(), false, nil) {
+			applied := o.Result
+			if o.Err != nil {
+				applied = &ssync.TargetResult{Target: o.Plan.Name, Mode: o.Plan.Mode}
 			}
-
-			if mode == "merge" {
-				mergeResult, err := ssync.SyncTargetMerge(name, target, src, false, false)
-				if err == nil {
-					res.Linked = mergeResult.Linked
-					res.Updated = mergeResult.Updated
-					res.Skipped = mergeResult.Skipped
-				}
-				pruneResult, err := ssync.PruneOrphanLinks(target.Path, src, false)
-				if err == nil {
-					res.Pruned = pruneResult.Removed
-				}
-			} else {
-				ssync.SyncTarget(name, target, src, false)
-				res.Linked = []string{"(symlink mode)"}
-			}
-
-			resp.SyncResults = append(resp.SyncResults, res)
+			resp.SyncResults = append(resp.SyncResults, newSyncTargetResult(applied))
 		}
 	}
 
@@ -13,7 +13,7 @@ func (s *Server) handleHubIndex(w http.ResponseWriter, r *http.Request) {
 		sourcePath = filepath.Join(s.projectRoot, ".skillshare", "skills")
 	}
 
-	idx, err := hub.BuildIndex(sourcePath, false)
+	idx, err := hub.BuildIndex(sourcePath, false, s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -12,7 +12,8 @@ import (
 	"skillshare/internal/install"
 )
 
-// handleDiscover clones a git repo to a temp dir, discovers skills, then cleans up.
+// handleDiscover clones a git repo (or extracts an archive) to a temp dir,
+// discovers skills, then cleans up.
 // Returns whether the caller needs to present a selection UI.
 func (s *Server) handleDiscover(w http.ResponseWriter, r *http.Request) {
 	var body struct {
@@ -33,8 +34,8 @@ func (s *Server) handleDiscover(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Only git sources without a subdir can contain multiple skills
-	if !source.IsGit() || source.HasSubdir() {
+	// Only git or archive sources without a subdir can contain multiple skills
+	if !(source.IsGit() || source.IsArchive()) || source.HasSubdir() {
 		writeJSON(w, map[string]any{
 			"needsSelection": false,
 			"skills":         []any{},
@@ -42,7 +43,7 @@ func (s *Server) handleDiscover(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	discovery, err := install.DiscoverFromGit(source)
+	discovery, err := install.Discover(source)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -91,7 +92,7 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	discovery, err := install.DiscoverFromGit(source)
+	discovery, err := install.Discover(source)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "discovery failed: "+err.Error())
 		return
@@ -114,10 +115,12 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	results := make([]batchResultItem, 0, len(body.Skills))
+	var changed []string
 	installOpts := install.InstallOptions{
 		Force:          body.Force,
 		SkipAudit:      body.SkipAudit,
 		AuditThreshold: s.auditThreshold(),
+		SourceDir:      s.cfg.Source,
 	}
 	if s.IsProjectMode() {
 		installOpts.AuditProjectRoot = s.projectRoot
@@ -135,6 +138,7 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 			})
 			continue
 		}
+		changed = append(changed, s.lockName(destPath))
 		results = append(results, batchResultItem{
 			Name:     sel.Name,
 			Action:   res.Action,
@@ -193,6 +197,9 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 	if s.IsProjectMode() && installed > 0 {
 		_ = config.ReconcileProjectSkills(s.projectRoot, s.projectCfg, s.cfg.Source)
 	}
+	if installed > 0 {
+		s.refreshLockfile(changed...)
+	}
 
 	writeJSON(w, map[string]any{
 		"results": results,
@@ -270,6 +277,7 @@ func (s *Server) handleInstall(w http.ResponseWriter, r *http.Request) {
 		if s.IsProjectMode() {
 			_ = config.ReconcileProjectSkills(s.projectRoot, s.projectCfg, s.cfg.Source)
 		}
+		s.refreshLockfile(s.lockName(result.RepoPath))
 
 		args := map[string]any{
 			"source":      body.Source,
@@ -315,6 +323,7 @@ func (s *Server) handleInstall(w http.ResponseWriter, r *http.Request) {
 		Force:          body.Force,
 		SkipAudit:      body.SkipAudit,
 		AuditThreshold: s.auditThreshold(),
+		SourceDir:      s.cfg.Source,
 		AuditProjectRoot: func() string {
 			if s.IsProjectMode() {
 				return s.projectRoot
@@ -339,6 +348,7 @@ func (s *Server) handleInstall(w http.ResponseWriter, r *http.Request) {
 	if s.IsProjectMode() {
 		_ = config.ReconcileProjectSkills(s.projectRoot, s.projectCfg, s.cfg.Source)
 	}
+	s.refreshLockfile(s.lockName(destPath))
 
 	okArgs := map[string]any{
 		"source":           body.Source,
@@ -370,3 +380,26 @@ func (s *Server) installLogMode() string {
 	}
 	return "global"
 }
+
+func (s *Server) lockfilePath() string {
+	if s.IsProjectMode() {
+		return config.ProjectLockfilePath(s.projectRoot)
+	}
+	return config.LockfilePath()
+}
+
+// refreshLockfile keeps skillshare.lock in step with installs, updates and
+// uninstalls made from the UI. changed names the source-relative skills and
+// repos the request installed or updated.
+func (s *Server) refreshLockfile(changed ...string) {
+	install.RefreshLockfile(s.lockfilePath(), s.cfg.Source, changed, false) //nolint:errcheck
+}
+
+// lockName returns the lockfile key of a skill or tracked repo at path
+func (s *Server) lockName(path string) string {
+	rel, err := filepath.Rel(s.cfg.Source, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -21,7 +21,7 @@ type trackedRepoItem struct {
 
 func (s *Server) handleOverview(w http.ResponseWriter, r *http.Request) {
 	// Count skills
-	skills, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	skills, _, err := sync.DiscoverLayeredSkills(s.cfg.SourceLayers(), s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -56,6 +56,8 @@ func (s *Server) handleOverview(w http.ResponseWriter, r *http.Request) {
 	}
 	if s.IsProjectMode() {
 		resp["projectRoot"] = s.projectRoot
+	} else {
+		resp["profile"] = activeProfileName()
 	}
 
 	writeJSON(w, resp)
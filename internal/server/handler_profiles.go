@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"skillshare/internal/config"
+)
+
+type profileItem struct {
+	Name   string `json:"name"`
+	Inline bool   `json:"inline"`
+	File   string `json:"file,omitempty"`
+}
+
+func activeProfileName() string {
+	if name := config.ActiveProfile(); name != "" {
+		return name
+	}
+	return config.DefaultProfile
+}
+
+func (s *Server) handleListProfiles(w http.ResponseWriter, r *http.Request) {
+	if s.IsProjectMode() {
+		writeError(w, http.StatusBadRequest, "profiles are not available in project mode")
+		return
+	}
+
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items := make([]profileItem, 0, len(profiles)+1)
+	items = append(items, profileItem{Name: config.DefaultProfile, File: config.BaseConfigPath()})
+	for _, p := range profiles {
+		items = append(items, profileItem{Name: p.Name, Inline: p.Inline, File: p.File})
+	}
+
+	writeJSON(w, map[string]any{
+		"active":   activeProfileName(),
+		"profiles": items,
+	})
+}
+
+// handleSwitchProfile switches the dashboard to another profile and saves it
+// as the default, like 'skillshare profile use'.
+func (s *Server) handleSwitchProfile(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.IsProjectMode() {
+		writeError(w, http.StatusBadRequest, "profiles are not available in project mode")
+		return
+	}
+
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "profile name is required")
+		return
+	}
+
+	previous := activeProfileName()
+	if err := config.UseProfile(body.Name); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	config.SetProfile(body.Name)
+	if err := s.reloadConfig(); err != nil {
+		config.SetProfile(previous)
+		config.UseProfile(previous) //nolint:errcheck
+		writeError(w, http.StatusInternalServerError, "failed to load profile: "+err.Error())
+		return
+	}
+
+	s.writeOpsLog("profile", "ok", start, map[string]any{
+		"from": previous,
+		"to":   body.Name,
+	}, "")
+
+	writeJSON(w, map[string]any{"success": true, "active": activeProfileName()})
+}
@@ -74,7 +74,7 @@ func (s *Server) searchBuiltinIndex(query string, limit int) ([]search.SearchRes
 	if s.IsProjectMode() {
 		sourcePath = filepath.Join(s.projectRoot, ".skillshare", "skills")
 	}
-	idx, err := hub.BuildIndex(sourcePath, false)
+	idx, err := hub.BuildIndex(sourcePath, false, s.cfg.Ignore...)
 	if err != nil {
 		return nil, err
 	}
@@ -19,15 +19,24 @@ type skillItem struct {
 	RelPath     string `json:"relPath"`
 	SourcePath  string `json:"sourcePath"`
 	IsInRepo    bool   `json:"isInRepo"`
+	Layer       string `json:"layer,omitempty"`
 	InstalledAt string `json:"installedAt,omitempty"`
 	Source      string `json:"source,omitempty"`
 	Type        string `json:"type,omitempty"`
 	RepoURL     string `json:"repoUrl,omitempty"`
 	Version     string `json:"version,omitempty"`
+
+	// Detail only: the frontmatter placement and the targets it resolves to
+	Placement *sync.SkillPlacement `json:"placement,omitempty"`
+	Targets   []string             `json:"targets,omitempty"`
+
+	// Detail only: installed skills it requires and that require it
+	Requires   []string `json:"requires,omitempty"`
+	RequiredBy []string `json:"requiredBy,omitempty"`
 }
 
 func (s *Server) handleListSkills(w http.ResponseWriter, r *http.Request) {
-	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	discovered, _, err := sync.DiscoverLayeredSkills(s.cfg.SourceLayers(), s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -42,6 +51,9 @@ func (s *Server) handleListSkills(w http.ResponseWriter, r *http.Request) {
 			SourcePath: d.SourcePath,
 			IsInRepo:   d.IsInRepo,
 		}
+		if len(s.cfg.SourceLayers()) > 1 {
+			item.Layer = d.Layer
+		}
 
 		// Enrich with metadata if available
 		if meta, _ := install.ReadMeta(d.SourcePath); meta != nil {
@@ -62,7 +74,7 @@ func (s *Server) handleGetSkill(w http.ResponseWriter, r *http.Request) {
 	name := r.PathValue("name")
 
 	// Find the skill by flat name or base name
-	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	discovered, _, err := sync.DiscoverLayeredSkills(s.cfg.SourceLayers(), s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -80,6 +92,10 @@ func (s *Server) handleGetSkill(w http.ResponseWriter, r *http.Request) {
 			RelPath:    d.RelPath,
 			SourcePath: d.SourcePath,
 			IsInRepo:   d.IsInRepo,
+			Targets:    sync.SkillTargetNames(d, s.cfg.Targets, s.cfg.Mode),
+		}
+		if !d.Placement.IsZero() {
+			item.Placement = &d.Placement
 		}
 
 		if meta, _ := install.ReadMeta(d.SourcePath); meta != nil {
@@ -88,6 +104,10 @@ func (s *Server) handleGetSkill(w http.ResponseWriter, r *http.Request) {
 			item.Type = meta.Type
 			item.RepoURL = meta.RepoURL
 			item.Version = meta.Version
+			item.Requires = meta.Requires
+		}
+		if graph, err := install.DependencyGraph(d.Layer); err == nil {
+			item.RequiredBy = install.Dependents(graph, d.RelPath)
 		}
 
 		// Read SKILL.md content
@@ -137,7 +157,7 @@ func (s *Server) handleGetSkillFile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Find the skill
-	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	discovered, _, err := sync.DiscoverLayeredSkills(s.cfg.SourceLayers(), s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -218,6 +238,8 @@ func (s *Server) handleUninstallRepo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.refreshLockfile()
+
 	s.writeOpsLog("uninstall", "ok", start, map[string]any{
 		"name":  repoName,
 		"type":  "repo",
@@ -235,7 +257,7 @@ func (s *Server) handleUninstallSkill(w http.ResponseWriter, r *http.Request) {
 	name := r.PathValue("name")
 
 	// Find skill path
-	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source, s.cfg.Ignore...)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -258,6 +280,8 @@ func (s *Server) handleUninstallSkill(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		s.refreshLockfile()
+
 		s.writeOpsLog("uninstall", "ok", start, map[string]any{
 			"name":  baseName,
 			"type":  "skill",
@@ -2,13 +2,12 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
 	"time"
 
+	"skillshare/internal/config"
 	ssync "skillshare/internal/sync"
-	"skillshare/internal/utils"
 )
 
 type syncTargetResult struct {
@@ -32,68 +31,18 @@ func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
 		// Default to non-dry-run, non-force
 	}
 
-	globalMode := s.cfg.Mode
-	if globalMode == "" {
-		globalMode = "merge"
-	}
-
-	results := make([]syncTargetResult, 0)
-
-	for name, target := range s.cfg.Targets {
-		mode := target.Mode
-		if mode == "" {
-			mode = globalMode
-		}
-
-		res := syncTargetResult{
-			Target:  name,
-			Linked:  make([]string, 0),
-			Updated: make([]string, 0),
-			Skipped: make([]string, 0),
-			Pruned:  make([]string, 0),
-		}
-
-		if mode == "merge" {
-			mergeResult, err := ssync.SyncTargetMerge(name, target, s.cfg.Source, body.DryRun, body.Force)
-			if err != nil {
-				s.writeOpsLog("sync", "error", start, map[string]any{
-					"targets_total":  len(s.cfg.Targets),
-					"targets_failed": 1,
-					"target":         name,
-					"dry_run":        body.DryRun,
-					"force":          body.Force,
-					"scope":          "ui",
-				}, err.Error())
-				writeError(w, http.StatusInternalServerError, "sync failed for "+name+": "+err.Error())
-				return
-			}
-			res.Linked = mergeResult.Linked
-			res.Updated = mergeResult.Updated
-			res.Skipped = mergeResult.Skipped
-
-			// Prune orphans
-			pruneResult, err := ssync.PruneOrphanLinks(target.Path, s.cfg.Source, body.DryRun)
-			if err == nil {
-				res.Pruned = pruneResult.Removed
-			}
-		} else {
-			err := ssync.SyncTarget(name, target, s.cfg.Source, body.DryRun)
-			if err != nil {
-				s.writeOpsLog("sync", "error", start, map[string]any{
-					"targets_total":  len(s.cfg.Targets),
-					"targets_failed": 1,
-					"target":         name,
-					"dry_run":        body.DryRun,
-					"force":          body.Force,
-					"scope":          "ui",
-				}, err.Error())
-				writeError(w, http.StatusInternalServerError, "sync failed for "+name+": "+err.Error())
-				return
-			}
-			res.Linked = []string{"(symlink mode)"}
-		}
-
-		results = append(results, res)
+	plan := ssync.NewPlan(s.cfg.Targets, s.cfg.Mode, s.cfg.SourceLayers(), body.Force, s.cfg.Ignore...)
+	results, err := applySyncPlan(plan, s.journalDir(), body.DryRun)
+	if err != nil {
+		s.writeOpsLog("sync", "error", start, map[string]any{
+			"targets_total":  len(s.cfg.Targets),
+			"targets_failed": 1,
+			"dry_run":        body.DryRun,
+			"force":          body.Force,
+			"scope":          "ui",
+		}, err.Error())
+		writeError(w, http.StatusInternalServerError, "sync failed for "+err.Error())
+		return
 	}
 
 	// Log the sync operation
@@ -108,6 +57,43 @@ func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, map[string]any{"results": results})
 }
 
+// applySyncPlan applies the target plans concurrently, as the CLI does. If
+// any target fails, the error names the first one in plan order.
+func applySyncPlan(plan *ssync.Plan, journalDir string, dryRun bool) ([]syncTargetResult, error) {
+	results := make([]syncTargetResult, 0, len(plan.Targets))
+	for _, o := range plan.Apply(journalDir, ssync.DefaultJobs(), dryRun, nil) {
+		if o.Err != nil {
+			return nil, fmt.Errorf("%s: %w", o.Plan.Name, o.Err)
+		}
+		results = append(results, newSyncTargetResult(o.Result))
+	}
+	return results, nil
+}
+
+// newSyncTargetResult maps an applied plan onto the shared sync result shape.
+// New copies, generated files and skills already in sync count as linked so
+// the UI shows them as in sync.
+func newSyncTargetResult(applied *ssync.TargetResult) syncTargetResult {
+	res := syncTargetResult{
+		Target:  applied.Target,
+		Linked:  make([]string, 0),
+		Updated: make([]string, 0),
+		Skipped: make([]string, 0),
+		Pruned:  make([]string, 0),
+	}
+	if applied.Mode == "symlink" {
+		res.Linked = append(res.Linked, "(symlink mode)")
+		return res
+	}
+	for _, names := range [][]string{applied.Linked, applied.Copied, applied.Generated, applied.Unchanged} {
+		res.Linked = append(res.Linked, names...)
+	}
+	res.Updated = append(res.Updated, applied.Updated...)
+	res.Skipped = append(res.Skipped, applied.Skipped...)
+	res.Pruned = append(res.Pruned, applied.Pruned...)
+	return res
+}
+
 type diffItem struct {
 	Skill  string `json:"skill"`
 	Action string `json:"action"` // "link", "update", "skip", "prune", "local"
@@ -122,100 +108,67 @@ type diffTarget struct {
 func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
 	filterTarget := r.URL.Query().Get("target")
 
-	globalMode := s.cfg.Mode
-	if globalMode == "" {
-		globalMode = "merge"
+	targets := s.cfg.Targets
+	if filterTarget != "" {
+		target, ok := s.cfg.Targets[filterTarget]
+		if !ok {
+			writeJSON(w, map[string]any{"diffs": []diffTarget{}})
+			return
+		}
+		targets = map[string]config.TargetConfig{filterTarget: target}
 	}
 
-	discovered, err := ssync.DiscoverSourceSkills(s.cfg.Source)
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
-		return
+	plan := ssync.NewPlan(targets, s.cfg.Mode, s.cfg.SourceLayers(), false, s.cfg.Ignore...)
+	diffs := make([]diffTarget, 0, len(plan.Targets))
+	for _, tp := range plan.Targets {
+		if tp.Error != "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("target %s: %s", tp.Name, tp.Error))
+			return
+		}
+		diffs = append(diffs, diffTarget{Target: tp.Name, Items: diffItems(tp)})
 	}
 
-	diffs := make([]diffTarget, 0)
-
-	for name, target := range s.cfg.Targets {
-		if filterTarget != "" && filterTarget != name {
-			continue
-		}
+	writeJSON(w, map[string]any{"diffs": diffs})
+}
 
-		mode := target.Mode
-		if mode == "" {
-			mode = globalMode
+// diffItems converts a target plan into the UI's diff actions.
+func diffItems(tp ssync.TargetPlan) []diffItem {
+	items := make([]diffItem, 0, len(tp.Actions))
+	for _, a := range tp.Actions {
+		item := diffItem{Skill: a.Skill, Reason: a.Reason}
+		if item.Skill == "" {
+			item.Skill = "(entire directory)"
 		}
-
-		dt := diffTarget{Target: name, Items: make([]diffItem, 0)}
-
-		if mode != "merge" {
-			status := ssync.CheckStatus(target.Path, s.cfg.Source)
-			if status != ssync.StatusLinked {
-				dt.Items = append(dt.Items, diffItem{Skill: "(entire directory)", Action: "link", Reason: "missing"})
+		switch a.Kind {
+		case ssync.ActionCreateLink, ssync.ActionCopy, ssync.ActionGenerate, ssync.ActionLinkTarget, ssync.ActionMigrate:
+			item.Action = "link"
+			if item.Reason == "" {
+				item.Reason = "missing"
 			}
-			diffs = append(diffs, dt)
+		case ssync.ActionFixLink, ssync.ActionReplaceLocal, ssync.ActionUpdateCopy, ssync.ActionRegenerate, ssync.ActionRelink, ssync.ActionConvert:
+			item.Action = "update"
+		case ssync.ActionIndex:
+			item.Action = "update"
+			item.Skill = a.Source
+			item.Reason = "skill index: " + a.Reason
+		case ssync.ActionSkip:
+			item.Action = "skip"
+		case ssync.ActionPrune:
+			item.Action = "prune"
+		case ssync.ActionKeep, ssync.ActionLocal:
+			item.Action = "local"
+		default:
 			continue
 		}
-
-		// Merge mode: check each skill
-		for _, skill := range discovered {
-			targetSkillPath := filepath.Join(target.Path, skill.FlatName)
-			_, err := os.Lstat(targetSkillPath)
-			if err != nil {
-				if os.IsNotExist(err) {
-					dt.Items = append(dt.Items, diffItem{Skill: skill.FlatName, Action: "link", Reason: "missing"})
-				}
-				continue
-			}
-
-			if utils.IsSymlinkOrJunction(targetSkillPath) {
-				absLink, err := utils.ResolveLinkTarget(targetSkillPath)
-				if err != nil {
-					dt.Items = append(dt.Items, diffItem{Skill: skill.FlatName, Action: "update", Reason: "link target unreadable"})
-					continue
-				}
-				absSource, _ := filepath.Abs(skill.SourcePath)
-				if !utils.PathsEqual(absLink, absSource) {
-					dt.Items = append(dt.Items, diffItem{Skill: skill.FlatName, Action: "update", Reason: "symlink points elsewhere"})
-				}
-			} else {
-				dt.Items = append(dt.Items, diffItem{Skill: skill.FlatName, Action: "skip", Reason: "local copy (sync --force to replace)"})
-			}
-		}
-
-		// Check for orphans
-		entries, _ := os.ReadDir(target.Path)
-		validNames := make(map[string]bool)
-		for _, skill := range discovered {
-			validNames[skill.FlatName] = true
-		}
-		for _, entry := range entries {
-			eName := entry.Name()
-			if utils.IsHidden(eName) {
-				continue
-			}
-			if !validNames[eName] {
-				_, err := os.Lstat(filepath.Join(target.Path, eName))
-				if err != nil {
-					continue
-				}
-				entryPath := filepath.Join(target.Path, eName)
-				if utils.IsSymlinkOrJunction(entryPath) {
-					absLink, err := utils.ResolveLinkTarget(entryPath)
-					if err != nil {
-						continue
-					}
-					absSource, _ := filepath.Abs(s.cfg.Source)
-					if utils.PathHasPrefix(absLink, absSource+string(filepath.Separator)) {
-						dt.Items = append(dt.Items, diffItem{Skill: eName, Action: "prune", Reason: "orphan symlink"})
-					}
-				} else {
-					dt.Items = append(dt.Items, diffItem{Skill: eName, Action: "local", Reason: "local only"})
-				}
-			}
-		}
-
-		diffs = append(diffs, dt)
+		items = append(items, item)
 	}
+	return items
+}
 
-	writeJSON(w, map[string]any{"diffs": diffs})
+// journalDir returns the sync journal directory for the current mode.
+func (s *Server) journalDir() string {
+	if s.IsProjectMode() {
+		return ssync.ProjectJournalDir(s.projectRoot)
+	}
+	return ssync.JournalDir()
 }
@@ -11,12 +11,16 @@ import (
 )
 
 type targetItem struct {
-	Name        string `json:"name"`
-	Path        string `json:"path"`
-	Mode        string `json:"mode"`
-	Status      string `json:"status"`
-	LinkedCount int    `json:"linkedCount"`
-	LocalCount  int    `json:"localCount"`
+	Name          string   `json:"name"`
+	Path          string   `json:"path"`
+	Mode          string   `json:"mode"`
+	Format        string   `json:"format,omitempty"` // Set when the target is not skill-dir
+	Status        string   `json:"status"`
+	LinkedCount   int      `json:"linkedCount"`
+	LocalCount    int      `json:"localCount"`
+	ExpectedCount int      `json:"expectedCount"` // Source skills this target should receive after include/exclude
+	Include       []string `json:"include,omitempty"`
+	Exclude       []string `json:"exclude,omitempty"`
 }
 
 func (s *Server) handleListTargets(w http.ResponseWriter, r *http.Request) {
@@ -34,16 +38,32 @@ func (s *Server) handleListTargets(w http.ResponseWriter, r *http.Request) {
 		}
 
 		item := targetItem{
-			Name: name,
-			Path: target.Path,
-			Mode: mode,
+			Name:    name,
+			Path:    target.Path,
+			Mode:    mode,
+			Include: target.Include,
+			Exclude: target.Exclude,
+		}
+		if expected, err := ssync.DiscoverTargetSkills(target, s.cfg.SourceLayers(), s.cfg.Ignore...); err == nil {
+			item.ExpectedCount = len(expected)
 		}
 
-		if mode == "merge" {
-			status, linked, local := ssync.CheckStatusMerge(target.Path, s.cfg.Source)
+		if format := target.OutputFormat(); format != config.FormatSkillDir {
+			drift := ssync.CheckStatusGenerated(target, s.cfg.SourceLayers(), s.cfg.Ignore...)
+			item.Format = format
+			item.Status = drift.Status.String()
+			item.LinkedCount = len(drift.Synced)
+			item.LocalCount = len(drift.Local)
+		} else if mode == "merge" {
+			status, linked, local := ssync.CheckStatusMerge(target, s.cfg.SourceLayers(), s.cfg.Ignore...)
 			item.Status = status.String()
 			item.LinkedCount = linked
 			item.LocalCount = local
+		} else if mode == "copy" {
+			drift := ssync.CheckStatusCopy(target, s.cfg.SourceLayers(), s.cfg.Ignore...)
+			item.Status = drift.Status.String()
+			item.LinkedCount = len(drift.Synced)
+			item.LocalCount = len(drift.Local)
 		} else {
 			status := ssync.CheckStatus(target.Path, s.cfg.Source)
 			item.Status = status.String()
@@ -54,7 +74,7 @@ func (s *Server) handleListTargets(w http.ResponseWriter, r *http.Request) {
 
 	// Count source skills for drift detection
 	sourceSkillCount := 0
-	if discovered, err := ssync.DiscoverSourceSkills(s.cfg.Source); err == nil {
+	if discovered, _, err := ssync.DiscoverLayeredSkills(s.cfg.SourceLayers(), s.cfg.Ignore...); err == nil {
 		sourceSkillCount = len(discovered)
 	}
 
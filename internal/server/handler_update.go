@@ -39,6 +39,7 @@ func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
 
 	if body.All {
 		results := s.updateAll(body.Force)
+		s.refreshLockfile(updatedNames(results)...)
 		total := len(results)
 		failed := 0
 		for _, item := range results {
@@ -69,6 +70,7 @@ func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	result := s.updateSingle(body.Name, body.Force)
+	s.refreshLockfile(updatedNames([]updateResultItem{result})...)
 	status := "ok"
 	msg := ""
 	if result.Action == "error" {
@@ -86,6 +88,17 @@ func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, map[string]any{"results": []updateResultItem{result}})
 }
 
+// updatedNames returns the skills and repos an update changed on disk
+func updatedNames(results []updateResultItem) []string {
+	var names []string
+	for _, item := range results {
+		if item.Action == "updated" {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
 func (s *Server) updateSingle(name string, force bool) updateResultItem {
 	// Try tracked repo first (with _ prefix)
 	repoName := name
@@ -138,13 +151,7 @@ func (s *Server) updateTrackedRepo(name, repoPath string, force bool) updateResu
 		}
 	}
 
-	var info *git.UpdateInfo
-	var err error
-	if force {
-		info, err = git.ForcePull(repoPath)
-	} else {
-		info, err = git.Pull(repoPath)
-	}
+	info, err := git.UpdateTracked(repoPath, force)
 	if err != nil {
 		return updateResultItem{
 			Name:    name,
@@ -177,7 +184,7 @@ func (s *Server) updateRegularSkill(name, skillPath string) updateResultItem {
 		}
 	}
 
-	opts := install.InstallOptions{Force: true, Update: true}
+	opts := install.InstallOptions{Force: true, Update: true, SourceDir: s.cfg.Source}
 	if _, err = install.Install(source, skillPath, opts); err != nil {
 		return updateResultItem{
 			Name:    name,
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/filelock"
+	"skillshare/internal/watch"
+)
+
+// watchSession is a background watcher resyncing changed skills, the same
+// as 'skillshare sync --watch'.
+type watchSession struct {
+	cancel      context.CancelFunc
+	done        chan struct{}
+	since       time.Time
+	cycles      int
+	lastRun     time.Time
+	lastChanged []string
+	lastError   string
+}
+
+type watchStatus struct {
+	Enabled     bool     `json:"enabled"`
+	Since       string   `json:"since,omitempty"`
+	Cycles      int      `json:"cycles"`
+	LastRun     string   `json:"lastRun,omitempty"`
+	LastChanged []string `json:"lastChanged"`
+	LastError   string   `json:"lastError,omitempty"`
+}
+
+// StartWatch starts the background watcher if it is not running.
+func (s *Server) StartWatch() error {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	if s.watcher != nil {
+		return nil
+	}
+
+	dirs := s.sourceLayers()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &watchSession{cancel: cancel, done: make(chan struct{}), since: time.Now()}
+	w := &watch.Watcher{
+		Dirs: dirs,
+		Scan: func() (watch.Snapshot, error) {
+			s.mu.Lock()
+			sources, ignore := s.cfg.SourceLayers(), s.cfg.Ignore
+			s.mu.Unlock()
+			return watch.Scan(sources, ignore...)
+		},
+	}
+	if _, err := w.Scan(); err != nil {
+		cancel()
+		return fmt.Errorf("cannot watch sources: %w", err)
+	}
+
+	go func() {
+		defer close(session.done)
+		w.Run(ctx, func(changed []string) error { //nolint:errcheck
+			return s.watchCycle(session, changed)
+		})
+	}()
+	s.watcher = session
+	return nil
+}
+
+// StopWatch stops the background watcher and waits for a running cycle.
+func (s *Server) StopWatch() {
+	s.watchMu.Lock()
+	session := s.watcher
+	s.watcher = nil
+	s.watchMu.Unlock()
+	if session != nil {
+		session.cancel()
+		<-session.done
+	}
+}
+
+// withWatchRestart restarts a running watcher after h when h changed the
+// source directories, e.g. by switching profiles or editing the config. A
+// watcher carried over would keep watching the old directories and report
+// every skill as changed on its first scan. This runs after h has released
+// the write lock, which a running cycle may be waiting for.
+func (s *Server) withWatchRestart(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		before := s.sourceLayers()
+		h(w, r)
+		if slices.Equal(before, s.sourceLayers()) {
+			return
+		}
+
+		s.watchMu.Lock()
+		running := s.watcher != nil
+		s.watchMu.Unlock()
+		if !running {
+			return
+		}
+		start := time.Now()
+		s.StopWatch()
+		if err := s.StartWatch(); err != nil {
+			s.writeOpsLog("sync", "error", start, map[string]any{
+				"watch": true,
+				"scope": "ui",
+			}, "watcher stopped: "+err.Error())
+		}
+	}
+}
+
+func (s *Server) sourceLayers() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg.SourceLayers()
+}
+
+// watchCycle resyncs one burst of changes under the write lock and logs it.
+func (s *Server) watchCycle(session *watchSession, changed []string) error {
+	start := time.Now()
+	lock, err := filelock.Acquire(config.LockPath(), "skillshare ui: watch", filelock.TimeoutFromEnv(), nil)
+	if err != nil {
+		s.watchMu.Lock()
+		session.lastError = err.Error()
+		s.watchMu.Unlock()
+		return err
+	}
+	defer lock.Release()
+
+	s.mu.Lock()
+	targets := make(map[string]config.TargetConfig, len(s.cfg.Targets))
+	for name, target := range s.cfg.Targets {
+		if target.Mode == "" {
+			target.Mode = s.cfg.Mode
+		}
+		if target.Mode == "" {
+			target.Mode = "merge"
+		}
+		targets[name] = target
+	}
+	results := watch.Resync(targets, s.cfg.SourceLayers(), changed, s.cfg.Ignore, s.journalDir())
+	s.mu.Unlock()
+
+	failed := 0
+	var cycleErr string
+	for _, res := range results {
+		if res.Err != nil {
+			failed++
+			cycleErr = res.Target + ": " + res.Err.Error()
+		}
+	}
+	status := "ok"
+	if failed > 0 {
+		status = "error"
+	}
+	s.writeOpsLog("sync", status, start, map[string]any{
+		"watch":          true,
+		"changed":        changed,
+		"targets_total":  len(targets),
+		"targets_failed": failed,
+		"scope":          "ui",
+	}, cycleErr)
+
+	s.watchMu.Lock()
+	session.cycles++
+	session.lastRun = start
+	session.lastChanged = changed
+	session.lastError = cycleErr
+	s.watchMu.Unlock()
+	return nil
+}
+
+func (s *Server) watchStatus() watchStatus {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	st := watchStatus{LastChanged: []string{}}
+	if s.watcher == nil {
+		return st
+	}
+	st.Enabled = true
+	st.Since = s.watcher.since.Format(time.RFC3339)
+	st.Cycles = s.watcher.cycles
+	if !s.watcher.lastRun.IsZero() {
+		st.LastRun = s.watcher.lastRun.Format(time.RFC3339)
+	}
+	if s.watcher.lastChanged != nil {
+		st.LastChanged = s.watcher.lastChanged
+	}
+	st.LastError = s.watcher.lastError
+	return st
+}
+
+func (s *Server) handleGetWatch(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.watchStatus())
+}
+
+func (s *Server) handlePutWatch(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if body.Enabled {
+		if err := s.StartWatch(); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	} else {
+		s.StopWatch()
+	}
+	writeJSON(w, s.watchStatus())
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"skillshare/internal/config"
+)
+
+func writeSkill(t *testing.T, dir, name string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+		t.Fatalf("failed to create skill dir: %v", err)
+	}
+	content := "---\nname: " + name + "\n---\n# " + name + "\n"
+	if err := os.WriteFile(filepath.Join(dir, name, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+}
+
+// waitWatchCycle rewrites skill name under dir until the watcher session
+// completes its first cycle, and returns the status. The skill is rewritten
+// because a new watcher may take its first snapshot after the first write.
+func waitWatchCycle(t *testing.T, s *Server, dir, name string) watchStatus {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	var nextWrite time.Time
+	for time.Now().Before(deadline) {
+		if st := s.watchStatus(); st.Cycles > 0 {
+			return st
+		}
+		if time.Now().After(nextWrite) {
+			writeSkill(t, dir, name)
+			nextWrite = time.Now().Add(500 * time.Millisecond)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("watcher did not report a change to %s", name)
+	return watchStatus{}
+}
+
+func TestSwitchProfile_RestartsWatcherOnNewSource(t *testing.T) {
+	tmp := t.TempDir()
+	baseSource := filepath.Join(tmp, "skills")
+	workSource := filepath.Join(tmp, "work-skills")
+	writeSkill(t, baseSource, "alpha")
+	writeSkill(t, workSource, "beta")
+
+	cfgPath := filepath.Join(tmp, "config", "config.yaml")
+	t.Setenv("SKILLSHARE_CONFIG", cfgPath)
+	t.Setenv("SKILLSHARE_PROFILE", "")
+	t.Cleanup(func() { config.SetProfile("") })
+	if err := os.MkdirAll(filepath.Dir(cfgPath), 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	raw := "source: " + baseSource + "\ntargets: {}\nprofiles:\n  work:\n    source: " + workSource + "\n"
+	if err := os.WriteFile(cfgPath, []byte(raw), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	s := New(&config.Config{
+		Source:  baseSource,
+		Targets: map[string]config.TargetConfig{},
+	}, "127.0.0.1:0")
+	if err := s.StartWatch(); err != nil {
+		t.Fatalf("StartWatch: %v", err)
+	}
+	t.Cleanup(s.StopWatch)
+	if st := waitWatchCycle(t, s, baseSource, "alpha"); !slices.Equal(st.LastChanged, []string{"alpha"}) {
+		t.Fatalf("expected alpha to change, got %v", st.LastChanged)
+	}
+
+	payload, _ := json.Marshal(map[string]string{"name": "work"})
+	req := httptest.NewRequest(http.MethodPut, "/api/profiles/active", bytes.NewReader(payload))
+	rr := httptest.NewRecorder()
+	s.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d, body=%s", rr.Code, rr.Body.String())
+	}
+
+	// A watcher carried over from the old source would report every skill
+	// of both profiles as changed.
+	st := waitWatchCycle(t, s, workSource, "gamma")
+	if !st.Enabled {
+		t.Fatal("expected watcher to keep running after the profile switch")
+	}
+	if !slices.Equal(st.LastChanged, []string{"gamma"}) {
+		t.Fatalf("expected only gamma to change, got %v", st.LastChanged)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 
 	"skillshare/internal/config"
+	"skillshare/internal/filelock"
+	"skillshare/internal/install"
 )
 
 // Server holds the HTTP server state
@@ -13,11 +15,15 @@ type Server struct {
 	cfg  *config.Config
 	addr string
 	mux  *http.ServeMux
-	mu   sync.Mutex // protects write operations (sync, install, uninstall, config)
+	mu   sync.Mutex // protects write operations (sync, install, uninstall, config); see also withWriteLock
 
 	// Project mode fields (empty/nil for global mode)
 	projectRoot string
 	projectCfg  *config.ProjectConfig
+
+	// Background watcher started by 'ui --watch' or PUT /api/watch
+	watchMu sync.Mutex
+	watcher *watchSession
 }
 
 // New creates a new Server for global mode
@@ -72,18 +78,29 @@ func (s *Server) reloadConfig() error {
 		if err != nil {
 			return err
 		}
+		forges, err := config.ProjectForges(pcfg.Forges)
+		if err != nil {
+			return err
+		}
+		if err := install.SetForgeHosts(forges); err != nil {
+			return err
+		}
 		s.projectCfg = pcfg
 		targets, err := config.ResolveProjectTargets(s.projectRoot, pcfg)
 		if err != nil {
 			return err
 		}
 		s.cfg.Targets = targets
+		s.cfg.Ignore = pcfg.Ignore
 		return nil
 	}
 	newCfg, err := config.Load()
 	if err != nil {
 		return err
 	}
+	if err := install.SetForgeHosts(newCfg.Forges); err != nil {
+		return err
+	}
 	s.cfg = newCfg
 	return nil
 }
@@ -106,65 +123,67 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("GET /api/skills", s.handleListSkills)
 	s.mux.HandleFunc("GET /api/skills/{name}", s.handleGetSkill)
 	s.mux.HandleFunc("GET /api/skills/{name}/files/{filepath...}", s.handleGetSkillFile)
-	s.mux.HandleFunc("DELETE /api/skills/{name}", s.handleUninstallSkill)
+	s.mux.HandleFunc("DELETE /api/skills/{name}", s.withWriteLock(s.handleUninstallSkill))
 
 	// Targets
 	s.mux.HandleFunc("GET /api/targets", s.handleListTargets)
-	s.mux.HandleFunc("POST /api/targets", s.handleAddTarget)
-	s.mux.HandleFunc("DELETE /api/targets/{name}", s.handleRemoveTarget)
+	s.mux.HandleFunc("POST /api/targets", s.withWriteLock(s.handleAddTarget))
+	s.mux.HandleFunc("DELETE /api/targets/{name}", s.withWriteLock(s.handleRemoveTarget))
 
 	// Sync
-	s.mux.HandleFunc("POST /api/sync", s.handleSync)
+	s.mux.HandleFunc("POST /api/sync", s.withWriteLock(s.handleSync))
 	s.mux.HandleFunc("GET /api/diff", s.handleDiff)
+	s.mux.HandleFunc("GET /api/watch", s.handleGetWatch)
+	s.mux.HandleFunc("PUT /api/watch", s.handlePutWatch)
 
 	// Collect
 	s.mux.HandleFunc("GET /api/collect/scan", s.handleCollectScan)
-	s.mux.HandleFunc("POST /api/collect", s.handleCollect)
+	s.mux.HandleFunc("POST /api/collect", s.withWriteLock(s.handleCollect))
 
 	// Hub
 	s.mux.HandleFunc("GET /api/hub/index", s.handleHubIndex)
 	s.mux.HandleFunc("GET /api/hub/saved", s.handleGetHubSaved)
-	s.mux.HandleFunc("PUT /api/hub/saved", s.handlePutHubSaved)
-	s.mux.HandleFunc("POST /api/hub/saved", s.handlePostHubSaved)
-	s.mux.HandleFunc("DELETE /api/hub/saved/{label}", s.handleDeleteHubSaved)
+	s.mux.HandleFunc("PUT /api/hub/saved", s.withWriteLock(s.handlePutHubSaved))
+	s.mux.HandleFunc("POST /api/hub/saved", s.withWriteLock(s.handlePostHubSaved))
+	s.mux.HandleFunc("DELETE /api/hub/saved/{label}", s.withWriteLock(s.handleDeleteHubSaved))
 
 	// Search & Install
 	s.mux.HandleFunc("GET /api/search", s.handleSearch)
 	s.mux.HandleFunc("POST /api/discover", s.handleDiscover)
-	s.mux.HandleFunc("POST /api/install", s.handleInstall)
-	s.mux.HandleFunc("POST /api/install/batch", s.handleInstallBatch)
+	s.mux.HandleFunc("POST /api/install", s.withWriteLock(s.handleInstall))
+	s.mux.HandleFunc("POST /api/install/batch", s.withWriteLock(s.handleInstallBatch))
 
 	// Update & Check
-	s.mux.HandleFunc("POST /api/update", s.handleUpdate)
+	s.mux.HandleFunc("POST /api/update", s.withWriteLock(s.handleUpdate))
 	s.mux.HandleFunc("GET /api/check", s.handleCheck)
 
 	// Repo uninstall
-	s.mux.HandleFunc("DELETE /api/repos/{name}", s.handleUninstallRepo)
+	s.mux.HandleFunc("DELETE /api/repos/{name}", s.withWriteLock(s.handleUninstallRepo))
 
 	// Version check
 	s.mux.HandleFunc("GET /api/version", s.handleVersionCheck)
 
 	// Backups
 	s.mux.HandleFunc("GET /api/backups", s.handleListBackups)
-	s.mux.HandleFunc("POST /api/backup", s.handleCreateBackup)
-	s.mux.HandleFunc("POST /api/backup/cleanup", s.handleCleanupBackups)
-	s.mux.HandleFunc("POST /api/restore", s.handleRestore)
+	s.mux.HandleFunc("POST /api/backup", s.withWriteLock(s.handleCreateBackup))
+	s.mux.HandleFunc("POST /api/backup/cleanup", s.withWriteLock(s.handleCleanupBackups))
+	s.mux.HandleFunc("POST /api/restore", s.withWriteLock(s.handleRestore))
 
 	// Trash
 	s.mux.HandleFunc("GET /api/trash", s.handleListTrash)
-	s.mux.HandleFunc("POST /api/trash/{name}/restore", s.handleRestoreTrash)
-	s.mux.HandleFunc("DELETE /api/trash/{name}", s.handleDeleteTrash)
-	s.mux.HandleFunc("POST /api/trash/empty", s.handleEmptyTrash)
+	s.mux.HandleFunc("POST /api/trash/{name}/restore", s.withWriteLock(s.handleRestoreTrash))
+	s.mux.HandleFunc("DELETE /api/trash/{name}", s.withWriteLock(s.handleDeleteTrash))
+	s.mux.HandleFunc("POST /api/trash/empty", s.withWriteLock(s.handleEmptyTrash))
 
 	// Git
 	s.mux.HandleFunc("GET /api/git/status", s.handleGitStatus)
-	s.mux.HandleFunc("POST /api/push", s.handlePush)
-	s.mux.HandleFunc("POST /api/pull", s.handlePull)
+	s.mux.HandleFunc("POST /api/push", s.withWriteLock(s.handlePush))
+	s.mux.HandleFunc("POST /api/pull", s.withWriteLock(s.handlePull))
 
 	// Audit
 	s.mux.HandleFunc("GET /api/audit/rules", s.handleGetAuditRules)
-	s.mux.HandleFunc("PUT /api/audit/rules", s.handlePutAuditRules)
-	s.mux.HandleFunc("POST /api/audit/rules", s.handleInitAuditRules)
+	s.mux.HandleFunc("PUT /api/audit/rules", s.withWriteLock(s.handlePutAuditRules))
+	s.mux.HandleFunc("POST /api/audit/rules", s.withWriteLock(s.handleInitAuditRules))
 	s.mux.HandleFunc("GET /api/audit", s.handleAuditAll)
 	s.mux.HandleFunc("GET /api/audit/{name}", s.handleAuditSkill)
 
@@ -174,13 +193,31 @@ func (s *Server) registerRoutes() {
 
 	// Config
 	s.mux.HandleFunc("GET /api/config", s.handleGetConfig)
-	s.mux.HandleFunc("PUT /api/config", s.handlePutConfig)
+	s.mux.HandleFunc("PUT /api/config", s.withWatchRestart(s.withWriteLock(s.handlePutConfig)))
 	s.mux.HandleFunc("GET /api/config/available-targets", s.handleAvailableTargets)
 
+	// Profiles
+	s.mux.HandleFunc("GET /api/profiles", s.handleListProfiles)
+	s.mux.HandleFunc("PUT /api/profiles/active", s.withWatchRestart(s.withWriteLock(s.handleSwitchProfile)))
+
 	// SPA fallback — must be last
 	s.mux.Handle("/", spaHandler())
 }
 
+// withWriteLock runs h under the cross-process write lock, so dashboard
+// actions and CLI commands in a terminal don't interleave their writes.
+func (s *Server) withWriteLock(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lock, err := filelock.Acquire(config.LockPath(), "skillshare ui: "+r.Method+" "+r.URL.Path, filelock.TimeoutFromEnv(), nil)
+		if err != nil {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		defer lock.Release()
+		h(w, r)
+	}
+}
+
 // handleHealth responds with a simple OK
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, map[string]string{"status": "ok"})
@@ -0,0 +1,248 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"skillshare/internal/utils"
+)
+
+// TargetResult is what applying a target plan did, or would do in a dry run.
+type TargetResult struct {
+	Target    string
+	Mode      string
+	Linked    []string // Links created
+	Copied    []string // Copies created
+	Generated []string // Rendered files created (non-skill-dir formats)
+	Index     string   // Index file whose skill list was rewritten
+	Updated   []string // Links fixed, local copies replaced, copies refreshed
+	Unchanged []string // Already in sync
+	Skipped   []string // Not synced because the target has local content
+	Pruned    []string // Removed because the skill left source
+	Warnings  []string
+}
+
+// ApplyTarget carries out a target plan in order and stops at the first
+// action that fails; prune failures are reported as warnings. Every change is
+// recorded in a journal under journalDir and rolled back if the target cannot
+// be synced completely. With dryRun nothing is written and the result shows
+// what would happen.
+func ApplyTarget(tp TargetPlan, journalDir string, dryRun bool) (*TargetResult, error) {
+	if tp.Error != "" {
+		return nil, errors.New(tp.Error)
+	}
+
+	res := &TargetResult{Target: tp.Name, Mode: tp.Mode, Unchanged: tp.InSync}
+	if dryRun {
+		for _, a := range tp.Actions {
+			if err := applyAction(tp, a, nil, nil, res); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+
+	j, err := BeginJournal(journalDir, tp.Name, tp.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyJournaled(tp, j, res); err != nil {
+		if rbErr := j.Rollback(); rbErr != nil {
+			return nil, fmt.Errorf("%w; rollback failed, run 'skillshare doctor': %v", err, rbErr)
+		}
+		return nil, fmt.Errorf("%w (changes rolled back)", err)
+	}
+	if err := j.Commit(); err != nil {
+		res.Warnings = append(res.Warnings, err.Error())
+	}
+	return res, nil
+}
+
+func applyJournaled(tp TargetPlan, j *Journal, res *TargetResult) error {
+	var manifest *Manifest
+	if tp.Mode != "symlink" {
+		if len(tp.Actions) > 0 && tp.Actions[0].Kind == ActionConvert {
+			if err := j.Remove(tp.Path); err != nil {
+				return fmt.Errorf("failed to remove symlink for %s conversion: %w", tp.Mode, err)
+			}
+		}
+		if err := j.Mkdir(tp.Path); err != nil {
+			return fmt.Errorf("failed to create target directory: %w", err)
+		}
+		if tp.Format == "" {
+			var err error
+			if manifest, err = ReadManifest(tp.Path); err != nil {
+				return err
+			}
+			manifest.Mode = tp.Mode
+		}
+	}
+
+	for _, a := range tp.Actions {
+		if err := applyAction(tp, a, j, manifest, res); err != nil {
+			return err
+		}
+	}
+
+	if manifest != nil {
+		manifestPath := filepath.Join(tp.Path, ManifestFile)
+		if err := j.Remove(manifestPath); err != nil {
+			return err
+		}
+		if err := j.Created(manifestPath); err != nil {
+			return err
+		}
+		if err := WriteManifest(tp.Path, manifest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyAction applies one action through the journal, or only records the
+// result when j is nil (dry run).
+func applyAction(tp TargetPlan, a Action, j *Journal, manifest *Manifest, res *TargetResult) error {
+	path := filepath.Join(tp.Path, a.Skill)
+	dryRun := j == nil
+
+	switch a.Kind {
+	case ActionConvert, ActionLocal:
+		// Conversion runs before the loop; local entries are left alone
+
+	case ActionCreateLink:
+		if !dryRun {
+			if err := j.Created(path); err != nil {
+				return err
+			}
+			if err := createLink(path, linkText(path, a.Source, tp.LinkStyle)); err != nil {
+				return fmt.Errorf("failed to create link for %s: %w", a.Skill, err)
+			}
+			recordLink(manifest, a)
+		}
+		res.Linked = append(res.Linked, a.Skill)
+
+	case ActionFixLink, ActionReplaceLocal:
+		if !dryRun {
+			if err := j.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", a.Skill, err)
+			}
+			if err := j.Created(path); err != nil {
+				return err
+			}
+			if err := createLink(path, linkText(path, a.Source, tp.LinkStyle)); err != nil {
+				return fmt.Errorf("failed to create link for %s: %w", a.Skill, err)
+			}
+			recordLink(manifest, a)
+		}
+		res.Updated = append(res.Updated, a.Skill)
+
+	case ActionCopy, ActionUpdateCopy:
+		if !dryRun {
+			if err := j.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove old copy %s: %w", a.Skill, err)
+			}
+			if err := j.Created(path); err != nil {
+				return err
+			}
+			if err := copySkillDirectory(a.Source, path); err != nil {
+				return fmt.Errorf("failed to copy %s: %w", a.Skill, err)
+			}
+			manifest.Skills[a.Skill] = ManifestEntry{Source: a.RelPath, Mode: EntryCopy, Hash: a.Hash, Synced: time.Now()}
+		}
+		if a.Kind == ActionCopy {
+			res.Copied = append(res.Copied, a.Skill)
+		} else {
+			res.Updated = append(res.Updated, a.Skill)
+		}
+
+	case ActionGenerate, ActionRegenerate:
+		if !dryRun {
+			if a.content == "" {
+				return fmt.Errorf("no rendered content for %s; plan the sync again", a.Skill)
+			}
+			if err := j.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove old %s: %w", a.Skill, err)
+			}
+			if err := j.Created(path); err != nil {
+				return err
+			}
+			if err := utils.WriteFileAtomic(path, []byte(a.content), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", a.Skill, err)
+			}
+		}
+		if a.Kind == ActionGenerate {
+			res.Generated = append(res.Generated, a.Skill)
+		} else {
+			res.Updated = append(res.Updated, a.Skill)
+		}
+
+	case ActionIndex:
+		if !dryRun {
+			if a.content == "" {
+				return fmt.Errorf("no rendered index for %s; plan the sync again", a.Source)
+			}
+			if err := writeIndex(j, a.Source, a.content); err != nil {
+				return fmt.Errorf("failed to update index %s: %w", a.Source, err)
+			}
+		}
+		res.Index = a.Source
+
+	case ActionLinkTarget, ActionMigrate, ActionRelink:
+		if !dryRun {
+			switch a.Kind {
+			case ActionMigrate:
+				if err := j.Migrate(tp.Path, a.Source); err != nil {
+					return err
+				}
+			case ActionRelink:
+				if err := j.Remove(tp.Path); err != nil {
+					return err
+				}
+			}
+			if err := j.Created(tp.Path); err != nil {
+				return err
+			}
+			if err := CreateSymlink(tp.Path, linkText(tp.Path, a.Source, tp.LinkStyle)); err != nil {
+				return err
+			}
+		}
+
+	case ActionPrune:
+		if !dryRun {
+			if err := j.Remove(path); err != nil {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("%s: failed to remove: %v", a.Skill, err))
+				return nil
+			}
+			if manifest != nil {
+				delete(manifest.Skills, a.Skill)
+			}
+		}
+		res.Pruned = append(res.Pruned, a.Skill)
+	case ActionForget:
+		if manifest != nil {
+			delete(manifest.Skills, a.Skill)
+		}
+	case ActionAdopt:
+		recordLink(manifest, a)
+
+	case ActionSkip:
+		res.Skipped = append(res.Skipped, a.Skill)
+
+	case ActionKeep:
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s: %s, kept", a.Skill, a.Reason))
+
+	default:
+		return fmt.Errorf("unknown plan action %q", a.Kind)
+	}
+	return nil
+}
+
+// recordLink records a link in the manifest; targets in a non-skill-dir
+// format keep none.
+func recordLink(manifest *Manifest, a Action) {
+	if manifest != nil {
+		manifest.Skills[a.Skill] = ManifestEntry{Source: a.RelPath, Mode: EntryLink, Synced: time.Now()}
+	}
+}
@@ -0,0 +1,132 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// copySkillDirectory copies a skill directory, leaving out any .git directory
+// so tracked repos don't drag their history into every target.
+func copySkillDirectory(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		relPath, _ := filepath.Rel(src, path)
+		dstPath := filepath.Join(dst, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(dstPath, info.Mode())
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		return copyFile(path, dstPath)
+	})
+}
+
+// CopyDrift describes how a copy-mode target differs from source.
+type CopyDrift struct {
+	Status   TargetStatus
+	Synced   []string // Copies identical to source
+	Missing  []string // Source skills with no copy in target
+	Outdated []string // Copies older than the source (sync will update)
+	Modified []string // Copies edited in target (sync --force would overwrite)
+	Orphaned []string // Managed copies whose source skill is gone
+	Local    []string // Unmanaged entries in target
+}
+
+// HasDrift reports whether a sync would change anything in the target.
+func (d *CopyDrift) HasDrift() bool {
+	return len(d.Missing) > 0 || len(d.Outdated) > 0 || len(d.Modified) > 0 || len(d.Orphaned) > 0
+}
+
+// CheckStatusCopy compares a copy-mode target against source using the manifest.
+func CheckStatusCopy(target config.TargetConfig, sources []string, ignore ...string) *CopyDrift {
+	drift := &CopyDrift{}
+	targetPath := target.Path
+
+	info, err := os.Lstat(targetPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			drift.Status = StatusNotExist
+		} else {
+			drift.Status = StatusUnknown
+		}
+		return drift
+	}
+
+	if utils.IsSymlinkOrJunction(targetPath) {
+		absLink, err := utils.ResolveLinkTarget(targetPath)
+		if err == nil && linkToSourceRoot(absLink, sources) {
+			drift.Status = StatusLinked
+		} else {
+			drift.Status = StatusConflict
+		}
+		return drift
+	}
+
+	if !info.IsDir() {
+		drift.Status = StatusUnknown
+		return drift
+	}
+
+	manifest, err := ReadManifest(targetPath)
+	if err != nil {
+		drift.Status = StatusUnknown
+		return drift
+	}
+
+	discoveredSkills, _ := DiscoverTargetSkills(target, sources, ignore...)
+	validFlatNames := make(map[string]bool)
+	for _, skill := range discoveredSkills {
+		validFlatNames[skill.FlatName] = true
+
+		entry, managed := manifest.Skills[skill.FlatName]
+		targetSkillPath := filepath.Join(targetPath, skill.FlatName)
+		if _, err := os.Lstat(targetSkillPath); err != nil || !managed {
+			drift.Missing = append(drift.Missing, skill.FlatName)
+			continue
+		}
+
+		dstHash, _ := utils.HashDir(targetSkillPath)
+		if dstHash != entry.Hash {
+			drift.Modified = append(drift.Modified, skill.FlatName)
+			continue
+		}
+		srcHash, _ := utils.HashDir(skill.SourcePath)
+		if srcHash != entry.Hash {
+			drift.Outdated = append(drift.Outdated, skill.FlatName)
+			continue
+		}
+		drift.Synced = append(drift.Synced, skill.FlatName)
+	}
+
+	entries, _ := os.ReadDir(targetPath)
+	for _, e := range entries {
+		name := e.Name()
+		if utils.IsHidden(name) || validFlatNames[name] {
+			continue
+		}
+		if _, managed := manifest.Skills[name]; managed {
+			drift.Orphaned = append(drift.Orphaned, name)
+		} else {
+			drift.Local = append(drift.Local, name)
+		}
+	}
+
+	if len(manifest.Skills) > 0 {
+		drift.Status = StatusCopied
+	} else {
+		drift.Status = StatusHasFiles
+	}
+	return drift
+}
@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"skillshare/internal/config"
+)
+
+// skillFilter selects which discovered skills a target receives.
+type skillFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// newSkillFilter compiles a target's include/exclude glob patterns.
+func newSkillFilter(include, exclude []string) (*skillFilter, error) {
+	f := &skillFilter{}
+	var err error
+	if f.include, err = compileFilterPatterns(include); err != nil {
+		return nil, fmt.Errorf("invalid include pattern: %w", err)
+	}
+	if f.exclude, err = compileFilterPatterns(exclude); err != nil {
+		return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+	return f, nil
+}
+
+func compileFilterPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		p = strings.Trim(strings.TrimSpace(p), "/")
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile("^" + globToRegexp(p) + "$")
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", p, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// allows reports whether the skill passes the filter. An empty include list
+// selects every skill; exclude always wins over include.
+func (f *skillFilter) allows(skill DiscoveredSkill) bool {
+	if len(f.include) > 0 && !matchSkill(f.include, skill) {
+		return false
+	}
+	return !matchSkill(f.exclude, skill)
+}
+
+// matchSkill matches patterns against the flat name, the rel path and each
+// parent directory of the rel path, so "frontend" selects "frontend/ui".
+func matchSkill(patterns []*regexp.Regexp, skill DiscoveredSkill) bool {
+	candidates := []string{skill.FlatName}
+	parts := strings.Split(skill.RelPath, "/")
+	for i := range parts {
+		candidates = append(candidates, strings.Join(parts[:i+1], "/"))
+	}
+
+	for _, re := range patterns {
+		for _, c := range candidates {
+			if re.MatchString(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateTargetFilters checks that include/exclude patterns compile.
+func ValidateTargetFilters(include, exclude []string) error {
+	_, err := newSkillFilter(include, exclude)
+	return err
+}
+
+// FilterSkills returns the skills selected by include/exclude glob patterns.
+// Patterns match a skill's rel path (or any parent directory) or its flat name.
+func FilterSkills(skills []DiscoveredSkill, include, exclude []string) ([]DiscoveredSkill, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return skills, nil
+	}
+
+	f, err := newSkillFilter(include, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]DiscoveredSkill, 0, len(skills))
+	for _, skill := range skills {
+		if f.allows(skill) {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterTargetSkills returns the skills a target receives: those its
+// include/exclude filters select whose frontmatter placement allows it.
+func FilterTargetSkills(skills []DiscoveredSkill, target config.TargetConfig) ([]DiscoveredSkill, error) {
+	skills, err := FilterSkills(skills, target.Include, target.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := skills[:0:0]
+	for _, skill := range skills {
+		if skill.Placement.Allows(target.Name()) {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered, nil
+}
+
+// DiscoverTargetSkills discovers the winning skills across the source layers,
+// applies the target's include/exclude filters and the skills' frontmatter
+// placement, and names them by the target's
+// naming policy, returning the skills that target should receive. FlatName
+// is the entry name in the target.
+func DiscoverTargetSkills(target config.TargetConfig, sources []string, ignore ...string) ([]DiscoveredSkill, error) {
+	skills, _, err := DiscoverLayeredSkills(sources, ignore...)
+	if err != nil {
+		return nil, err
+	}
+	skills, err = FilterTargetSkills(skills, target)
+	if err != nil {
+		return nil, err
+	}
+	skills, _, err = NameSkills(skills, target.NamingPolicy(), sources)
+	return skills, err
+}
@@ -0,0 +1,53 @@
+package sync
+
+import "testing"
+
+func TestFilterSkills(t *testing.T) {
+	skills := []DiscoveredSkill{
+		{RelPath: "frontend/react", FlatName: "frontend__react"},
+		{RelPath: "frontend/css", FlatName: "frontend__css"},
+		{RelPath: "claude-hooks", FlatName: "claude-hooks"},
+		{RelPath: "git-commit", FlatName: "git-commit"},
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{"no filters", nil, nil, []string{"frontend__react", "frontend__css", "claude-hooks", "git-commit"}},
+		{"include parent dir", []string{"frontend"}, nil, []string{"frontend__react", "frontend__css"}},
+		{"include rel path glob", []string{"frontend/*"}, nil, []string{"frontend__react", "frontend__css"}},
+		{"include flat name glob", []string{"frontend__c*"}, nil, []string{"frontend__css"}},
+		{"exclude glob", nil, []string{"claude-*"}, []string{"frontend__react", "frontend__css", "git-commit"}},
+		{"exclude wins over include", []string{"frontend/**"}, []string{"frontend/css"}, []string{"frontend__react"}},
+		{"include nothing matches", []string{"backend"}, nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterSkills(skills, tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("FilterSkills: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("got %d skills, want %d (%v)", len(got), len(tt.expected), got)
+			}
+			for i, s := range got {
+				if s.FlatName != tt.expected[i] {
+					t.Errorf("skill[%d] = %q, want %q", i, s.FlatName, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTargetFilters_InvalidPattern(t *testing.T) {
+	if err := ValidateTargetFilters([]string{"[z-a]"}, nil); err == nil {
+		t.Error("expected error for invalid include pattern")
+	}
+	if err := ValidateTargetFilters(nil, []string{"ok-*"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
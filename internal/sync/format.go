@@ -0,0 +1,387 @@
+package sync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// skillDoc is a skill's SKILL.md split into the frontmatter fields formats
+// use and the markdown body.
+type skillDoc struct {
+	Skill       DiscoveredSkill
+	Name        string // Frontmatter name, else the target name
+	Description string
+	Globs       []string // Cursor: file globs the rule applies to
+	AlwaysApply bool
+	Body        string
+}
+
+// generatedFile is one file a format writes into a target. Skill and Source
+// are empty for files built from every skill.
+type generatedFile struct {
+	Name    string
+	Skill   string
+	Source  string
+	Content string // Without the generated header
+}
+
+// formatAdapter renders skills into the files of one target format.
+type formatAdapter interface {
+	render(docs []skillDoc) []generatedFile
+	entryName(flatName string) string // File in the target that holds the skill
+}
+
+// formatAdapters maps each format other than skill-dir to its adapter.
+var formatAdapters = map[string]formatAdapter{
+	"cursor-mdc":      perSkillFormat{ext: ".mdc", renderDoc: renderCursorMDC},
+	"single-markdown": perSkillFormat{ext: ".md", renderDoc: renderMarkdown},
+	"agents-md":       agentsMDFormat{},
+}
+
+// perSkillFormat writes one file per skill, named after the skill.
+type perSkillFormat struct {
+	ext       string
+	renderDoc func(skillDoc) string
+}
+
+func (f perSkillFormat) entryName(flatName string) string { return flatName + f.ext }
+
+func (f perSkillFormat) render(docs []skillDoc) []generatedFile {
+	files := make([]generatedFile, 0, len(docs))
+	for _, d := range docs {
+		files = append(files, generatedFile{
+			Name:    f.entryName(d.Skill.FlatName),
+			Skill:   d.Skill.FlatName,
+			Source:  d.Skill.SourcePath,
+			Content: f.renderDoc(d),
+		})
+	}
+	return files
+}
+
+// renderCursorMDC writes a Cursor rule: MDC frontmatter, then the body.
+func renderCursorMDC(d skillDoc) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "description: %s\n", oneLine(d.Description))
+	fmt.Fprintf(&b, "globs: %s\n", strings.Join(d.Globs, ","))
+	fmt.Fprintf(&b, "alwaysApply: %t\n", d.AlwaysApply)
+	b.WriteString("---\n")
+	b.WriteString(d.Body)
+	return b.String()
+}
+
+// renderMarkdown writes a plain rule file: title (the body's own, else the
+// skill name), description, body.
+func renderMarkdown(d skillDoc) string {
+	var b strings.Builder
+	title := "# " + d.Name
+	if strings.HasPrefix(d.Body, "# ") {
+		title, _, _ = strings.Cut(d.Body, "\n")
+	}
+	fmt.Fprintf(&b, "%s\n\n", title)
+	if d.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", oneLine(d.Description))
+	}
+	b.WriteString(stripTitle(d.Body))
+	return b.String()
+}
+
+// agentsMDFormat writes every skill as a section of one AGENTS.md.
+type agentsMDFormat struct{}
+
+func (agentsMDFormat) entryName(string) string { return "AGENTS.md" }
+
+func (agentsMDFormat) render(docs []skillDoc) []generatedFile {
+	var b strings.Builder
+	b.WriteString("# Skills\n")
+	for _, d := range docs {
+		fmt.Fprintf(&b, "\n## %s\n\n", d.Name)
+		if d.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", oneLine(d.Description))
+		}
+		b.WriteString(demoteHeadings(stripTitle(d.Body), 2))
+	}
+	return []generatedFile{{Name: "AGENTS.md", Content: b.String()}}
+}
+
+// stripTitle drops a leading "# Title" line; the section heading replaces it.
+func stripTitle(body string) string {
+	if !strings.HasPrefix(body, "# ") {
+		return body
+	}
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		return strings.TrimLeft(body[i+1:], "\n")
+	}
+	return ""
+}
+
+// demoteHeadings adds levels to every markdown heading outside code fences.
+func demoteHeadings(body string, levels int) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && strings.HasPrefix(line, "#") {
+			lines[i] = strings.Repeat("#", levels) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// readSkillDoc parses a skill's SKILL.md.
+func readSkillDoc(skill DiscoveredSkill) (skillDoc, error) {
+	data, err := os.ReadFile(filepath.Join(skill.SourcePath, "SKILL.md"))
+	if err != nil {
+		return skillDoc{}, err
+	}
+	d := skillDoc{Skill: skill, Name: skill.FlatName}
+	var meta struct {
+		Name        string    `yaml:"name"`
+		Description string    `yaml:"description"`
+		Globs       yaml.Node `yaml:"globs"`
+		AlwaysApply bool      `yaml:"alwaysApply"`
+	}
+	body, err := utils.DecodeFrontmatter(string(data), &meta)
+	if err != nil {
+		return skillDoc{}, fmt.Errorf("%s: invalid frontmatter: %w", skill.RelPath, err)
+	}
+	if meta.Name != "" {
+		d.Name = meta.Name
+	}
+	d.Description, d.AlwaysApply = meta.Description, meta.AlwaysApply
+	switch meta.Globs.Kind {
+	case yaml.ScalarNode:
+		d.Globs = []string{meta.Globs.Value}
+	case yaml.SequenceNode:
+		meta.Globs.Decode(&d.Globs)
+	}
+	d.Body = strings.TrimSpace(body) + "\n"
+	return d, nil
+}
+
+// renderTarget renders the target's skills in format.
+func renderTarget(format string, skills []DiscoveredSkill) ([]generatedFile, error) {
+	adapter, ok := formatAdapters[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown target format %q", format)
+	}
+	docs := make([]skillDoc, 0, len(skills))
+	for _, skill := range skills {
+		d, err := readSkillDoc(skill)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", skill.RelPath, err)
+		}
+		docs = append(docs, d)
+	}
+	return adapter.render(docs), nil
+}
+
+var generatedHeaderRe = regexp.MustCompile(`(?m)^<!-- Generated by skillshare.*skillshare:hash=(sha256:[0-9a-f]+) -->\n`)
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// stampGenerated adds the generated header, after any frontmatter so tools
+// still find it on the first line.
+func stampGenerated(content, from string) string {
+	header := fmt.Sprintf("<!-- Generated by skillshare from %s; edit the skill in source instead. skillshare:hash=%s -->\n",
+		from, contentHash(content))
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		if end := strings.Index(rest, "\n---\n"); end >= 0 {
+			split := len("---\n") + end + len("\n---\n")
+			return content[:split] + header + content[split:]
+		}
+	}
+	return header + content
+}
+
+// readGenerated reports whether the file at path carries a generated header
+// and, if so, whether its content still matches the recorded hash.
+func readGenerated(path string) (generated, edited bool, data []byte, err error) {
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return false, false, nil, err
+	}
+	loc := generatedHeaderRe.FindSubmatchIndex(data)
+	if loc == nil {
+		return false, false, data, nil
+	}
+	hash := string(data[loc[2]:loc[3]])
+	content := append(bytes.Clone(data[:loc[0]]), data[loc[1]:]...)
+	return true, contentHash(string(content)) != hash, data, nil
+}
+
+// planGenerate plans a target that receives rendered files instead of skill
+// folders. Files skillshare wrote carry a header with the hash of their
+// content, so hand-written and hand-edited files are never overwritten
+// without --force.
+func planGenerate(tp *TargetPlan, target config.TargetConfig, sources []string, force bool, ctx *planContext) error {
+	if tp.Mode == "symlink" {
+		return fmt.Errorf("format %s cannot be used in symlink mode (use merge or copy)", tp.Format)
+	}
+	converting := planConvert(tp, sources)
+
+	skills, shadowed, err := ctx.targetSkills(target, sources)
+	if err != nil {
+		return err
+	}
+	planNameShadowed(tp, shadowed)
+
+	files, err := renderTarget(tp.Format, skills)
+	if err != nil {
+		return err
+	}
+
+	valid := make(map[string]bool, len(files))
+	for _, f := range files {
+		valid[f.Name] = true
+		from := f.Skill
+		if from == "" {
+			from = fmt.Sprintf("%d skills", len(skills))
+		}
+		stamped := stampGenerated(f.Content, from)
+		a := Action{Kind: ActionGenerate, Skill: f.Name, Source: f.Source, Hash: contentHash(f.Content), content: stamped}
+		if converting {
+			tp.Actions = append(tp.Actions, a)
+			continue
+		}
+
+		path := filepath.Join(tp.Path, f.Name)
+		info, err := os.Lstat(path)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return fmt.Errorf("failed to check %s: %w", f.Name, err)
+		case !info.Mode().IsRegular():
+			a.Kind, a.Reason = ActionSkip, "not a file (sync --force to replace)"
+			if force {
+				a.Kind, a.Reason = ActionRegenerate, "replace "+info.Mode().Type().String()
+			}
+		default:
+			generated, edited, data, err := readGenerated(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			switch {
+			case string(data) == stamped:
+				tp.InSync = append(tp.InSync, f.Name)
+				continue
+			case !generated && !force:
+				a.Kind, a.Reason = ActionSkip, "hand-written file with the same name (sync --force to overwrite)"
+			case !generated:
+				a.Kind, a.Reason = ActionRegenerate, "overwrite hand-written file"
+			case edited && !force:
+				a.Kind, a.Reason = ActionSkip, "edited in target (sync --force to overwrite)"
+			case edited:
+				a.Kind, a.Reason = ActionRegenerate, "overwrite edits in target"
+			default:
+				a.Kind, a.Reason = ActionRegenerate, "source changed"
+			}
+		}
+		tp.Actions = append(tp.Actions, a)
+	}
+
+	if converting {
+		return nil
+	}
+	return planPruneGenerated(tp, sources, valid)
+}
+
+// planPruneGenerated removes generated files no skill renders any more and
+// links left from skill-dir sync. Hand-written files are not touched.
+func planPruneGenerated(tp *TargetPlan, sources []string, valid map[string]bool) error {
+	entries, err := os.ReadDir(tp.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read target directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if utils.IsHidden(name) || valid[name] {
+			continue
+		}
+		path := filepath.Join(tp.Path, name)
+		switch {
+		case utils.IsSymlinkOrJunction(path):
+			if absLink, err := utils.ResolveLinkTarget(path); err == nil && linkIntoSources(absLink, sources) {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionPrune, Skill: name, Reason: "skill link replaced by " + tp.Format})
+			}
+		case entry.Type().IsRegular():
+			generated, edited, _, err := readGenerated(path)
+			switch {
+			case err != nil || !generated:
+			case edited:
+				tp.Actions = append(tp.Actions, Action{Kind: ActionKeep, Skill: name, Reason: "skill removed but generated file has local edits"})
+			default:
+				tp.Actions = append(tp.Actions, Action{Kind: ActionPrune, Skill: name, Reason: "orphan generated file"})
+			}
+		}
+	}
+	return nil
+}
+
+// CheckStatusGenerated compares a target with a non-skill-dir format against
+// source. Synced, Missing, Outdated, Modified and Orphaned list file names.
+func CheckStatusGenerated(target config.TargetConfig, sources []string, ignore ...string) *CopyDrift {
+	drift := &CopyDrift{}
+	if _, err := os.Lstat(target.Path); err != nil {
+		drift.Status = StatusNotExist
+		if !os.IsNotExist(err) {
+			drift.Status = StatusUnknown
+		}
+		return drift
+	}
+	if utils.IsSymlinkOrJunction(target.Path) {
+		drift.Status = StatusLinked
+		return drift
+	}
+
+	if target.Mode == "" {
+		target.Mode = "merge"
+	}
+	tp := PlanTarget("", target, sources, false, ignore...)
+	if tp.Error != "" {
+		drift.Status = StatusUnknown
+		return drift
+	}
+	drift.Status = StatusGenerated
+	drift.Synced = tp.InSync
+	for _, a := range tp.Actions {
+		switch {
+		case a.Kind == ActionGenerate:
+			drift.Missing = append(drift.Missing, a.Skill)
+		case a.Kind == ActionRegenerate:
+			drift.Outdated = append(drift.Outdated, a.Skill)
+		case a.Kind == ActionSkip && strings.HasPrefix(a.Reason, "edited"):
+			drift.Modified = append(drift.Modified, a.Skill)
+		case a.Kind == ActionSkip && (strings.HasPrefix(a.Reason, "hand-written") || strings.HasPrefix(a.Reason, "not a file")):
+			drift.Local = append(drift.Local, a.Skill)
+		case a.Kind == ActionPrune:
+			drift.Orphaned = append(drift.Orphaned, a.Skill)
+		}
+	}
+	return drift
+}
@@ -0,0 +1,125 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func TestRenderTarget(t *testing.T) {
+	source := t.TempDir()
+	dir := filepath.Join(source, "_team", "lint")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := "---\nname: lint\ndescription: |\n  Run the linter\n  before commits\nglobs: [\"*.go\", \"*.ts\"]\n---\n# Lint\n\n## Steps\n\n```sh\n# not a heading\n```\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644); err != nil {
+		t.Fatal(err)
+	}
+	skills := []DiscoveredSkill{{SourcePath: dir, RelPath: "_team/lint", FlatName: "_team__lint"}}
+
+	tests := []struct {
+		format string
+		file   string
+		want   string
+	}{
+		{"cursor-mdc", "_team__lint.mdc",
+			"---\ndescription: Run the linter before commits\nglobs: *.go,*.ts\nalwaysApply: false\n---\n# Lint\n\n## Steps\n\n```sh\n# not a heading\n```\n"},
+		{"single-markdown", "_team__lint.md",
+			"# Lint\n\nRun the linter before commits\n\n## Steps\n\n```sh\n# not a heading\n```\n"},
+		{"agents-md", "AGENTS.md",
+			"# Skills\n\n## lint\n\nRun the linter before commits\n\n#### Steps\n\n```sh\n# not a heading\n```\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			files, err := renderTarget(tt.format, skills)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 1 || files[0].Name != tt.file {
+				t.Fatalf("files = %+v, want one %s", files, tt.file)
+			}
+			if files[0].Content != tt.want {
+				t.Errorf("content =\n%s\nwant\n%s", files[0].Content, tt.want)
+			}
+		})
+	}
+}
+
+func TestStampGenerated_KeepsFrontmatterFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rule.mdc")
+	stamped := stampGenerated("---\nglobs: \n---\nbody\n", "rule")
+	if !strings.HasPrefix(stamped, "---\nglobs: \n---\n<!-- Generated by skillshare from rule;") {
+		t.Fatalf("header not after frontmatter:\n%s", stamped)
+	}
+
+	os.WriteFile(path, []byte(stamped), 0644)
+	if generated, edited, _, _ := readGenerated(path); !generated || edited {
+		t.Errorf("fresh file: generated=%v edited=%v", generated, edited)
+	}
+	os.WriteFile(path, []byte(stamped+"more\n"), 0644)
+	if generated, edited, _, _ := readGenerated(path); !generated || !edited {
+		t.Errorf("edited file: generated=%v edited=%v", generated, edited)
+	}
+	os.WriteFile(path, []byte("body\n"), 0644)
+	if generated, _, _, _ := readGenerated(path); generated {
+		t.Error("hand-written file reported as generated")
+	}
+}
+
+func TestPlanTarget_Format(t *testing.T) {
+	source := t.TempDir()
+	target := t.TempDir()
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		writeLayerSkill(t, source, name)
+	}
+	cfg := config.TargetConfig{Path: target, Mode: "merge", Format: "single-markdown"}
+
+	tp := PlanTarget("augment", cfg, []string{source}, false)
+	if tp.Format != "single-markdown" {
+		t.Fatalf("plan format = %q", tp.Format)
+	}
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := PlanTarget("augment", cfg, []string{source}, false); len(got.Changes()) != 0 || len(got.InSync) != 3 {
+		t.Fatalf("second plan: actions %v, in sync %v", got.Actions, got.InSync)
+	}
+
+	// Edit one output, replace another by hand, change a source, remove a skill
+	appendFile(t, filepath.Join(target, "alpha.md"), "local note\n")
+	os.WriteFile(filepath.Join(target, "beta.md"), []byte("# my beta\n"), 0644)
+	appendFile(t, filepath.Join(source, "gamma", "SKILL.md"), "\nmore\n")
+	writeLayerSkill(t, source, "delta")
+	ApplyTarget(PlanTarget("augment", cfg, []string{source}, false), t.TempDir(), false)
+	os.RemoveAll(filepath.Join(source, "delta"))
+	os.WriteFile(filepath.Join(target, "notes.md"), []byte("mine\n"), 0644)
+
+	got := planKinds(PlanTarget("augment", cfg, []string{source}, false))
+	want := map[string]ActionKind{"alpha.md": ActionSkip, "beta.md": ActionSkip, "delta.md": ActionPrune}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+
+	got = planKinds(PlanTarget("augment", cfg, []string{source}, true))
+	want = map[string]ActionKind{"alpha.md": ActionRegenerate, "beta.md": ActionRegenerate, "delta.md": ActionPrune}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("forced actions = %v, want %v", got, want)
+	}
+}
+
+func appendFile(t *testing.T, path, text string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(text); err != nil {
+		t.Fatal(err)
+	}
+}
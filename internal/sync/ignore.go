@@ -0,0 +1,213 @@
+package sync
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile is the per-directory ignore file honored during discovery.
+// Patterns inside it are relative to the directory that contains it.
+const IgnoreFile = ".skillshareignore"
+
+// ignoreRule is a single compiled gitignore-style pattern.
+type ignoreRule struct {
+	pattern  string // Original pattern text, for reporting
+	origin   string // "config" or path of the .skillshareignore file
+	base     string // Directory the pattern is relative to ("" for source root)
+	negate   bool
+	anchored bool // Pattern contains a slash and matches from base, not by name
+	re       *regexp.Regexp
+}
+
+// IgnoreMatcher decides whether a path in the source directory is excluded by
+// the config `ignore:` patterns or by a .skillshareignore file.
+type IgnoreMatcher struct {
+	sourcePath string
+	rules      []ignoreRule            // Config-level rules
+	fileRules  map[string][]ignoreRule // .skillshareignore rules keyed by rel dir
+}
+
+// IgnoredSkill describes a skill excluded from discovery and the rule that excluded it.
+type IgnoredSkill struct {
+	SourcePath string
+	RelPath    string
+	FlatName   string
+	Pattern    string // The matching pattern, e.g. "drafts/"
+	Origin     string // "config" or the .skillshareignore path that declared it
+	Dir        string // The ignored directory (RelPath or one of its parents)
+}
+
+// NewIgnoreMatcher builds a matcher for sourcePath from config-level patterns.
+// .skillshareignore files are loaded lazily as paths are matched.
+func NewIgnoreMatcher(sourcePath string, patterns []string) *IgnoreMatcher {
+	m := &IgnoreMatcher{
+		sourcePath: sourcePath,
+		fileRules:  make(map[string][]ignoreRule),
+	}
+	for _, p := range patterns {
+		if rule, ok := compileIgnoreRule(p, "", "config"); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+	return m
+}
+
+// Match reports whether relPath (slash-separated, relative to source) is ignored.
+// Like gitignore, a path inside an ignored directory cannot be re-included.
+// On a match, the deciding rule and the ignored directory are returned.
+func (m *IgnoreMatcher) Match(relPath string) (bool, IgnoredSkill) {
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	if relPath == "" || relPath == "." {
+		return false, IgnoredSkill{}
+	}
+
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		prefix := strings.Join(parts[:i+1], "/")
+		if ignored, rule := m.matchOne(prefix); ignored {
+			return true, IgnoredSkill{Pattern: rule.pattern, Origin: rule.origin, Dir: prefix}
+		}
+	}
+	return false, IgnoredSkill{}
+}
+
+// matchOne evaluates all applicable rules against a single path; the last match wins.
+func (m *IgnoreMatcher) matchOne(relPath string) (bool, ignoreRule) {
+	rules := append([]ignoreRule{}, m.rules...)
+
+	// Rules from .skillshareignore files in ancestor directories, root first
+	dir := path.Dir(relPath)
+	var ancestors []string
+	for {
+		if dir == "." {
+			ancestors = append(ancestors, "")
+			break
+		}
+		ancestors = append(ancestors, dir)
+		dir = path.Dir(dir)
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		rules = append(rules, m.loadFileRules(ancestors[i])...)
+	}
+
+	ignored := false
+	var decided ignoreRule
+	for _, rule := range rules {
+		if rule.matches(relPath) {
+			ignored = !rule.negate
+			decided = rule
+		}
+	}
+	return ignored, decided
+}
+
+// loadFileRules reads and caches the .skillshareignore in relDir.
+func (m *IgnoreMatcher) loadFileRules(relDir string) []ignoreRule {
+	if rules, ok := m.fileRules[relDir]; ok {
+		return rules
+	}
+
+	var rules []ignoreRule
+	file := filepath.Join(m.sourcePath, filepath.FromSlash(relDir), IgnoreFile)
+	if f, err := os.Open(file); err == nil {
+		origin := path.Join(relDir, IgnoreFile)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if rule, ok := compileIgnoreRule(scanner.Text(), relDir, origin); ok {
+				rules = append(rules, rule)
+			}
+		}
+		f.Close()
+	}
+
+	m.fileRules[relDir] = rules
+	return rules
+}
+
+func (r ignoreRule) matches(relPath string) bool {
+	if r.base != "" {
+		if !strings.HasPrefix(relPath, r.base+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, r.base+"/")
+	}
+	if r.anchored {
+		return r.re.MatchString(relPath)
+	}
+	return r.re.MatchString(path.Base(relPath))
+}
+
+// compileIgnoreRule parses one gitignore-style line. Blank lines and
+// comments yield ok=false.
+func compileIgnoreRule(line, base, origin string) (ignoreRule, bool) {
+	pattern := strings.TrimSpace(line)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{pattern: pattern, origin: origin, base: base}
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = pattern[1:]
+	}
+	// Only directories are ever matched, so a trailing slash changes nothing
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.Contains(pattern, "/") {
+		rule.anchored = true
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	if pattern == "" {
+		return ignoreRule{}, false
+	}
+
+	re, err := regexp.Compile("^" + globToRegexp(pattern) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.re = re
+	return rule, true
+}
+
+// globToRegexp translates a gitignore glob (*, ?, **, [...]) into a regexp body.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" matches zero or more directories
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreMatcher_ConfigPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{"no patterns", nil, "my-skill", false},
+		{"exact name", []string{"drafts"}, "drafts", true},
+		{"name matches at any depth", []string{"drafts"}, "team/drafts", true},
+		{"child of ignored dir", []string{"drafts/"}, "drafts/idea", true},
+		{"star glob", []string{"wip-*"}, "wip-parser", true},
+		{"star glob no match", []string{"wip-*"}, "parser", false},
+		{"question mark", []string{"v?"}, "v1", true},
+		{"char class", []string{"exp[0-9]"}, "exp3", true},
+		{"anchored pattern", []string{"/vendor"}, "vendor", true},
+		{"anchored pattern nested", []string{"/vendor"}, "team/vendor", false},
+		{"path pattern", []string{"team/experimental"}, "team/experimental/ui", true},
+		{"double star", []string{"**/tmp"}, "a/b/tmp", true},
+		{"double star root", []string{"**/tmp"}, "tmp", true},
+		{"negation re-includes", []string{"wip-*", "!wip-keep"}, "wip-keep", false},
+		{"comment ignored", []string{"# drafts"}, "drafts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewIgnoreMatcher(t.TempDir(), tt.patterns)
+			got, _ := m.Match(tt.path)
+			if got != tt.expected {
+				t.Errorf("Match(%q) with %v = %v, want %v", tt.path, tt.patterns, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIgnoreMatcher_SkillshareIgnoreFile(t *testing.T) {
+	source := t.TempDir()
+	os.MkdirAll(filepath.Join(source, "team"), 0755)
+	os.WriteFile(filepath.Join(source, "team", IgnoreFile), []byte("# team drafts\nscratch\n"), 0644)
+
+	m := NewIgnoreMatcher(source, nil)
+
+	ignored, info := m.Match("team/scratch")
+	if !ignored {
+		t.Fatal("team/scratch should be ignored by team/.skillshareignore")
+	}
+	if info.Origin != "team/"+IgnoreFile || info.Pattern != "scratch" {
+		t.Errorf("got origin %q pattern %q", info.Origin, info.Pattern)
+	}
+
+	if ignored, _ := m.Match("scratch"); ignored {
+		t.Error("patterns in team/.skillshareignore should not apply outside team/")
+	}
+}
+
+func TestIgnoreMatcher_NegationCannotReincludeChild(t *testing.T) {
+	m := NewIgnoreMatcher(t.TempDir(), []string{"drafts/", "!drafts/keep"})
+
+	ignored, info := m.Match("drafts/keep")
+	if !ignored {
+		t.Error("a path inside an ignored directory should stay ignored")
+	}
+	if info.Dir != "drafts" {
+		t.Errorf("Dir = %q, want %q", info.Dir, "drafts")
+	}
+}
+
+func TestDiscoverSourceSkills_Ignore(t *testing.T) {
+	source := t.TempDir()
+	for _, dir := range []string{"keep", "drafts/idea", "team/ui", "team/scratch"} {
+		os.MkdirAll(filepath.Join(source, dir), 0755)
+		os.WriteFile(filepath.Join(source, dir, "SKILL.md"), []byte("# "+dir), 0644)
+	}
+	os.WriteFile(filepath.Join(source, "team", IgnoreFile), []byte("scratch\n"), 0644)
+
+	skills, err := DiscoverSourceSkills(source, "drafts/")
+	if err != nil {
+		t.Fatalf("DiscoverSourceSkills: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, s := range skills {
+		got[s.RelPath] = true
+	}
+	if len(got) != 2 || !got["keep"] || !got["team/ui"] {
+		t.Errorf("discovered %v, want keep and team/ui", got)
+	}
+
+	ignored, err := DiscoverIgnoredSkills(source, "drafts/")
+	if err != nil {
+		t.Fatalf("DiscoverIgnoredSkills: %v", err)
+	}
+	if len(ignored) != 2 {
+		t.Fatalf("ignored %d skills, want 2", len(ignored))
+	}
+}
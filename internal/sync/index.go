@@ -0,0 +1,140 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+const (
+	indexMarkerStart = "<!-- BEGIN SKILLSHARE INDEX - DO NOT EDIT -->"
+	indexMarkerEnd   = "<!-- END SKILLSHARE INDEX -->"
+)
+
+// renderIndex builds the managed block listing skills as the target holds
+// them, with paths relative to the index file.
+func renderIndex(indexPath, targetPath, format string, skills []DiscoveredSkill) (string, error) {
+	rel := func(path string) string {
+		if r, err := filepath.Rel(filepath.Dir(indexPath), path); err == nil {
+			path = r
+		}
+		return filepath.ToSlash(path)
+	}
+
+	var b strings.Builder
+	b.WriteString(indexMarkerStart + "\n")
+	b.WriteString("## Skills\n\n")
+	fmt.Fprintf(&b, "Skills synced to `%s`. Read a skill's file before following it.\n\n", rel(targetPath))
+	if len(skills) == 0 {
+		b.WriteString("_No skills synced._\n")
+	}
+	for _, skill := range skills {
+		d, err := readSkillDoc(skill)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", skill.RelPath, err)
+		}
+		entry := filepath.Join(skill.FlatName, "SKILL.md")
+		if adapter, ok := formatAdapters[format]; ok {
+			entry = adapter.entryName(skill.FlatName)
+		}
+		fmt.Fprintf(&b, "- **%s**", d.Name)
+		if desc := oneLine(d.Description); desc != "" {
+			fmt.Fprintf(&b, " — %s", desc)
+		}
+		fmt.Fprintf(&b, " (`%s`)\n", rel(filepath.Join(targetPath, entry)))
+	}
+	b.WriteString(indexMarkerEnd + "\n")
+	return b.String(), nil
+}
+
+// findIndexBlock returns the byte range of the managed block in content, or
+// -1, -1 when there is none. A stray start marker is not part of the block.
+func findIndexBlock(content string) (int, int) {
+	for from := 0; ; {
+		i := strings.Index(content[from:], indexMarkerEnd)
+		if i < 0 {
+			return -1, -1
+		}
+		end := from + i
+		from = end + len(indexMarkerEnd)
+		start := strings.LastIndex(content[:end], indexMarkerStart)
+		if start < 0 {
+			continue
+		}
+		if from < len(content) && content[from] == '\n' {
+			from++
+		}
+		return start, from
+	}
+}
+
+// spliceIndex replaces the managed block in content with block, or appends
+// block after a blank line. Content outside the markers is kept as is.
+func spliceIndex(content, block string) string {
+	if start, end := findIndexBlock(content); start >= 0 {
+		return content[:start] + block + content[end:]
+	}
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return block
+	}
+	return content + "\n\n" + block
+}
+
+// planIndex adds an index action when the target's index file does not hold
+// the current block.
+func planIndex(tp *TargetPlan, target config.TargetConfig, sources []string, ctx *planContext) error {
+	indexPath := target.IndexPath()
+	if indexPath == "" {
+		return nil
+	}
+	tp.Index = indexPath
+
+	skills, _, err := ctx.targetSkills(target, sources)
+	if err != nil {
+		return err
+	}
+	block, err := renderIndex(indexPath, tp.Path, target.OutputFormat(), skills)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(indexPath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("failed to read index %s: %w", indexPath, err)
+	default:
+		if start, end := findIndexBlock(string(data)); start >= 0 && string(data[start:end]) == block {
+			return nil
+		}
+	}
+	reason := "skills changed"
+	if start, _ := findIndexBlock(string(data)); start < 0 {
+		reason = "add index block"
+	}
+	tp.Actions = append(tp.Actions, Action{Kind: ActionIndex, Source: indexPath, Hash: contentHash(block), Reason: reason, content: block})
+	return nil
+}
+
+// writeIndex splices block into the index file through the journal.
+func writeIndex(j *Journal, indexPath, block string) error {
+	data, err := os.ReadFile(indexPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+	if err := j.Mkdir(filepath.Dir(indexPath)); err != nil {
+		return err
+	}
+	if err := j.Remove(indexPath); err != nil {
+		return err
+	}
+	if err := j.Created(indexPath); err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(indexPath, []byte(spliceIndex(string(data), block)), 0644)
+}
@@ -0,0 +1,92 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func TestSpliceIndex(t *testing.T) {
+	block := indexMarkerStart + "\n- a\n" + indexMarkerEnd + "\n"
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty file", "", block},
+		{"appends after user content", "# Notes\n", "# Notes\n\n" + block},
+		{"replaces block in place", "# Top\n\n" + indexMarkerStart + "\n- old\n" + indexMarkerEnd + "\n\nBottom\n",
+			"# Top\n\n" + block + "\nBottom\n"},
+		{"stray end marker is skipped", indexMarkerEnd + "\n" + block, indexMarkerEnd + "\n" + block},
+		{"unterminated block is left alone", "x\n" + indexMarkerStart + "\n", "x\n" + indexMarkerStart + "\n\n" + block},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spliceIndex(tt.content, block)
+			if got != tt.want {
+				t.Errorf("spliceIndex() =\n%q\nwant\n%q", got, tt.want)
+			}
+			if again := spliceIndex(got, block); again != got {
+				t.Errorf("not idempotent:\n%q", again)
+			}
+		})
+	}
+}
+
+func TestPlanTarget_Index(t *testing.T) {
+	source := t.TempDir()
+	root := t.TempDir()
+	target := filepath.Join(root, ".claude", "skills")
+	index := filepath.Join(root, "AGENTS.md")
+	if err := os.MkdirAll(filepath.Join(source, "lint"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(source, "lint", "SKILL.md"), []byte("---\nname: lint\ndescription: Run the linter\n---\n# Lint\n"), 0644)
+	os.WriteFile(index, []byte("# Project\n\nKeep this.\n"), 0644)
+
+	cfg := config.TargetConfig{Path: target, Mode: "merge", Index: index}
+	tp := PlanTarget("claude", cfg, []string{source}, false)
+	if kinds := planKinds(tp); kinds[""] != ActionIndex {
+		t.Fatalf("actions = %v, want an index action", tp.Actions)
+	}
+	res, err := ApplyTarget(tp, t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Index != index {
+		t.Errorf("result index = %q", res.Index)
+	}
+
+	data, _ := os.ReadFile(index)
+	content := string(data)
+	if !strings.HasPrefix(content, "# Project\n\nKeep this.\n\n"+indexMarkerStart) {
+		t.Errorf("user content not kept:\n%s", content)
+	}
+	if !strings.Contains(content, "- **lint** — Run the linter (`.claude/skills/lint/SKILL.md`)") {
+		t.Errorf("skill entry missing:\n%s", content)
+	}
+	if tp := PlanTarget("claude", cfg, []string{source}, false); len(tp.Changes()) != 0 {
+		t.Errorf("second plan changes = %v, want none", tp.Changes())
+	}
+}
+
+func TestApplyTarget_IndexRollback(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	index := filepath.Join(t.TempDir(), "AGENTS.md")
+	writeLayerSkill(t, source, "lint")
+	os.WriteFile(index, []byte("mine\n"), 0644)
+
+	tp := PlanTarget("claude", config.TargetConfig{Path: target, Mode: "merge", Index: index}, []string{source}, false)
+	// A link that cannot be created fails the target after nothing else ran
+	tp.Actions = append([]Action{{Kind: ActionCreateLink, Skill: "x/y/z", Source: source}}, tp.Actions...)
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err == nil {
+		t.Fatal("expected failure")
+	}
+	if data, _ := os.ReadFile(index); string(data) != "mine\n" {
+		t.Errorf("index after rollback = %q", data)
+	}
+}
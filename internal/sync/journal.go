@@ -0,0 +1,308 @@
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// JournalOp names one recorded filesystem change.
+type JournalOp string
+
+const (
+	JournalCreate  JournalOp = "create"  // Path was created; undo removes it
+	JournalMkdir   JournalOp = "mkdir"   // Directory was created; undo removes it if empty
+	JournalUnlink  JournalOp = "unlink"  // Symlink at Path was removed; Link is where it pointed
+	JournalStash   JournalOp = "stash"   // Path was moved to Stash; undo moves it back
+	JournalMigrate JournalOp = "migrate" // Path was renamed to Source, which did not exist; undo moves it back
+)
+
+// JournalEntry is one change made to a target, recorded before it happens.
+type JournalEntry struct {
+	Op     JournalOp `json:"op"`
+	Path   string    `json:"path"`
+	Link   string    `json:"link,omitempty"`
+	Stash  string    `json:"stash,omitempty"`
+	Source string    `json:"source,omitempty"`
+}
+
+// Journal records every filesystem change made while applying one target
+// plan so it can be rolled back. It is saved before each change; a journal
+// left on disk means a sync was interrupted. Removed entries are moved into
+// a stash and only deleted on commit.
+type Journal struct {
+	Target  string         `json:"target"`
+	Path    string         `json:"path"`
+	Started time.Time      `json:"started"`
+	Entries []JournalEntry `json:"entries"`
+
+	file  string
+	stash string
+}
+
+// JournalDir returns the global sync journal directory, namespaced by the
+// active profile.
+func JournalDir() string {
+	return filepath.Join(config.ProfileDir(filepath.Dir(config.BaseConfigPath())), "journal")
+}
+
+// ProjectJournalDir returns the project-level sync journal directory.
+func ProjectJournalDir(root string) string {
+	return filepath.Join(root, ".skillshare", "journal")
+}
+
+// BeginJournal starts the journal for one target sync. It fails if an
+// earlier sync of the same target left a journal behind.
+func BeginJournal(dir, target, targetPath string) (*Journal, error) {
+	j := &Journal{
+		Target:  target,
+		Path:    targetPath,
+		Started: time.Now().UTC(),
+		Entries: []JournalEntry{},
+		file:    filepath.Join(dir, target+".json"),
+		stash:   filepath.Join(dir, target),
+	}
+	if _, err := os.Stat(j.file); err == nil {
+		return nil, fmt.Errorf("an interrupted sync of %s left a journal; run 'skillshare doctor' to finish or revert it", target)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	if err := os.RemoveAll(j.stash); err != nil {
+		return nil, fmt.Errorf("failed to clear journal stash: %w", err)
+	}
+	if err := j.save(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// LoadJournals returns the journals left in dir by interrupted syncs.
+func LoadJournals(dir string) ([]*Journal, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var journals []*Journal
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		file := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var j Journal
+		if err := json.Unmarshal(data, &j); err != nil {
+			return nil, fmt.Errorf("failed to parse journal %s: %w", file, err)
+		}
+		j.file = file
+		j.stash = strings.TrimSuffix(file, ".json")
+		journals = append(journals, &j)
+	}
+	sort.Slice(journals, func(a, b int) bool { return journals[a].Target < journals[b].Target })
+	return journals, nil
+}
+
+func (j *Journal) save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFileAtomic(j.file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync journal: %w", err)
+	}
+	return nil
+}
+
+func (j *Journal) record(e JournalEntry) error {
+	j.Entries = append(j.Entries, e)
+	return j.save()
+}
+
+// Created records that path is about to be created.
+func (j *Journal) Created(path string) error {
+	return j.record(JournalEntry{Op: JournalCreate, Path: path})
+}
+
+// Mkdir creates dir (and parents), recording it if it did not exist.
+func (j *Journal) Mkdir(dir string) error {
+	if _, err := os.Lstat(dir); os.IsNotExist(err) {
+		if err := j.record(JournalEntry{Op: JournalMkdir, Path: dir}); err != nil {
+			return err
+		}
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// Remove removes path so it can be restored on rollback: symlinks are
+// recorded by destination, anything else is moved into the stash.
+func (j *Journal) Remove(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 || utils.IsSymlinkOrJunction(path) {
+		link, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		if err := j.record(JournalEntry{Op: JournalUnlink, Path: path, Link: link}); err != nil {
+			return err
+		}
+		return os.Remove(path)
+	}
+
+	stash := j.stashPath()
+	if err := j.record(JournalEntry{Op: JournalStash, Path: path, Stash: stash}); err != nil {
+		return err
+	}
+	return moveAside(path, stash)
+}
+
+// Migrate moves target files into source as MigrateToSource does. A
+// missing source is renamed into place; otherwise each directory and file
+// the merge adds to source is recorded as it is created, and the target is
+// stashed. Files already in source are never touched, so undoing the merge
+// leaves them as they were.
+func (j *Journal) Migrate(targetPath, sourcePath string) error {
+	if err := j.Mkdir(filepath.Dir(sourcePath)); err != nil {
+		return fmt.Errorf("failed to create source parent: %w", err)
+	}
+
+	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+		if err := j.record(JournalEntry{Op: JournalMigrate, Path: targetPath, Source: sourcePath}); err != nil {
+			return err
+		}
+		return moveAside(targetPath, sourcePath)
+	}
+
+	err := filepath.Walk(targetPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(targetPath, path)
+		dstPath := filepath.Join(sourcePath, relPath)
+		if info.IsDir() {
+			return j.Mkdir(dstPath)
+		}
+		// Skip if destination exists
+		if _, err := os.Stat(dstPath); err == nil {
+			return nil
+		}
+		if err := j.Created(dstPath); err != nil {
+			return err
+		}
+		return copyFile(path, dstPath)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge directories: %w", err)
+	}
+	return j.Remove(targetPath)
+}
+
+func (j *Journal) stashPath() string {
+	return filepath.Join(j.stash, strconv.Itoa(len(j.Entries)))
+}
+
+// Commit keeps the recorded changes and deletes the journal and its stash.
+func (j *Journal) Commit() error {
+	if err := os.RemoveAll(j.stash); err != nil {
+		return fmt.Errorf("failed to remove journal stash: %w", err)
+	}
+	if err := os.Remove(j.file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove journal: %w", err)
+	}
+	return nil
+}
+
+// Rollback undoes the recorded changes newest first, then deletes the
+// journal. Entries that were recorded but never carried out are harmless to
+// undo. If any step fails, the journal is kept so rollback can be retried.
+func (j *Journal) Rollback() error {
+	var errs []error
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		if err := undoEntry(j.Entries[i]); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", j.Entries[i].Op, j.Entries[i].Path, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return j.Commit()
+}
+
+func undoEntry(e JournalEntry) error {
+	switch e.Op {
+	case JournalCreate:
+		return os.RemoveAll(e.Path)
+
+	case JournalMkdir:
+		// Only empty directories are ours to remove
+		os.Remove(e.Path)
+		return nil
+
+	case JournalUnlink:
+		if link, err := os.Readlink(e.Path); err == nil && link == e.Link {
+			return nil
+		}
+		if err := os.RemoveAll(e.Path); err != nil {
+			return err
+		}
+		return createLink(e.Path, e.Link)
+
+	case JournalStash:
+		if _, err := os.Lstat(e.Stash); os.IsNotExist(err) {
+			return nil
+		}
+		if _, err := os.Lstat(e.Path); err == nil {
+			// The move never completed; the original is still in place
+			return nil
+		}
+		return moveAside(e.Stash, e.Path)
+
+	case JournalMigrate:
+		if _, err := os.Lstat(e.Path); err == nil {
+			// The rename never happened
+			return nil
+		}
+		if _, err := os.Lstat(e.Source); os.IsNotExist(err) {
+			return nil
+		}
+		return moveAside(e.Source, e.Path)
+	}
+	return fmt.Errorf("unknown journal entry %q", e.Op)
+}
+
+// moveAside renames src to dst, copying across devices when rename fails.
+func moveAside(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	if err := copyDirectory(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
@@ -0,0 +1,148 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyTarget_RollsBackConversionOnFailure(t *testing.T) {
+	source := t.TempDir()
+	writeLayerSkill(t, source, "alpha")
+	target := filepath.Join(t.TempDir(), "skills")
+	if err := os.Symlink(source, target); err != nil {
+		t.Fatal(err)
+	}
+	journalDir := t.TempDir()
+
+	// The second copy fails halfway through the conversion to copy mode
+	tp := TargetPlan{Name: "claude", Path: target, Mode: "copy", Actions: []Action{
+		{Kind: ActionConvert},
+		{Kind: ActionCopy, Skill: "alpha", Source: filepath.Join(source, "alpha"), RelPath: "alpha"},
+		{Kind: ActionCopy, Skill: "beta", Source: filepath.Join(source, "missing"), RelPath: "missing"},
+	}}
+	_, err := ApplyTarget(tp, journalDir, false)
+	if err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("ApplyTarget() = %v, want rolled back error", err)
+	}
+
+	if link, err := os.Readlink(target); err != nil || link != source {
+		t.Errorf("target should be the original symlink again, got %q (%v)", link, err)
+	}
+	if _, err := os.Stat(filepath.Join(source, "alpha", "SKILL.md")); err != nil {
+		t.Errorf("source must be untouched: %v", err)
+	}
+	if journals, _ := LoadJournals(journalDir); len(journals) != 0 {
+		t.Errorf("journal should be removed after rollback, got %d", len(journals))
+	}
+}
+
+func TestJournal_RollbackAfterInterruptedSync(t *testing.T) {
+	target := t.TempDir()
+	journalDir := t.TempDir()
+	writeLayerSkill(t, target, "local")
+	if err := os.Symlink("/elsewhere", filepath.Join(target, "old")); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := BeginJournal(journalDir, "claude", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"local", "old"} {
+		if err := j.Remove(filepath.Join(target, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	created := filepath.Join(target, "new")
+	if err := j.Created(created); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(created, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second sync of the same target must wait for the journal
+	if _, err := BeginJournal(journalDir, "claude", target); err == nil {
+		t.Fatal("BeginJournal should refuse while a journal is pending")
+	}
+
+	// Simulate a new process finding the journal
+	journals, err := LoadJournals(journalDir)
+	if err != nil || len(journals) != 1 || len(journals[0].Entries) != 3 {
+		t.Fatalf("LoadJournals() = %v, %v", journals, err)
+	}
+	if err := journals[0].Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(target, "local", "SKILL.md")); err != nil {
+		t.Errorf("stashed directory not restored: %v", err)
+	}
+	if link, _ := os.Readlink(filepath.Join(target, "old")); link != "/elsewhere" {
+		t.Errorf("removed symlink not restored, got %q", link)
+	}
+	if _, err := os.Lstat(created); !os.IsNotExist(err) {
+		t.Error("created entry should be removed")
+	}
+	if journals, _ := LoadJournals(journalDir); len(journals) != 0 {
+		t.Error("journal should be removed after rollback")
+	}
+}
+
+func TestJournal_RollbackMigrateKeepsSource(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	journalDir := t.TempDir()
+
+	writeLayerSkill(t, source, "shared")
+	if err := os.Symlink("/elsewhere", filepath.Join(source, "linked")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(target, "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "shared", "SKILL.md"), []byte("# target copy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "shared", "notes.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeLayerSkill(t, target, "mine")
+
+	j, err := BeginJournal(journalDir, "claude", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Migrate(target, source); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(source, "shared", "SKILL.md")); string(data) != "# shared" {
+		t.Errorf("migrate must not overwrite source files, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(source, "mine", "SKILL.md")); err != nil {
+		t.Errorf("migrate should add new skills to source: %v", err)
+	}
+
+	if err := j.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rel := range []string{filepath.Join("shared", "notes.md"), "mine"} {
+		if _, err := os.Lstat(filepath.Join(source, rel)); !os.IsNotExist(err) {
+			t.Errorf("rollback should remove %s from source", rel)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(source, "shared", "SKILL.md")); string(data) != "# shared" {
+		t.Errorf("source file changed by rollback, got %q", data)
+	}
+	if link, _ := os.Readlink(filepath.Join(source, "linked")); link != "/elsewhere" {
+		t.Errorf("source symlink should be left alone, got %q", link)
+	}
+	for _, rel := range []string{filepath.Join("shared", "SKILL.md"), filepath.Join("shared", "notes.md"), filepath.Join("mine", "SKILL.md")} {
+		if _, err := os.Stat(filepath.Join(target, rel)); err != nil {
+			t.Errorf("target file %s not restored: %v", rel, err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeLayerSkill(t *testing.T, layer, rel string) {
+	t.Helper()
+	dir := filepath.Join(layer, rel)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# "+rel), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscoverLayeredSkills_LaterLayerWins(t *testing.T) {
+	base := t.TempDir()
+	team := t.TempDir()
+	writeLayerSkill(t, base, "shared")
+	writeLayerSkill(t, base, "base-only")
+	writeLayerSkill(t, team, "shared")
+	writeLayerSkill(t, team, "team-only")
+
+	skills, shadowed, err := DiscoverLayeredSkills([]string{base, team})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := map[string]DiscoveredSkill{}
+	for _, s := range skills {
+		byName[s.FlatName] = s
+	}
+	if len(byName) != 3 {
+		t.Fatalf("expected 3 skills, got %d: %v", len(byName), skills)
+	}
+	if got := byName["shared"]; got.Layer != team || got.SourcePath != filepath.Join(team, "shared") {
+		t.Errorf("shared should come from the team layer, got layer %q path %q", got.Layer, got.SourcePath)
+	}
+	if got := byName["base-only"]; got.Layer != base {
+		t.Errorf("base-only layer = %q, want %q", got.Layer, base)
+	}
+
+	if len(shadowed) != 1 {
+		t.Fatalf("expected 1 shadowed skill, got %d", len(shadowed))
+	}
+	if shadowed[0].Layer != base || shadowed[0].ShadowedBy != team {
+		t.Errorf("shadowed = %+v, want base shadowed by team", shadowed[0])
+	}
+}
+
+func TestDiscoverLayeredSkills_SingleLayer(t *testing.T) {
+	source := t.TempDir()
+	writeLayerSkill(t, source, "alpha")
+	writeLayerSkill(t, source, "team/beta")
+
+	skills, shadowed, err := DiscoverLayeredSkills([]string{source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 2 || len(shadowed) != 0 {
+		t.Fatalf("got %d skills, %d shadowed; want 2, 0", len(skills), len(shadowed))
+	}
+	for _, s := range skills {
+		if s.Layer != source {
+			t.Errorf("%s layer = %q, want %q", s.FlatName, s.Layer, source)
+		}
+	}
+}
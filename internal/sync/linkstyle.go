@@ -0,0 +1,65 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// linkText returns what the link at linkPath holds to point at source in the
+// given style: the absolute source path, or the path from the link's
+// directory to source. A relative path is only used when the filesystem
+// resolves it to source as well, which it does not when a directory on the
+// way (other than a shared parent) is itself a symlink.
+func linkText(linkPath, source, style string) string {
+	absSource, err := filepath.Abs(source)
+	if err != nil || style != config.LinkRelative || !relativeLinks {
+		return source
+	}
+	absDir, err := filepath.Abs(filepath.Dir(linkPath))
+	if err != nil {
+		return source
+	}
+	rel, err := filepath.Rel(absDir, absSource)
+	if err != nil {
+		return source
+	}
+	if !utils.PathsEqual(realPath(filepath.Join(realPath(absDir), rel)), realPath(absSource)) {
+		return source
+	}
+	return rel
+}
+
+// linkStyleMatches reports whether the link at linkPath, which already points
+// at source, is written in the given style. Links that cannot be read (such as
+// Windows junctions) always match.
+func linkStyleMatches(linkPath, source, style string) bool {
+	current, err := os.Readlink(linkPath)
+	if err != nil {
+		return true
+	}
+	return filepath.IsAbs(current) == filepath.IsAbs(linkText(linkPath, source, style))
+}
+
+// restyleReason explains an action that rewrites a link in another style.
+func restyleReason(style string) string {
+	if style == config.LinkRelative {
+		return "rewrite as relative link"
+	}
+	return "rewrite as absolute link"
+}
+
+// realPath resolves symlinks in path, including in the parents of a path that
+// does not exist yet.
+func realPath(path string) string {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		return real
+	}
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path
+	}
+	return filepath.Join(realPath(parent), filepath.Base(path))
+}
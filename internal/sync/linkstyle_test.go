@@ -0,0 +1,87 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func TestLinkText(t *testing.T) {
+	if !relativeLinks {
+		t.Skip("links are always absolute on this platform")
+	}
+	root := t.TempDir()
+	source := filepath.Join(root, "src", "skills", "alpha")
+	target := filepath.Join(root, "home", ".claude", "skills")
+
+	// A target directory reached through a symlink: ../ from the link would
+	// leave the real directory, so the link stays absolute
+	real := filepath.Join(root, "dotfiles", "claude")
+	if err := os.MkdirAll(real, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(real, filepath.Join(root, "linked-claude")); err != nil {
+		t.Fatal(err)
+	}
+	throughLink := filepath.Join(root, "linked-claude", "skills")
+
+	tests := []struct {
+		name     string
+		linkPath string
+		style    string
+		want     string
+	}{
+		{"absolute", filepath.Join(target, "alpha"), config.LinkAbsolute, source},
+		{"default", filepath.Join(target, "alpha"), "", source},
+		{"relative", filepath.Join(target, "alpha"), config.LinkRelative, filepath.Join("..", "..", "..", "src", "skills", "alpha")},
+		{"relative target dir", target, config.LinkRelative, filepath.Join("..", "..", "src", "skills", "alpha")},
+		{"symlinked parent", filepath.Join(throughLink, "alpha"), config.LinkRelative, source},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := linkText(tt.linkPath, source, tt.style); got != tt.want {
+				t.Errorf("linkText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanTarget_LinkStyle(t *testing.T) {
+	if !relativeLinks {
+		t.Skip("links are always absolute on this platform")
+	}
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	writeLayerSkill(t, source, "alpha")
+	relative := config.TargetConfig{Path: target, Mode: "merge", LinkStyle: config.LinkRelative}
+
+	tp := PlanTarget("claude", relative, []string{source}, false)
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	link, err := os.Readlink(filepath.Join(target, "alpha"))
+	if err != nil || filepath.IsAbs(link) {
+		t.Fatalf("Readlink() = %q, %v; want a relative link", link, err)
+	}
+	if tp = PlanTarget("claude", relative, []string{source}, false); len(tp.Changes()) != 0 {
+		t.Errorf("relative link should be in sync, got %v", tp.Changes())
+	}
+	if status, linked, _ := CheckStatusMerge(relative, []string{source}); status != StatusMerged || linked != 1 {
+		t.Errorf("CheckStatusMerge() = %s, %d linked; want merged, 1", status, linked)
+	}
+
+	// Switching back rewrites the existing link
+	absolute := config.TargetConfig{Path: target, Mode: "merge"}
+	tp = PlanTarget("claude", absolute, []string{source}, false)
+	if got := planKinds(tp); got["alpha"] != ActionFixLink {
+		t.Fatalf("actions = %v, want alpha rewritten", got)
+	}
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	if link, _ := os.Readlink(filepath.Join(target, "alpha")); !filepath.IsAbs(link) {
+		t.Errorf("link = %q, want absolute", link)
+	}
+}
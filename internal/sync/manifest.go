@@ -0,0 +1,214 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"skillshare/internal/utils"
+)
+
+// ManifestFile is the manifest sync writes into merge and copy targets. It
+// lists the entries skillshare created there, so prune, status, collect and
+// uninstall know exactly what they own.
+const ManifestFile = ".skillshare-manifest.json"
+
+// Manifest entry modes
+const (
+	EntryLink = "merge" // Symlink (junction on Windows) to the source skill
+	EntryCopy = "copy"  // Physical copy of the source skill
+)
+
+// ManifestEntry describes a single entry skillshare created in a target.
+type ManifestEntry struct {
+	Source string    `json:"source"`         // Relative path in source: _team/frontend/ui
+	Mode   string    `json:"mode"`           // EntryLink or EntryCopy
+	Hash   string    `json:"hash,omitempty"` // Copies: sha256 of the copied content
+	Synced time.Time `json:"synced"`
+}
+
+// Manifest tracks the entries skillshare owns in a merge or copy target.
+type Manifest struct {
+	Mode   string                   `json:"mode"`   // Target mode when last synced
+	Skills map[string]ManifestEntry `json:"skills"` // Keyed by flat name
+
+	missing bool // No manifest on disk yet
+}
+
+// Missing reports whether the target had no manifest, either because it was
+// never synced or because it was last synced before manifests were written.
+// Callers then fall back to recognising skillshare's entries by name.
+func (m *Manifest) Missing() bool {
+	return m.missing
+}
+
+// Owns reports whether name is an entry skillshare created.
+func (m *Manifest) Owns(name string) bool {
+	_, ok := m.Skills[name]
+	return ok
+}
+
+// ReadManifest loads the manifest of a target. A missing manifest yields an
+// empty manifest that reports Missing, not an error.
+func ReadManifest(targetPath string) (*Manifest, error) {
+	m := &Manifest{Skills: make(map[string]ManifestEntry)}
+
+	data, err := os.ReadFile(filepath.Join(targetPath, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			m.missing = true
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if m.Skills == nil {
+		m.Skills = make(map[string]ManifestEntry)
+	}
+	// Manifests written by copy mode alone had no per-entry mode
+	for name, entry := range m.Skills {
+		if entry.Mode == "" {
+			entry.Mode = EntryCopy
+			m.Skills[name] = entry
+		}
+	}
+	return m, nil
+}
+
+// WriteManifest persists the manifest into the target directory.
+func WriteManifest(targetPath string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := utils.WriteFileAtomic(filepath.Join(targetPath, ManifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	m.missing = false
+	return nil
+}
+
+// planPruneManaged plans what to do with manifest entries whose skill the
+// target no longer receives: unchanged links and copies are pruned, entries
+// already gone are forgotten, and entries changed in the target are kept. A
+// link repointed outside sources is the user's now; it is forgotten, not
+// pruned.
+func planPruneManaged(tp *TargetPlan, manifest *Manifest, valid map[string]bool, sources []string) {
+	names := make([]string, 0, len(manifest.Skills))
+	for name := range manifest.Skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if valid[name] {
+			continue
+		}
+		entryPath := filepath.Join(tp.Path, name)
+		if _, err := os.Lstat(entryPath); os.IsNotExist(err) {
+			tp.Actions = append(tp.Actions, Action{Kind: ActionForget, Skill: name})
+			continue
+		}
+		isLink := utils.IsSymlinkOrJunction(entryPath)
+
+		if manifest.Skills[name].Mode == EntryLink {
+			if !isLink {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionKeep, Skill: name, Reason: "link replaced by a local directory"})
+				continue
+			}
+			if absLink, err := utils.ResolveLinkTarget(entryPath); err != nil || !linkIntoSources(absLink, sources) {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionForget, Skill: name, Reason: "link points outside source, kept as local"})
+				continue
+			}
+			reason := "orphan symlink to source"
+			if _, err := os.Stat(entryPath); err != nil {
+				reason = "broken symlink to source"
+			}
+			tp.Actions = append(tp.Actions, Action{Kind: ActionPrune, Skill: name, Reason: reason})
+			continue
+		}
+
+		if hash, err := utils.HashDir(entryPath); isLink || (err == nil && hash != manifest.Skills[name].Hash) {
+			tp.Actions = append(tp.Actions, Action{Kind: ActionKeep, Skill: name, Reason: "source removed but copy has local edits"})
+			continue
+		}
+		tp.Actions = append(tp.Actions, Action{Kind: ActionPrune, Skill: name, Reason: "orphan copy"})
+	}
+}
+
+// planLocalEntries adds a local action for each target entry that is neither
+// a skill the target receives nor an entry skillshare created.
+func planLocalEntries(tp *TargetPlan, manifest *Manifest, valid map[string]bool) {
+	entries, _ := os.ReadDir(tp.Path)
+	for _, e := range entries {
+		name := e.Name()
+		if utils.IsHidden(name) || valid[name] || manifest.Owns(name) {
+			continue
+		}
+		tp.Actions = append(tp.Actions, Action{Kind: ActionLocal, Skill: name, Reason: "local only"})
+	}
+}
+
+// RemoveManaged removes the entries a target holds for the skill at relPath
+// (and for skills nested under it), as recorded in the target's manifest, and
+// returns their names. Links are removed only when they point into skillDir,
+// the skill's directory in source; copies edited in the target are kept and
+// returned in kept. With dryRun nothing is changed.
+func RemoveManaged(targetPath, relPath, skillDir string, dryRun bool) (removed, kept []string, err error) {
+	if info, err := os.Lstat(targetPath); err != nil || !info.IsDir() {
+		return nil, nil, nil
+	}
+	manifest, err := ReadManifest(targetPath)
+	if err != nil || manifest.Missing() {
+		return nil, nil, err
+	}
+
+	relPath = filepath.ToSlash(relPath)
+	names := make([]string, 0, len(manifest.Skills))
+	for name, entry := range manifest.Skills {
+		if entry.Source == relPath || strings.HasPrefix(entry.Source, relPath+"/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entryPath := filepath.Join(targetPath, name)
+		entry := manifest.Skills[name]
+		if _, err := os.Lstat(entryPath); os.IsNotExist(err) {
+			delete(manifest.Skills, name)
+			continue
+		}
+
+		switch entry.Mode {
+		case EntryLink:
+			absLink, err := utils.ResolveLinkTarget(entryPath)
+			if err != nil || !linkIntoSources(absLink, []string{skillDir}) {
+				continue
+			}
+		default:
+			if hash, err := utils.HashDir(entryPath); err != nil || hash != entry.Hash {
+				kept = append(kept, name)
+				continue
+			}
+		}
+		if !dryRun {
+			if err := os.RemoveAll(entryPath); err != nil {
+				return removed, kept, fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+			delete(manifest.Skills, name)
+		}
+		removed = append(removed, name)
+	}
+
+	if dryRun || len(removed) == 0 {
+		return removed, kept, nil
+	}
+	return removed, kept, WriteManifest(targetPath, manifest)
+}
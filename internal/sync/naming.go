@@ -0,0 +1,167 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// TargetName returns the entry name a skill gets in targets under the naming
+// policy, before collisions are resolved. Aliases match the skill's rel path
+// or full flat name.
+func TargetName(relPath string, n config.NamingConfig) string {
+	relPath = strings.Trim(strings.ReplaceAll(relPath, "\\", "/"), "/")
+	flat := utils.PathToFlatName(relPath)
+	for key, alias := range n.Aliases {
+		if k := strings.Trim(key, "/"); k == relPath || k == flat {
+			return alias
+		}
+	}
+
+	parts := strings.Split(relPath, "/")
+	last := parts[len(parts)-1]
+	switch n.Strategy {
+	case "last":
+		return last
+	case "last-n":
+		if n.Segments < len(parts) {
+			parts = parts[len(parts)-n.Segments:]
+		}
+		return strings.Join(parts, utils.NestedSeparator)
+	case "prefix":
+		// The longest configured folder containing the skill wins
+		best := -1
+		prefix := ""
+		for folder, p := range n.Prefixes {
+			folder = strings.Trim(folder, "/")
+			if strings.HasPrefix(relPath, folder+"/") && len(folder) > best {
+				best, prefix = len(folder), p
+			}
+		}
+		return prefix + last
+	}
+	return flat
+}
+
+func hasAlias(skill DiscoveredSkill, n config.NamingConfig) bool {
+	flat := utils.PathToFlatName(skill.RelPath)
+	for key := range n.Aliases {
+		if k := strings.Trim(key, "/"); k == skill.RelPath || k == flat {
+			return true
+		}
+	}
+	return false
+}
+
+// NameSkills sets each skill's FlatName to its target name under the naming
+// policy and resolves names used by more than one skill: collisions "error"
+// fails, "suffix" keeps the first name (aliases, then by path) and appends -2,
+// -3, ... to the rest, and "layer" keeps the skill from the latest layer in
+// sources and returns the others as shadowed. Order is preserved.
+func NameSkills(skills []DiscoveredSkill, n config.NamingConfig, sources []string) ([]DiscoveredSkill, []ShadowedSkill, error) {
+	if n.IsDefault() {
+		return skills, nil, nil
+	}
+
+	named := make([]DiscoveredSkill, len(skills))
+	byName := make(map[string][]int)
+	for i, skill := range skills {
+		skill.FlatName = TargetName(skill.RelPath, n)
+		named[i] = skill
+		byName[skill.FlatName] = append(byName[skill.FlatName], i)
+	}
+
+	names := make([]string, 0, len(byName))
+	for name, idx := range byName {
+		if len(idx) > 1 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return named, nil, nil
+	}
+	sort.Strings(names)
+
+	drop := make(map[int]string) // index -> layer of the winning skill
+	for _, name := range names {
+		idx := byName[name]
+		switch n.Collisions {
+		case "suffix":
+			sort.SliceStable(idx, func(a, b int) bool {
+				sa, sb := named[idx[a]], named[idx[b]]
+				if aa, ab := hasAlias(sa, n), hasAlias(sb, n); aa != ab {
+					return aa
+				}
+				return sa.RelPath < sb.RelPath
+			})
+			for k, i := range idx[1:] {
+				candidate := name + "-" + strconv.Itoa(k+2)
+				for m := k + 3; len(byName[candidate]) > 0; m++ {
+					candidate = name + "-" + strconv.Itoa(m)
+				}
+				named[i].FlatName = candidate
+				byName[candidate] = []int{i}
+			}
+
+		case "layer":
+			winner := idx[0]
+			tie := false
+			for _, i := range idx[1:] {
+				switch a, b := layerIndex(named[i].Layer, sources), layerIndex(named[winner].Layer, sources); {
+				case a > b:
+					winner, tie = i, false
+				case a == b:
+					tie = true
+				}
+			}
+			if tie {
+				return nil, nil, collisionError(name, named, idx, "in the same layer")
+			}
+			for _, i := range idx {
+				if i != winner {
+					drop[i] = named[winner].Layer
+				}
+			}
+
+		default:
+			return nil, nil, collisionError(name, named, idx, "")
+		}
+	}
+
+	var kept []DiscoveredSkill
+	var shadowed []ShadowedSkill
+	for i, skill := range named {
+		if layer, ok := drop[i]; ok {
+			shadowed = append(shadowed, ShadowedSkill{DiscoveredSkill: skill, ShadowedBy: layer})
+			continue
+		}
+		kept = append(kept, skill)
+	}
+	return kept, shadowed, nil
+}
+
+func layerIndex(layer string, sources []string) int {
+	for i, src := range sources {
+		if src == layer {
+			return i
+		}
+	}
+	return -1
+}
+
+func collisionError(name string, skills []DiscoveredSkill, idx []int, detail string) error {
+	paths := make([]string, len(idx))
+	for k, i := range idx {
+		paths[k] = skills[i].RelPath
+	}
+	sort.Strings(paths)
+	if detail != "" {
+		detail = " " + detail
+	}
+	return fmt.Errorf("naming: %q would be used by %s%s; add naming.aliases or set naming.collisions to suffix or layer",
+		name, strings.Join(paths, ", "), detail)
+}
@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func TestTargetName(t *testing.T) {
+	tests := []struct {
+		name   string
+		rel    string
+		naming config.NamingConfig
+		want   string
+	}{
+		{"default", "_team/frontend/hooks", config.NamingConfig{}, "_team__frontend__hooks"},
+		{"full", "_team/frontend/hooks", config.NamingConfig{Strategy: "full"}, "_team__frontend__hooks"},
+		{"last", "_team/frontend/hooks", config.NamingConfig{Strategy: "last"}, "hooks"},
+		{"last-n", "_team/frontend/hooks", config.NamingConfig{Strategy: "last-n", Segments: 2}, "frontend__hooks"},
+		{"last-n longer than path", "hooks", config.NamingConfig{Strategy: "last-n", Segments: 3}, "hooks"},
+		{"prefix longest folder", "_team/frontend/react/hooks", config.NamingConfig{Strategy: "prefix",
+			Prefixes: map[string]string{"_team": "team-", "_team/frontend": "fe-"}}, "fe-hooks"},
+		{"prefix outside folders", "tools/lint", config.NamingConfig{Strategy: "prefix",
+			Prefixes: map[string]string{"_team": "team-"}}, "lint"},
+		{"alias by path", "_team/frontend/hooks", config.NamingConfig{Strategy: "last",
+			Aliases: map[string]string{"_team/frontend/hooks": "react-hooks"}}, "react-hooks"},
+		{"alias by flat name", "_team/frontend/hooks", config.NamingConfig{
+			Aliases: map[string]string{"_team__frontend__hooks": "react-hooks"}}, "react-hooks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TargetName(tt.rel, tt.naming); got != tt.want {
+				t.Errorf("TargetName(%q) = %q, want %q", tt.rel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameSkills_Collisions(t *testing.T) {
+	skills := []DiscoveredSkill{
+		{RelPath: "frontend/utils", Layer: "base"},
+		{RelPath: "backend/utils", Layer: "team"},
+		{RelPath: "tools/lint", Layer: "base"},
+	}
+	sources := []string{"base", "team"}
+	names := func(skills []DiscoveredSkill) []string {
+		var out []string
+		for _, s := range skills {
+			out = append(out, s.FlatName)
+		}
+		return out
+	}
+
+	_, _, err := NameSkills(skills, config.NamingConfig{Strategy: "last"}, sources)
+	if err == nil || !strings.Contains(err.Error(), `"utils" would be used by backend/utils, frontend/utils`) {
+		t.Errorf("error strategy: got %v", err)
+	}
+
+	named, _, err := NameSkills(skills, config.NamingConfig{Strategy: "last", Collisions: "suffix"}, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"utils-2", "utils", "lint"}; !reflect.DeepEqual(names(named), want) {
+		t.Errorf("suffix strategy: names = %v, want %v", names(named), want)
+	}
+
+	// An alias keeps its name ahead of path order
+	named, _, err = NameSkills(skills, config.NamingConfig{Strategy: "last", Collisions: "suffix",
+		Aliases: map[string]string{"frontend/utils": "utils"}}, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"utils", "utils-2", "lint"}; !reflect.DeepEqual(names(named), want) {
+		t.Errorf("suffix with alias: names = %v, want %v", names(named), want)
+	}
+
+	named, shadowed, err := NameSkills(skills, config.NamingConfig{Strategy: "last", Collisions: "layer"}, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"utils", "lint"}; !reflect.DeepEqual(names(named), want) || named[0].RelPath != "backend/utils" {
+		t.Errorf("layer strategy: kept %+v", named)
+	}
+	if len(shadowed) != 1 || shadowed[0].RelPath != "frontend/utils" || shadowed[0].ShadowedBy != "team" {
+		t.Errorf("layer strategy: shadowed %+v", shadowed)
+	}
+}
+
+func TestPlanTarget_NamingPrunesOldNames(t *testing.T) {
+	source := t.TempDir()
+	target := t.TempDir()
+	writeLayerSkill(t, source, "_team/frontend/hooks")
+
+	cfg := config.TargetConfig{Path: target, Mode: "merge"}
+	if _, err := ApplyTarget(PlanTarget("claude", cfg, []string{source}, false), t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Naming = &config.NamingConfig{Strategy: "last"}
+	got := planKinds(PlanTarget("claude", cfg, []string{source}, false))
+	want := map[string]ActionKind{"hooks": ActionCreateLink, "_team__frontend__hooks": ActionPrune}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+}
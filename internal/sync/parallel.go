@@ -0,0 +1,161 @@
+package sync
+
+import (
+	"path/filepath"
+	"runtime"
+	gosync "sync"
+
+	"skillshare/internal/utils"
+)
+
+// DefaultJobs is the number of targets synced at once when no limit is given.
+func DefaultJobs() int {
+	return min(runtime.NumCPU(), 8)
+}
+
+// hashCache memoizes utils.HashDir for one plan, so copy targets that share skills
+// hash each source directory once.
+type hashCache struct {
+	mu     gosync.Mutex
+	hashes map[string]string
+}
+
+func newHashCache() *hashCache {
+	return &hashCache{hashes: make(map[string]string)}
+}
+
+func (c *hashCache) hash(dir string) (string, error) {
+	c.mu.Lock()
+	h, ok := c.hashes[dir]
+	c.mu.Unlock()
+	if ok {
+		return h, nil
+	}
+
+	h, err := utils.HashDir(dir)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.hashes[dir] = h
+	c.mu.Unlock()
+	return h, nil
+}
+
+// TargetOutcome is the result of applying one target of a plan.
+type TargetOutcome struct {
+	Plan   TargetPlan // As applied, re-planned after earlier targets when needed
+	Result *TargetResult
+	Err    error
+}
+
+// Apply syncs the plan's targets with up to jobs running at once. Targets
+// that share a path run one after another in name order, each re-planned
+// after the previous one was applied. Targets that migrate files into source
+// run first, one group at a time; source is then rediscovered and every
+// remaining target re-planned so it gets the migrated skills. onDone, if set, is called in plan
+// order as outcomes become available; the full list is returned in the same
+// order.
+func (p *Plan) Apply(journalDir string, jobs int, dryRun bool, onDone func(TargetOutcome)) []TargetOutcome {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	serial, parallel := p.groups()
+
+	outcomes := make([]TargetOutcome, len(p.Targets))
+	done := make([]chan struct{}, len(p.Targets))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	// Serial groups replace ctx before any parallel group starts
+	ctx := p.ctx
+	replanAll := len(serial) > 0
+	runGroup := func(group []int, serial bool) {
+		for n, i := range group {
+			tp := p.Targets[i]
+			if (n > 0 || replanAll) && !dryRun && ctx != nil {
+				tp = planTarget(tp.Name, p.targets[tp.Name], p.Sources, p.Force, ctx)
+			}
+			res, err := ApplyTarget(tp, journalDir, dryRun)
+			outcomes[i] = TargetOutcome{Plan: tp, Result: res, Err: err}
+			close(done[i])
+			if serial && !dryRun && ctx != nil {
+				ctx = newPlanContext(p.Sources, ctx.ignore)
+			}
+		}
+	}
+
+	var wg gosync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for _, group := range serial {
+			runGroup(group, true)
+		}
+
+		work := make(chan []int)
+		var pool gosync.WaitGroup
+		for range min(jobs, len(parallel)) {
+			pool.Add(1)
+			go func() {
+				defer pool.Done()
+				for group := range work {
+					runGroup(group, false)
+				}
+			}()
+		}
+		for _, group := range parallel {
+			work <- group
+		}
+		close(work)
+		pool.Wait()
+	}()
+
+	for i := range outcomes {
+		<-done[i]
+		if onDone != nil {
+			onDone(outcomes[i])
+		}
+	}
+	wg.Wait()
+	return outcomes
+}
+
+// groups splits the plan's target indexes into units of work: targets that
+// share a path form one group, applied in order. Groups that migrate files
+// into source are returned as serial, to run one at a time before the rest.
+func (p *Plan) groups() (serial, parallel [][]int) {
+	var groups [][]int
+	byPath := make(map[string]int)
+	for i, tp := range p.Targets {
+		key := filepath.Clean(tp.Path)
+		if g, ok := byPath[key]; ok {
+			groups[g] = append(groups[g], i)
+			continue
+		}
+		byPath[key] = len(groups)
+		groups = append(groups, []int{i})
+	}
+	for _, group := range groups {
+		if p.migrates(group) {
+			serial = append(serial, group)
+		} else {
+			parallel = append(parallel, group)
+		}
+	}
+	return serial, parallel
+}
+
+// migrates reports whether any target in a group moves files into source
+func (p *Plan) migrates(group []int) bool {
+	for _, i := range group {
+		for _, action := range p.Targets[i].Actions {
+			if action.Kind == ActionMigrate {
+				return true
+			}
+		}
+	}
+	return false
+}
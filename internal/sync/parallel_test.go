@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func TestPlanApply_OrderedAndSharedPathsSerialized(t *testing.T) {
+	source := t.TempDir()
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		writeLayerSkill(t, source, name)
+	}
+	base := t.TempDir()
+	shared := filepath.Join(base, "agents")
+	targets := map[string]config.TargetConfig{
+		"amp":    {Path: shared},
+		"codex":  {Path: shared},
+		"claude": {Path: filepath.Join(base, "claude")},
+		"cursor": {Path: filepath.Join(base, "cursor"), Mode: "copy"},
+	}
+
+	plan := NewPlan(targets, "merge", []string{source}, false)
+	var order []string
+	outcomes := plan.Apply(t.TempDir(), 4, false, func(o TargetOutcome) {
+		order = append(order, o.Plan.Name)
+	})
+
+	want := []string{"amp", "claude", "codex", "cursor"}
+	if len(order) != len(want) {
+		t.Fatalf("onDone order = %v, want %v", order, want)
+	}
+	for i, name := range want {
+		if order[i] != name || outcomes[i].Plan.Name != name {
+			t.Errorf("outcome %d = %s, want %s", i, order[i], name)
+		}
+		if outcomes[i].Err != nil {
+			t.Errorf("%s: %v", name, outcomes[i].Err)
+		}
+	}
+
+	// codex shares amp's path, so it was re-planned and found everything linked
+	if got := outcomes[2].Result; len(got.Linked) != 0 || len(got.Unchanged) != 3 {
+		t.Errorf("codex result = %+v, want 3 unchanged", got)
+	}
+	for _, dir := range []string{shared, filepath.Join(base, "claude"), filepath.Join(base, "cursor")} {
+		if _, err := os.Stat(filepath.Join(dir, "gamma", "SKILL.md")); err != nil {
+			t.Errorf("gamma missing in %s: %v", dir, err)
+		}
+	}
+}
+
+func TestPlanGroups_MigrateKeepsSharedPathTogether(t *testing.T) {
+	source := t.TempDir()
+	writeLayerSkill(t, source, "alpha")
+	base := t.TempDir()
+
+	// A symlink-mode target over a real directory migrates its files
+	shared := filepath.Join(base, "agents")
+	writeLayerSkill(t, shared, "local")
+	other := filepath.Join(base, "other")
+	writeLayerSkill(t, other, "local")
+	targets := map[string]config.TargetConfig{
+		"amp":    {Path: shared, Mode: "symlink"},
+		"codex":  {Path: shared},
+		"claude": {Path: filepath.Join(base, "claude")},
+		"cursor": {Path: other, Mode: "symlink"},
+	}
+
+	plan := NewPlan(targets, "merge", []string{source}, false)
+	serial, parallel := plan.groups()
+
+	names := func(groups [][]int) [][]string {
+		var out [][]string
+		for _, g := range groups {
+			var group []string
+			for _, i := range g {
+				group = append(group, plan.Targets[i].Name)
+			}
+			out = append(out, group)
+		}
+		return out
+	}
+	if got := names(serial); !reflect.DeepEqual(got, [][]string{{"amp", "codex"}, {"cursor"}}) {
+		t.Errorf("serial groups = %v, want [[amp codex] [cursor]]", got)
+	}
+	if got := names(parallel); !reflect.DeepEqual(got, [][]string{{"claude"}}) {
+		t.Errorf("parallel groups = %v, want [[claude]]", got)
+	}
+
+	for _, o := range plan.Apply(t.TempDir(), 4, false, nil) {
+		if o.Err != nil {
+			t.Errorf("%s: %v", o.Plan.Name, o.Err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(source, "local", "SKILL.md")); err != nil {
+		t.Errorf("migrated skill missing from source: %v", err)
+	}
+}
+
+func TestPlanApply_ReplansAfterMigration(t *testing.T) {
+	source := t.TempDir()
+	writeLayerSkill(t, source, "alpha")
+	base := t.TempDir()
+
+	// amp migrates "local" into source; claude shares nothing with it
+	migrating := filepath.Join(base, "agents")
+	writeLayerSkill(t, migrating, "local")
+	independent := filepath.Join(base, "claude")
+	targets := map[string]config.TargetConfig{
+		"amp":    {Path: migrating, Mode: "symlink"},
+		"claude": {Path: independent},
+	}
+
+	plan := NewPlan(targets, "merge", []string{source}, false)
+	for _, o := range plan.Apply(t.TempDir(), 4, false, nil) {
+		if o.Err != nil {
+			t.Errorf("%s: %v", o.Plan.Name, o.Err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(independent, "local", "SKILL.md")); err != nil {
+		t.Errorf("independent target should get the migrated skill in the same sync: %v", err)
+	}
+}
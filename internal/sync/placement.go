@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// SkillPlacement is the target selection a skill declares in its SKILL.md
+// frontmatter with targets:, exclude_targets: and os:.
+type SkillPlacement struct {
+	Targets        []string `json:"targets,omitempty"`        // Allow list of target names; empty means all
+	ExcludeTargets []string `json:"excludeTargets,omitempty"` // Target names the skill never syncs to
+	OS             []string `json:"os,omitempty"`             // Platforms the skill syncs on; empty means all
+}
+
+// readSkillPlacement reads the placement fields from a skill's SKILL.md.
+func readSkillPlacement(skillDir string) SkillPlacement {
+	var fields struct {
+		Targets        utils.FrontmatterList `yaml:"targets"`
+		ExcludeTargets utils.FrontmatterList `yaml:"exclude_targets"`
+		OS             utils.FrontmatterList `yaml:"os"`
+	}
+	utils.ReadFrontmatter(filepath.Join(skillDir, "SKILL.md"), &fields)
+	return SkillPlacement{
+		Targets:        fields.Targets,
+		ExcludeTargets: fields.ExcludeTargets,
+		OS:             fields.OS,
+	}
+}
+
+// IsZero reports whether the skill declares no placement at all.
+func (p SkillPlacement) IsZero() bool {
+	return len(p.Targets) == 0 && len(p.ExcludeTargets) == 0 && len(p.OS) == 0
+}
+
+// Allows reports whether the skill belongs in the named target on this
+// machine. Names match case-insensitively and through the target registry,
+// so "claude" and "claude-code" select the same tool. A target without a
+// name only passes when there is no allow list.
+func (p SkillPlacement) Allows(targetName string) bool {
+	if len(p.OS) > 0 && !slices.ContainsFunc(p.OS, config.MatchOS) {
+		return false
+	}
+	if targetName == "" {
+		return len(p.Targets) == 0
+	}
+
+	aliases := config.TargetAliases(targetName)
+	named := func(name string) bool {
+		for _, alias := range aliases {
+			if strings.EqualFold(strings.TrimSpace(name), alias) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(p.Targets) > 0 && !slices.ContainsFunc(p.Targets, named) {
+		return false
+	}
+	return !slices.ContainsFunc(p.ExcludeTargets, named)
+}
+
+// SkillTargetNames returns the sorted names of the targets a skill syncs to:
+// every symlink-mode target, since those link the whole source, plus each
+// other target whose include/exclude filters and the skill's placement
+// select it.
+func SkillTargetNames(skill DiscoveredSkill, targets map[string]config.TargetConfig, defaultMode string) []string {
+	names := make([]string, 0, len(targets))
+	for name, target := range targets {
+		mode := target.Mode
+		if mode == "" {
+			mode = defaultMode
+		}
+		if mode != "symlink" {
+			selected, err := FilterTargetSkills([]DiscoveredSkill{skill}, target.WithName(name))
+			if err != nil || len(selected) == 0 {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
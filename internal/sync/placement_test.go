@@ -0,0 +1,57 @@
+package sync
+
+import (
+	"runtime"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func TestSkillPlacement_Allows(t *testing.T) {
+	otherOS := "windows"
+	if runtime.GOOS == "windows" {
+		otherOS = "linux"
+	}
+
+	tests := []struct {
+		name      string
+		placement SkillPlacement
+		target    string
+		want      bool
+	}{
+		{"no placement", SkillPlacement{}, "cursor", true},
+		{"allow list match", SkillPlacement{Targets: []string{"claude", "cursor"}}, "cursor", true},
+		{"allow list miss", SkillPlacement{Targets: []string{"claude"}}, "cursor", false},
+		{"case insensitive", SkillPlacement{Targets: []string{"Cursor"}}, "cursor", true},
+		{"registry alias", SkillPlacement{Targets: []string{"claude"}}, "claude-code", true},
+		{"excluded", SkillPlacement{ExcludeTargets: []string{"cursor"}}, "cursor", false},
+		{"exclude wins over allow", SkillPlacement{Targets: []string{"cursor"}, ExcludeTargets: []string{"cursor"}}, "cursor", false},
+		{"current os", SkillPlacement{OS: []string{runtime.GOOS}}, "cursor", true},
+		{"other os", SkillPlacement{OS: []string{otherOS}}, "cursor", false},
+		{"unnamed target without allow list", SkillPlacement{ExcludeTargets: []string{"cursor"}}, "", true},
+		{"unnamed target with allow list", SkillPlacement{Targets: []string{"cursor"}}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.placement.Allows(tt.target); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillTargetNames(t *testing.T) {
+	skill := DiscoveredSkill{RelPath: "review", FlatName: "review", Placement: SkillPlacement{Targets: []string{"claude"}}}
+	targets := map[string]config.TargetConfig{
+		"claude":   {Path: "/tmp/claude"},
+		"cursor":   {Path: "/tmp/cursor"},
+		"codex":    {Path: "/tmp/codex", Mode: "symlink"},
+		"filtered": {Path: "/tmp/filtered", Include: []string{"other"}},
+	}
+
+	got := SkillTargetNames(skill, targets, "merge")
+	if len(got) != 2 || got[0] != "claude" || got[1] != "codex" {
+		t.Errorf("SkillTargetNames() = %v, want [claude codex]", got)
+	}
+}
@@ -0,0 +1,632 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// PlanVersion is the format version of saved plan files.
+const PlanVersion = 1
+
+// ActionKind names one step of a sync plan.
+type ActionKind string
+
+const (
+	ActionConvert      ActionKind = "convert"       // Remove a whole-directory symlink before merge or copy sync
+	ActionCreateLink   ActionKind = "create-link"   // Merge: link a skill missing from the target
+	ActionFixLink      ActionKind = "fix-link"      // Merge: repoint a link that points elsewhere
+	ActionReplaceLocal ActionKind = "replace-local" // Merge --force: replace a local copy with a link
+	ActionCopy         ActionKind = "copy"          // Copy: copy a skill missing from the target
+	ActionUpdateCopy   ActionKind = "update-copy"   // Copy: recopy a skill
+	ActionLinkTarget   ActionKind = "link-target"   // Symlink: link the target directory to source
+	ActionMigrate      ActionKind = "migrate"       // Symlink: move target files into source, then link
+	ActionRelink       ActionKind = "relink"        // Symlink: replace a broken, restyled or (with --force) foreign link
+	ActionPrune        ActionKind = "prune"         // Remove an entry whose skill left source
+	ActionForget       ActionKind = "forget"        // Drop a manifest entry whose link or copy is already gone
+	ActionAdopt        ActionKind = "adopt"         // Merge: record a link made before manifests in the manifest
+	ActionGenerate     ActionKind = "generate"      // Format: write a rendered file missing from the target
+	ActionRegenerate   ActionKind = "regenerate"    // Format: rewrite a rendered file
+	ActionIndex        ActionKind = "index"         // Rewrite the managed skill list in the target's index file (Source)
+	ActionSkip         ActionKind = "skip"          // Source skill not synced, see Reason
+	ActionKeep         ActionKind = "keep"          // Target entry kept with a warning, see Reason
+	ActionLocal        ActionKind = "local"         // Entry that exists only in the target
+)
+
+// Action is one planned change to a target. Skill is empty for actions on
+// the whole target directory.
+type Action struct {
+	Kind    ActionKind `json:"kind"`
+	Skill   string     `json:"skill,omitempty"`
+	Source  string     `json:"source,omitempty"`   // Source directory to link or copy
+	RelPath string     `json:"rel_path,omitempty"` // Link and copy: path relative to its source layer
+	Hash    string     `json:"hash,omitempty"`     // Copy: source content hash; format: rendered content hash
+	Reason  string     `json:"reason,omitempty"`
+
+	content string // Format: rendered file to write, set when planned
+}
+
+// Changes reports whether applying the action writes to the target.
+func (a Action) Changes() bool {
+	switch a.Kind {
+	case ActionSkip, ActionKeep, ActionLocal, ActionAdopt:
+		return false
+	}
+	return true
+}
+
+// Describe returns a one-line, human-readable form of the action.
+func (a Action) Describe(targetPath string) string {
+	path := targetPath
+	if a.Skill != "" {
+		path = filepath.Join(targetPath, a.Skill)
+	}
+	switch a.Kind {
+	case ActionConvert:
+		return fmt.Sprintf("convert %s from symlink mode (%s)", targetPath, a.Reason)
+	case ActionCreateLink, ActionLinkTarget:
+		return fmt.Sprintf("link %s -> %s", path, a.Source)
+	case ActionFixLink:
+		return fmt.Sprintf("fix link %s (%s)", a.Skill, a.Reason)
+	case ActionReplaceLocal:
+		return fmt.Sprintf("replace local copy %s with link", a.Skill)
+	case ActionCopy:
+		return fmt.Sprintf("copy %s -> %s", a.Source, path)
+	case ActionUpdateCopy:
+		return fmt.Sprintf("update copy %s (%s)", a.Skill, a.Reason)
+	case ActionMigrate:
+		return fmt.Sprintf("migrate files from %s to %s, then link", targetPath, a.Source)
+	case ActionRelink:
+		return fmt.Sprintf("relink %s -> %s (%s)", targetPath, a.Source, a.Reason)
+	case ActionPrune:
+		return fmt.Sprintf("remove %s: %s", a.Reason, path)
+	case ActionForget:
+		if a.Reason != "" {
+			return fmt.Sprintf("forget %s (%s)", a.Skill, a.Reason)
+		}
+		return fmt.Sprintf("forget %s (already removed)", a.Skill)
+	case ActionAdopt:
+		return fmt.Sprintf("record link %s in manifest", a.Skill)
+	case ActionGenerate:
+		return fmt.Sprintf("generate %s", path)
+	case ActionRegenerate:
+		return fmt.Sprintf("regenerate %s (%s)", a.Skill, a.Reason)
+	case ActionIndex:
+		return fmt.Sprintf("update skill index in %s (%s)", a.Source, a.Reason)
+	}
+	return fmt.Sprintf("%s %s: %s", a.Kind, a.Skill, a.Reason)
+}
+
+// TargetPlan holds the planned actions for one target.
+type TargetPlan struct {
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Mode      string   `json:"mode"`
+	Format    string   `json:"format,omitempty"`     // Set when the target is not skill-dir
+	Index     string   `json:"index,omitempty"`      // Index file the target keeps a skill list in
+	LinkStyle string   `json:"link_style,omitempty"` // Set when links are written relative
+	Actions   []Action `json:"actions"`
+	InSync    []string `json:"in_sync,omitempty"` // Skills that need no change
+	Error     string   `json:"error,omitempty"`   // Set when the target cannot be synced
+}
+
+// Changes returns the actions that write to the target.
+func (tp *TargetPlan) Changes() []Action {
+	var out []Action
+	for _, a := range tp.Actions {
+		if a.Changes() {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Only narrows the plan to the named skills (flat names), so watch mode can
+// resync just what changed. A plan that converts the target is kept whole,
+// and an index update is always kept.
+func (tp TargetPlan) Only(names []string) TargetPlan {
+	if len(tp.Actions) > 0 && tp.Actions[0].Kind == ActionConvert {
+		return tp
+	}
+	keep := make(map[string]bool, len(names))
+	for _, name := range names {
+		keep[name] = true
+	}
+	out := tp
+	out.Actions = []Action{}
+	out.InSync = nil
+	for _, a := range tp.Actions {
+		if keep[a.Skill] || a.Kind == ActionIndex {
+			out.Actions = append(out.Actions, a)
+		}
+	}
+	for _, name := range tp.InSync {
+		if keep[name] {
+			out.InSync = append(out.InSync, name)
+		}
+	}
+	return out
+}
+
+// Plan is the full set of changes a sync would make. It is computed without
+// touching any target and can be saved as JSON and applied later.
+type Plan struct {
+	Version int          `json:"version"`
+	Created time.Time    `json:"created"`
+	Sources []string     `json:"sources"`
+	Force   bool         `json:"force"`
+	Targets []TargetPlan `json:"targets"`
+
+	// Set by NewPlan so Apply can re-plan targets that share a path
+	targets map[string]config.TargetConfig
+	ctx     *planContext
+}
+
+// planContext holds what every target of one plan shares: the skills
+// discovered once across the source layers, and source hashes for copy mode.
+type planContext struct {
+	skills []DiscoveredSkill
+	err    error
+	hashes *hashCache
+	ignore []string
+}
+
+func newPlanContext(sources []string, ignore []string) *planContext {
+	skills, _, err := DiscoverLayeredSkills(sources, ignore...)
+	return &planContext{skills: skills, err: err, hashes: newHashCache(), ignore: ignore}
+}
+
+// targetSkills returns the skills a target receives after include/exclude
+// and frontmatter placement, named by its naming policy, plus those that lost a name to a later layer.
+func (c *planContext) targetSkills(target config.TargetConfig, sources []string) ([]DiscoveredSkill, []ShadowedSkill, error) {
+	if c.err != nil {
+		return nil, nil, fmt.Errorf("failed to discover skills: %w", c.err)
+	}
+	skills, err := FilterTargetSkills(c.skills, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NameSkills(skills, target.NamingPolicy(), sources)
+}
+
+// planNameShadowed adds a skip action for each skill that lost its name to a
+// skill in a later layer (naming.collisions: layer).
+func planNameShadowed(tp *TargetPlan, shadowed []ShadowedSkill) {
+	for _, s := range shadowed {
+		tp.Actions = append(tp.Actions, Action{Kind: ActionSkip, Skill: s.FlatName, Source: s.SourcePath,
+			Reason: fmt.Sprintf("name taken by a skill in %s (%s not synced)", s.ShadowedBy, s.RelPath)})
+	}
+}
+
+// Skills returns the skills discovered for the plan, or nil for a plan that
+// was read from a file.
+func (p *Plan) Skills() []DiscoveredSkill {
+	if p.ctx == nil {
+		return nil
+	}
+	return p.ctx.skills
+}
+
+// NewPlan plans every target, in name order. Targets without a mode use
+// defaultMode, then merge.
+func NewPlan(targets map[string]config.TargetConfig, defaultMode string, sources []string, force bool, ignore ...string) *Plan {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	p := &Plan{
+		Version: PlanVersion,
+		Created: time.Now().UTC(),
+		Sources: sources,
+		Force:   force,
+		targets: make(map[string]config.TargetConfig, len(targets)),
+		ctx:     newPlanContext(sources, ignore),
+	}
+	for _, name := range names {
+		target := targets[name]
+		if target.Mode == "" {
+			target.Mode = defaultMode
+		}
+		p.targets[name] = target
+		p.Targets = append(p.Targets, planTarget(name, target, sources, force, p.ctx))
+	}
+	return p
+}
+
+// PlanTarget works out what syncing one target would do. target.Mode selects
+// merge (default), copy or symlink.
+func PlanTarget(name string, target config.TargetConfig, sources []string, force bool, ignore ...string) TargetPlan {
+	if target.Mode == "symlink" {
+		return planTarget(name, target, sources, force, &planContext{})
+	}
+	return planTarget(name, target, sources, force, newPlanContext(sources, ignore))
+}
+
+func planTarget(name string, target config.TargetConfig, sources []string, force bool, ctx *planContext) TargetPlan {
+	if target.Name() == "" {
+		target = target.WithName(name)
+	}
+	tp := TargetPlan{Name: name, Path: target.Path, Mode: target.Mode, Actions: []Action{}}
+	if tp.Mode == "" {
+		tp.Mode = "merge"
+	}
+	if format := target.OutputFormat(); format != config.FormatSkillDir {
+		tp.Format = format
+	}
+	if style := target.SymlinkStyle(); style != config.LinkAbsolute {
+		tp.LinkStyle = style
+	}
+
+	var err error
+	switch {
+	case tp.Format != "":
+		err = planGenerate(&tp, target, sources, force, ctx)
+	case tp.Mode == "copy":
+		err = planCopy(&tp, target, sources, force, ctx)
+	case tp.Mode == "symlink":
+		err = planSymlink(&tp, sources, force)
+	default:
+		err = planMerge(&tp, target, sources, force, ctx)
+	}
+	if err == nil && tp.Mode != "symlink" {
+		err = planIndex(&tp, target, sources, ctx)
+	}
+	if err != nil {
+		tp.Error = err.Error()
+		tp.Actions = []Action{}
+		tp.InSync = nil
+	}
+	return tp
+}
+
+// planConvert adds a convert action when the target is a directory symlink
+// left from symlink mode, and reports whether it did.
+func planConvert(tp *TargetPlan, sources []string) bool {
+	if !utils.IsSymlinkOrJunction(tp.Path) {
+		return false
+	}
+	reason := "to " + tp.Mode + " mode"
+	if absLink, err := utils.ResolveLinkTarget(tp.Path); err == nil && !linkToSourceRoot(absLink, sources) {
+		reason += "; link pointed to " + absLink
+	}
+	tp.Actions = append(tp.Actions, Action{Kind: ActionConvert, Reason: reason})
+	return true
+}
+
+func planMerge(tp *TargetPlan, target config.TargetConfig, sources []string, force bool, ctx *planContext) error {
+	converting := planConvert(tp, sources)
+
+	manifest, err := readPlanManifest(tp, converting)
+	if err != nil {
+		return err
+	}
+
+	skills, shadowed, err := ctx.targetSkills(target, sources)
+	if err != nil {
+		return err
+	}
+	planNameShadowed(tp, shadowed)
+
+	valid := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		valid[skill.FlatName] = true
+		targetSkillPath := filepath.Join(tp.Path, skill.FlatName)
+		link := Action{Skill: skill.FlatName, Source: skill.SourcePath, RelPath: skill.RelPath}
+
+		if converting {
+			link.Kind = ActionCreateLink
+			tp.Actions = append(tp.Actions, link)
+			continue
+		}
+
+		_, err := os.Lstat(targetSkillPath)
+		switch {
+		case os.IsNotExist(err):
+			link.Kind = ActionCreateLink
+		case err != nil:
+			return fmt.Errorf("failed to check target skill %s: %w", skill.FlatName, err)
+		case utils.IsSymlinkOrJunction(targetSkillPath):
+			absLink, err := utils.ResolveLinkTarget(targetSkillPath)
+			if err != nil {
+				link.Kind, link.Reason = ActionFixLink, "link target unreadable"
+				break
+			}
+			absSource, _ := filepath.Abs(skill.SourcePath)
+			if utils.PathsEqual(absLink, absSource) && !linkStyleMatches(targetSkillPath, skill.SourcePath, tp.LinkStyle) {
+				link.Kind, link.Reason = ActionFixLink, restyleReason(tp.LinkStyle)
+				break
+			}
+			if utils.PathsEqual(absLink, absSource) {
+				tp.InSync = append(tp.InSync, skill.FlatName)
+				if entry := manifest.Skills[skill.FlatName]; entry.Mode != EntryLink || entry.Source != skill.RelPath {
+					link.Kind = ActionAdopt
+					tp.Actions = append(tp.Actions, link)
+				}
+				continue
+			}
+			link.Kind, link.Reason = ActionFixLink, "symlink points elsewhere"
+		case force:
+			link.Kind = ActionReplaceLocal
+		case isUnchangedCopy(manifest, skill.FlatName, targetSkillPath):
+			link.Kind, link.Reason = ActionReplaceLocal, "copy from copy mode"
+		default:
+			link.Kind, link.Reason = ActionSkip, "local copy (sync --force to replace)"
+		}
+		tp.Actions = append(tp.Actions, link)
+	}
+
+	if converting {
+		return nil
+	}
+	if manifest.Missing() {
+		return planPruneLinks(tp, sources, valid)
+	}
+	planPruneManaged(tp, manifest, valid, sources)
+	planLocalEntries(tp, manifest, valid)
+	return nil
+}
+
+// readPlanManifest reads the target's manifest, or starts an empty one when
+// the target is converted from symlink mode.
+func readPlanManifest(tp *TargetPlan, converting bool) (*Manifest, error) {
+	if converting {
+		return &Manifest{Skills: map[string]ManifestEntry{}}, nil
+	}
+	return ReadManifest(tp.Path)
+}
+
+// isUnchangedCopy reports whether name is a copy skillshare made that is
+// still as it was copied.
+func isUnchangedCopy(manifest *Manifest, name, path string) bool {
+	entry, ok := manifest.Skills[name]
+	if !ok || entry.Mode != EntryCopy {
+		return false
+	}
+	hash, err := utils.HashDir(path)
+	return err == nil && hash == entry.Hash
+}
+
+// planPruneLinks plans removal of orphan entries in a merge target that has no
+// manifest yet, with a three-layer safety check:
+// 1. Symlinks into a source layer (dead or not) -> prune
+// 2. Directories with __ separator or _ prefix (skillshare-managed) -> prune
+// 3. Unknown directories and external links -> keep and warn
+func planPruneLinks(tp *TargetPlan, sources []string, valid map[string]bool) error {
+	entries, err := os.ReadDir(tp.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read target directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if utils.IsHidden(name) || valid[name] {
+			continue
+		}
+		entryPath := filepath.Join(tp.Path, name)
+		info, err := os.Lstat(entryPath)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case utils.IsSymlinkOrJunction(entryPath):
+			absLink, err := utils.ResolveLinkTarget(entryPath)
+			if err != nil {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionKeep, Skill: name, Reason: "unable to resolve link target"})
+				continue
+			}
+			if !linkIntoSources(absLink, sources) {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionKeep, Skill: name,
+					Reason: fmt.Sprintf("symlink to external location (%s)", absLink)})
+				continue
+			}
+			reason := "orphan symlink to source"
+			if _, err := os.Stat(absLink); err != nil {
+				reason = "broken symlink to source"
+			}
+			tp.Actions = append(tp.Actions, Action{Kind: ActionPrune, Skill: name, Reason: reason})
+		case info.IsDir():
+			if utils.HasNestedSeparator(name) || utils.IsTrackedRepoDir(name) {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionPrune, Skill: name, Reason: "orphan skillshare-managed directory"})
+			} else {
+				tp.Actions = append(tp.Actions, Action{Kind: ActionKeep, Skill: name, Reason: "unknown directory (not from skillshare)"})
+			}
+		}
+	}
+	return nil
+}
+
+func planCopy(tp *TargetPlan, target config.TargetConfig, sources []string, force bool, ctx *planContext) error {
+	converting := planConvert(tp, sources)
+
+	manifest, err := readPlanManifest(tp, converting)
+	if err != nil {
+		return err
+	}
+
+	skills, shadowed, err := ctx.targetSkills(target, sources)
+	if err != nil {
+		return err
+	}
+	planNameShadowed(tp, shadowed)
+
+	valid := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		valid[skill.FlatName] = true
+		targetSkillPath := filepath.Join(tp.Path, skill.FlatName)
+
+		srcHash, err := ctx.hashes.hash(skill.SourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", skill.FlatName, err)
+		}
+		copyAction := Action{Skill: skill.FlatName, Source: skill.SourcePath, RelPath: skill.RelPath, Hash: srcHash}
+
+		_, statErr := os.Lstat(targetSkillPath)
+		switch {
+		case converting || os.IsNotExist(statErr):
+			copyAction.Kind = ActionCopy
+		case statErr != nil:
+			return fmt.Errorf("failed to check target skill %s: %w", skill.FlatName, statErr)
+		case utils.IsSymlinkOrJunction(targetSkillPath):
+			// Left over from merge mode: replace our links, keep foreign links
+			if ownsLink(manifest, skill.FlatName, targetSkillPath, sources) || force {
+				copyAction.Kind, copyAction.Reason = ActionUpdateCopy, "replace symlink with copy"
+			} else {
+				copyAction.Kind, copyAction.Reason = ActionSkip, "foreign symlink (sync --force to replace)"
+			}
+		default:
+			entry, managed := manifest.Skills[skill.FlatName]
+			if !managed || entry.Mode != EntryCopy {
+				if force {
+					copyAction.Kind, copyAction.Reason = ActionUpdateCopy, "overwrite unmanaged directory"
+				} else {
+					copyAction.Kind, copyAction.Reason = ActionSkip, "local directory with the same name (sync --force to overwrite)"
+				}
+				break
+			}
+			dstHash, err := utils.HashDir(targetSkillPath)
+			if err != nil {
+				return fmt.Errorf("failed to hash target copy %s: %w", skill.FlatName, err)
+			}
+			switch {
+			case dstHash != entry.Hash && !force:
+				copyAction.Kind, copyAction.Reason = ActionSkip, "edited in target (sync --force to overwrite)"
+			case dstHash == srcHash:
+				tp.InSync = append(tp.InSync, skill.FlatName)
+				continue
+			case dstHash != entry.Hash:
+				copyAction.Kind, copyAction.Reason = ActionUpdateCopy, "overwrite edits in target"
+			default:
+				copyAction.Kind, copyAction.Reason = ActionUpdateCopy, "source changed"
+			}
+		}
+		tp.Actions = append(tp.Actions, copyAction)
+	}
+
+	if converting {
+		return nil
+	}
+
+	planPruneManaged(tp, manifest, valid, sources)
+	planLocalEntries(tp, manifest, valid)
+	return nil
+}
+
+// ownsLink reports whether the link at path is one skillshare made: recorded
+// in the manifest or, for targets without one, pointing into a source layer.
+func ownsLink(manifest *Manifest, name, path string, sources []string) bool {
+	if manifest.Missing() {
+		absLink, err := utils.ResolveLinkTarget(path)
+		return err == nil && linkIntoSources(absLink, sources)
+	}
+	return manifest.Skills[name].Mode == EntryLink
+}
+
+func planSymlink(tp *TargetPlan, sources []string, force bool) error {
+	if len(sources) > 1 {
+		return fmt.Errorf("symlink mode needs a single source; use merge or copy mode with layered sources")
+	}
+	source := sources[0]
+
+	switch status := CheckStatus(tp.Path, source); status {
+	case StatusLinked:
+		if !linkStyleMatches(tp.Path, source, tp.LinkStyle) {
+			tp.Actions = append(tp.Actions, Action{Kind: ActionRelink, Source: source, Reason: restyleReason(tp.LinkStyle)})
+		}
+	case StatusNotExist:
+		tp.Actions = append(tp.Actions, Action{Kind: ActionLinkTarget, Source: source})
+	case StatusHasFiles:
+		tp.Actions = append(tp.Actions, Action{Kind: ActionMigrate, Source: source})
+	case StatusBroken:
+		tp.Actions = append(tp.Actions, Action{Kind: ActionRelink, Source: source, Reason: "broken symlink"})
+	case StatusConflict:
+		link, err := utils.ResolveLinkTarget(tp.Path)
+		if err != nil {
+			link = "(unable to resolve target)"
+		}
+		if !force {
+			return fmt.Errorf("conflict - symlink points to %s (use --force to override)", link)
+		}
+		tp.Actions = append(tp.Actions, Action{Kind: ActionRelink, Source: source, Reason: "symlink pointed to " + link})
+	default:
+		return fmt.Errorf("unknown target status: %s", status)
+	}
+	return nil
+}
+
+// ReadPlan loads a plan saved with 'sync --plan-json'.
+func ReadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", path, err)
+	}
+	if p.Version != PlanVersion {
+		return nil, fmt.Errorf("plan %s has version %d, expected %d", path, p.Version, PlanVersion)
+	}
+	return &p, nil
+}
+
+// CheckCurrent compares a saved plan with a fresh plan of the same targets and
+// returns an error describing the first difference, so a plan is only applied
+// while the source and targets are as they were when it was made.
+func (p *Plan) CheckCurrent(current *Plan) error {
+	if !equalStrings(p.Sources, current.Sources) {
+		return fmt.Errorf("plan is stale: sources changed")
+	}
+	if len(p.Targets) != len(current.Targets) {
+		return fmt.Errorf("plan is stale: targets changed")
+	}
+	for i, saved := range p.Targets {
+		now := current.Targets[i]
+		if saved.Name != now.Name || saved.Path != now.Path || saved.Mode != now.Mode || saved.Format != now.Format || saved.Index != now.Index || saved.LinkStyle != now.LinkStyle {
+			return fmt.Errorf("plan is stale: target %s changed", saved.Name)
+		}
+		if now.Error != "" {
+			return fmt.Errorf("plan is stale: %s: %s", now.Name, now.Error)
+		}
+		a, b := saved.Changes(), now.Changes()
+		for j := 0; j < max(len(a), len(b)); j++ {
+			switch {
+			case j >= len(a):
+				return fmt.Errorf("plan is stale: %s: would now %s", now.Name, b[j].Describe(now.Path))
+			case j >= len(b) || !a[j].samePlanned(b[j]):
+				return fmt.Errorf("plan is stale: %s: planned to %s", saved.Name, a[j].Describe(saved.Path))
+			}
+		}
+	}
+	return nil
+}
+
+// samePlanned compares two actions as saved in a plan file, where the
+// rendered content of format actions is not kept.
+func (a Action) samePlanned(b Action) bool {
+	a.content, b.content = "", ""
+	return a == b
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
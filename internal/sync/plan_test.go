@@ -0,0 +1,217 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func planKinds(tp TargetPlan) map[string]ActionKind {
+	kinds := make(map[string]ActionKind)
+	for _, a := range tp.Actions {
+		kinds[a.Skill] = a.Kind
+	}
+	return kinds
+}
+
+func TestPlanTarget_Merge(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	writeLayerSkill(t, source, "linked")
+	writeLayerSkill(t, source, "missing")
+	writeLayerSkill(t, source, "copied")
+	writeLayerSkill(t, target, "copied")
+	writeLayerSkill(t, target, "mine")
+	if err := os.Symlink(filepath.Join(source, "linked"), filepath.Join(target, "linked")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(source, "gone"), filepath.Join(target, "gone")); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.TargetConfig{Path: target, Mode: "merge"}
+	tests := []struct {
+		force bool
+		want  map[string]ActionKind
+	}{
+		// No manifest yet: the existing link is adopted, orphans found by heuristics
+		{false, map[string]ActionKind{"linked": ActionAdopt, "missing": ActionCreateLink, "copied": ActionSkip, "gone": ActionPrune, "mine": ActionKeep}},
+		{true, map[string]ActionKind{"linked": ActionAdopt, "missing": ActionCreateLink, "copied": ActionReplaceLocal, "gone": ActionPrune, "mine": ActionKeep}},
+	}
+	for _, tt := range tests {
+		tp := PlanTarget("claude", cfg, []string{source}, tt.force)
+		if tp.Error != "" {
+			t.Fatalf("force=%v: unexpected error %s", tt.force, tp.Error)
+		}
+		got := planKinds(tp)
+		if len(got) != len(tt.want) {
+			t.Errorf("force=%v: actions = %v, want %v", tt.force, got, tt.want)
+		}
+		for skill, kind := range tt.want {
+			if got[skill] != kind {
+				t.Errorf("force=%v: %s = %q, want %q", tt.force, skill, got[skill], kind)
+			}
+		}
+		if len(tp.InSync) != 1 || tp.InSync[0] != "linked" {
+			t.Errorf("force=%v: InSync = %v, want [linked]", tt.force, tp.InSync)
+		}
+	}
+
+	// Planning must not touch the target
+	if _, err := os.Lstat(filepath.Join(target, "missing")); !os.IsNotExist(err) {
+		t.Error("planning created a link")
+	}
+}
+
+func TestPlanTarget_MergeManifest(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	writeLayerSkill(t, source, "keep")
+	writeLayerSkill(t, source, "dropped")
+	cfg := config.TargetConfig{Path: target, Mode: "merge"}
+
+	tp := PlanTarget("claude", cfg, []string{source}, false)
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ReadManifest(target)
+	if err != nil || manifest.Missing() {
+		t.Fatalf("ReadManifest() = %v, %v; want manifest written", manifest, err)
+	}
+	if entry := manifest.Skills["keep"]; entry.Mode != EntryLink || entry.Source != "keep" {
+		t.Errorf("manifest entry = %+v", entry)
+	}
+
+	// Names alone no longer decide ownership: a user directory with the
+	// nested separator and a hand-made link into source stay
+	if err := os.RemoveAll(filepath.Join(source, "dropped")); err != nil {
+		t.Fatal(err)
+	}
+	writeLayerSkill(t, target, "team__mine")
+	if err := os.Symlink(filepath.Join(source, "keep"), filepath.Join(target, "by-hand")); err != nil {
+		t.Fatal(err)
+	}
+
+	tp = PlanTarget("claude", cfg, []string{source}, false)
+	want := map[string]ActionKind{"dropped": ActionPrune, "team__mine": ActionLocal, "by-hand": ActionLocal}
+	got := planKinds(tp)
+	if len(got) != len(want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+	for skill, kind := range want {
+		if got[skill] != kind {
+			t.Errorf("%s = %q, want %q", skill, got[skill], kind)
+		}
+	}
+}
+
+func TestPlanTarget_MergeManifestRepointedLink(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	mine := t.TempDir()
+	writeLayerSkill(t, source, "dropped")
+	writeLayerSkill(t, mine, "dropped")
+	cfg := config.TargetConfig{Path: target, Mode: "merge"}
+
+	tp := PlanTarget("claude", cfg, []string{source}, false)
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The skill leaves source while the user points its link elsewhere
+	if err := os.RemoveAll(filepath.Join(source, "dropped")); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(target, "dropped")
+	if err := os.Remove(link); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(mine, "dropped"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	tp = PlanTarget("claude", cfg, []string{source}, false)
+	if got := planKinds(tp); len(got) != 1 || got["dropped"] != ActionForget {
+		t.Fatalf("actions = %v, want dropped forgotten", got)
+	}
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	if dest, _ := os.Readlink(link); dest != filepath.Join(mine, "dropped") {
+		t.Errorf("repointed link should be kept, got %q", dest)
+	}
+	if manifest, _ := ReadManifest(target); manifest.Owns("dropped") {
+		t.Error("repointed link should be dropped from the manifest")
+	}
+	if got := planKinds(PlanTarget("claude", cfg, []string{source}, false)); got["dropped"] != ActionLocal {
+		t.Errorf("repointed link should then be local, got %v", got)
+	}
+}
+
+func TestApplyTarget_CopyThenInSync(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	writeLayerSkill(t, source, "alpha")
+	cfg := config.TargetConfig{Path: target, Mode: "copy"}
+
+	tp := PlanTarget("cursor", cfg, []string{source}, false)
+	if got := planKinds(tp); got["alpha"] != ActionCopy {
+		t.Fatalf("actions = %v, want alpha copied", got)
+	}
+	if _, err := ApplyTarget(tp, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "alpha", "SKILL.md")); err != nil {
+		t.Fatalf("copy missing: %v", err)
+	}
+
+	tp = PlanTarget("cursor", cfg, []string{source}, false)
+	if len(tp.Changes()) != 0 {
+		t.Errorf("expected no changes after apply, got %v", tp.Changes())
+	}
+
+	if err := os.WriteFile(filepath.Join(source, "alpha", "SKILL.md"), []byte("# edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tp = PlanTarget("cursor", cfg, []string{source}, false)
+	if got := planKinds(tp); got["alpha"] != ActionUpdateCopy {
+		t.Errorf("actions = %v, want alpha updated", got)
+	}
+}
+
+func TestPlanCheckCurrent(t *testing.T) {
+	source := t.TempDir()
+	target := filepath.Join(t.TempDir(), "skills")
+	writeLayerSkill(t, source, "alpha")
+	targets := map[string]config.TargetConfig{"claude": {Path: target}}
+
+	saved := NewPlan(targets, "merge", []string{source}, false)
+	if err := saved.CheckCurrent(NewPlan(targets, "merge", []string{source}, false)); err != nil {
+		t.Fatalf("unchanged plan reported stale: %v", err)
+	}
+
+	writeLayerSkill(t, source, "beta")
+	err := saved.CheckCurrent(NewPlan(targets, "merge", []string{source}, false))
+	if err == nil || !strings.Contains(err.Error(), "plan is stale") {
+		t.Errorf("CheckCurrent() = %v, want stale error", err)
+	}
+}
+
+func TestTargetPlanOnly(t *testing.T) {
+	tp := TargetPlan{
+		Actions: []Action{{Kind: ActionCreateLink, Skill: "a"}, {Kind: ActionPrune, Skill: "b"}},
+		InSync:  []string{"c", "d"},
+	}
+	got := tp.Only([]string{"b", "d"})
+	if len(got.Actions) != 1 || got.Actions[0].Skill != "b" || len(got.InSync) != 1 || got.InSync[0] != "d" {
+		t.Errorf("Only() = %+v", got)
+	}
+
+	tp.Actions = append([]Action{{Kind: ActionConvert}}, tp.Actions...)
+	if got := tp.Only([]string{"b"}); len(got.Actions) != 3 {
+		t.Errorf("converting plan should be kept whole, got %+v", got.Actions)
+	}
+}
@@ -60,12 +60,15 @@ func FindLocalSkills(targetPath, sourcePath string) ([]LocalSkillInfo, error) {
 		return skills, nil
 	}
 
-	// Target is a directory (merge mode) - scan for local skills
+	// Target is a directory (merge or copy mode) - scan for local skills
 	entries, err := os.ReadDir(targetPath)
 	if err != nil {
 		return nil, err
 	}
 
+	// Entries in the manifest were synced from source, not local
+	manifest, _ := ReadManifest(targetPath)
+
 	for _, entry := range entries {
 		// Skip hidden files/directories
 		if utils.IsHidden(entry.Name()) {
@@ -88,6 +91,10 @@ func FindLocalSkills(targetPath, sourcePath string) ([]LocalSkillInfo, error) {
 			continue
 		}
 
+		if manifest != nil && manifest.Owns(entry.Name()) {
+			continue
+		}
+
 		// This is a local skill
 		skills = append(skills, LocalSkillInfo{
 			Name:    entry.Name(),
@@ -6,6 +6,9 @@ import (
 	"os"
 )
 
+// relativeLinks reports whether links can hold relative paths.
+const relativeLinks = true
+
 // createLink creates a symlink on Unix systems
 func createLink(targetPath, sourcePath string) error {
 	return os.Symlink(sourcePath, targetPath)
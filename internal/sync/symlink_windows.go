@@ -12,9 +12,18 @@ import (
 	"strings"
 )
 
+// relativeLinks reports whether links can hold relative paths. Junctions
+// always hold an absolute path, so link_style: relative has no effect.
+const relativeLinks = false
+
 // createLink creates a directory junction on Windows (no admin required).
-// Falls back to symlink if junction fails.
+// Falls back to symlink if junction fails. A relative sourcePath is taken
+// relative to the link's directory.
 func createLink(targetPath, sourcePath string) error {
+	if !filepath.IsAbs(sourcePath) {
+		sourcePath = filepath.Join(filepath.Dir(targetPath), sourcePath)
+	}
+
 	// Ensure absolute paths for junction
 	absSource, err := filepath.Abs(sourcePath)
 	if err != nil {
@@ -13,17 +13,90 @@ import (
 
 // DiscoveredSkill represents a skill found during recursive source directory scan.
 type DiscoveredSkill struct {
-	SourcePath string // Full path: ~/.config/skillshare/skills/_team/frontend/ui
-	RelPath    string // Relative path from source: _team/frontend/ui
-	FlatName   string // Flat name for target: _team__frontend__ui
-	IsInRepo   bool   // Whether this skill is inside a tracked repo (_-prefixed directory)
+	SourcePath string         // Full path: ~/.config/skillshare/skills/_team/frontend/ui
+	RelPath    string         // Relative path from source: _team/frontend/ui
+	FlatName   string         // Flat name for target: _team__frontend__ui
+	IsInRepo   bool           // Whether this skill is inside a tracked repo (_-prefixed directory)
+	Layer      string         // Source directory (layer) the skill was found in
+	Placement  SkillPlacement // Frontmatter targets:, exclude_targets: and os:
+}
+
+// ShadowedSkill is a skill hidden by a skill with the same flat name in a later source layer.
+type ShadowedSkill struct {
+	DiscoveredSkill
+	ShadowedBy string // Layer holding the winning copy
 }
 
 // DiscoverSourceSkills recursively scans the source directory for skills.
 // A skill is identified by the presence of a SKILL.md file.
+// Skills matched by the given gitignore-style patterns (config `ignore:`) or by
+// a .skillshareignore file are left out.
 // Returns all discovered skills with their metadata for syncing.
-func DiscoverSourceSkills(sourcePath string) ([]DiscoveredSkill, error) {
+func DiscoverSourceSkills(sourcePath string, ignore ...string) ([]DiscoveredSkill, error) {
+	skills, _, err := discoverSkills(sourcePath, ignore)
+	return skills, err
+}
+
+// DiscoverIgnoredSkills returns the skills that DiscoverSourceSkills would leave
+// out, together with the pattern that excluded each one.
+func DiscoverIgnoredSkills(sourcePath string, ignore ...string) ([]IgnoredSkill, error) {
+	_, ignored, err := discoverSkills(sourcePath, ignore)
+	return ignored, err
+}
+
+// DiscoverLayeredSkills scans each source layer in order. Later layers override
+// earlier ones by flat name; the overridden copies are returned as shadowed.
+// Every returned skill records the layer it came from.
+func DiscoverLayeredSkills(sources []string, ignore ...string) ([]DiscoveredSkill, []ShadowedSkill, error) {
+	var skills []DiscoveredSkill
+	var shadowed []ShadowedSkill
+	index := make(map[string]int)
+
+	for _, src := range sources {
+		found, _, err := discoverSkills(src, ignore)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, skill := range found {
+			if i, ok := index[skill.FlatName]; ok {
+				shadowed = append(shadowed, ShadowedSkill{DiscoveredSkill: skills[i], ShadowedBy: src})
+				skills[i] = skill
+				continue
+			}
+			index[skill.FlatName] = len(skills)
+			skills = append(skills, skill)
+		}
+	}
+
+	return skills, shadowed, nil
+}
+
+// linkIntoSources reports whether absLink is a source layer or lies inside one.
+func linkIntoSources(absLink string, sources []string) bool {
+	for _, src := range sources {
+		absSource, _ := filepath.Abs(src)
+		if utils.PathsEqual(absLink, absSource) || utils.PathHasPrefix(absLink, absSource+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkToSourceRoot reports whether absLink is the root of a source layer (symlink mode).
+func linkToSourceRoot(absLink string, sources []string) bool {
+	for _, src := range sources {
+		absSource, _ := filepath.Abs(src)
+		if utils.PathsEqual(absLink, absSource) {
+			return true
+		}
+	}
+	return false
+}
+
+func discoverSkills(sourcePath string, ignore []string) ([]DiscoveredSkill, []IgnoredSkill, error) {
 	var skills []DiscoveredSkill
+	var ignored []IgnoredSkill
+	matcher := NewIgnoreMatcher(sourcePath, ignore)
 
 	err := filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -52,6 +125,14 @@ func DiscoverSourceSkills(sourcePath string) ([]DiscoveredSkill, error) {
 			// Normalize path separators
 			relPath = strings.ReplaceAll(relPath, "\\", "/")
 
+			if isIgnored, match := matcher.Match(relPath); isIgnored {
+				match.SourcePath = skillDir
+				match.RelPath = relPath
+				match.FlatName = utils.PathToFlatName(relPath)
+				ignored = append(ignored, match)
+				return nil
+			}
+
 			// Check if this skill is inside a tracked repo
 			isInRepo := false
 			parts := strings.Split(relPath, "/")
@@ -64,6 +145,8 @@ func DiscoverSourceSkills(sourcePath string) ([]DiscoveredSkill, error) {
 				RelPath:    relPath,
 				FlatName:   utils.PathToFlatName(relPath),
 				IsInRepo:   isInRepo,
+				Layer:      sourcePath,
+				Placement:  readSkillPlacement(skillDir),
 			})
 		}
 
@@ -71,23 +154,25 @@ func DiscoverSourceSkills(sourcePath string) ([]DiscoveredSkill, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to walk source directory: %w", err)
+		return nil, nil, fmt.Errorf("failed to walk source directory: %w", err)
 	}
 
-	return skills, nil
+	return skills, ignored, nil
 }
 
 // TargetStatus represents the state of a target
 type TargetStatus int
 
 const (
-	StatusUnknown  TargetStatus = iota
-	StatusLinked                // Target is a symlink pointing to source
-	StatusNotExist              // Target doesn't exist
-	StatusHasFiles              // Target exists with files (needs migration)
-	StatusConflict              // Target is a symlink pointing elsewhere
-	StatusBroken                // Target is a broken symlink
-	StatusMerged                // Target uses merge mode (individual skill symlinks)
+	StatusUnknown   TargetStatus = iota
+	StatusLinked                 // Target is a symlink pointing to source
+	StatusNotExist               // Target doesn't exist
+	StatusHasFiles               // Target exists with files (needs migration)
+	StatusConflict               // Target is a symlink pointing elsewhere
+	StatusBroken                 // Target is a broken symlink
+	StatusMerged                 // Target uses merge mode (individual skill symlinks)
+	StatusCopied                 // Target uses copy mode (manifest-tracked physical copies)
+	StatusGenerated              // Target holds files rendered in a non-skill-dir format
 )
 
 func (s TargetStatus) String() string {
@@ -104,6 +189,10 @@ func (s TargetStatus) String() string {
 		return "broken"
 	case StatusMerged:
 		return "merged"
+	case StatusCopied:
+		return "copied"
+	case StatusGenerated:
+		return "generated"
 	default:
 		return "unknown"
 	}
@@ -195,52 +284,6 @@ func CreateSymlink(targetPath, sourcePath string) error {
 	return nil
 }
 
-// SyncTarget performs the sync operation for a single target
-func SyncTarget(name string, target config.TargetConfig, sourcePath string, dryRun bool) error {
-	status := CheckStatus(target.Path, sourcePath)
-
-	switch status {
-	case StatusLinked:
-		// Already correct
-		return nil
-
-	case StatusNotExist:
-		if dryRun {
-			fmt.Printf("[dry-run] Would create symlink: %s -> %s\n", target.Path, sourcePath)
-			return nil
-		}
-		return CreateSymlink(target.Path, sourcePath)
-
-	case StatusHasFiles:
-		if dryRun {
-			fmt.Printf("[dry-run] Would migrate files from %s to %s, then create symlink\n", target.Path, sourcePath)
-			return nil
-		}
-		if err := MigrateToSource(target.Path, sourcePath); err != nil {
-			return err
-		}
-		return CreateSymlink(target.Path, sourcePath)
-
-	case StatusConflict:
-		link, err := utils.ResolveLinkTarget(target.Path)
-		if err != nil {
-			link = "(unable to resolve target)"
-		}
-		return fmt.Errorf("target is symlink to different location: %s -> %s", target.Path, link)
-
-	case StatusBroken:
-		if dryRun {
-			fmt.Printf("[dry-run] Would remove broken symlink and recreate: %s\n", target.Path)
-			return nil
-		}
-		os.Remove(target.Path)
-		return CreateSymlink(target.Path, sourcePath)
-
-	default:
-		return fmt.Errorf("unknown target status: %s", status)
-	}
-}
-
 // mergeDirectories copies files from src to dst, skipping existing files
 func mergeDirectories(src, dst string) error {
 	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
@@ -306,231 +349,6 @@ func copyFile(src, dst string) error {
 	return err
 }
 
-// MergeResult holds the result of a merge sync operation
-type MergeResult struct {
-	Linked  []string // Skills that were symlinked
-	Skipped []string // Skills that already exist in target (kept local)
-	Updated []string // Skills that had broken symlinks fixed
-}
-
-// SyncTargetMerge performs merge mode sync - creates symlinks for each skill individually
-// while preserving target-specific skills.
-// Supports nested skills: source path "personal/writing/email" becomes target symlink "personal__writing__email"
-// If force is true, local copies will be replaced with symlinks.
-func SyncTargetMerge(name string, target config.TargetConfig, sourcePath string, dryRun, force bool) (*MergeResult, error) {
-	result := &MergeResult{}
-
-	// Check if target is currently a symlink/junction (symlink mode) - need to convert to merge mode
-	info, err := os.Lstat(target.Path)
-	if err == nil && info != nil && utils.IsSymlinkOrJunction(target.Path) {
-		// Target is a symlink - remove it to convert to merge mode
-		if dryRun {
-			fmt.Printf("[dry-run] Would convert from symlink mode to merge mode: %s\n", target.Path)
-		} else {
-			if err := os.Remove(target.Path); err != nil {
-				return nil, fmt.Errorf("failed to remove symlink for merge conversion: %w", err)
-			}
-		}
-	}
-
-	// Ensure target directory exists
-	if !dryRun {
-		if err := os.MkdirAll(target.Path, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create target directory: %w", err)
-		}
-	}
-
-	// Discover all skills recursively from source
-	discoveredSkills, err := DiscoverSourceSkills(sourcePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to discover skills: %w", err)
-	}
-
-	for _, skill := range discoveredSkills {
-		// Use flat name in target (e.g., "personal__writing__email")
-		targetSkillPath := filepath.Join(target.Path, skill.FlatName)
-
-		// Check if skill exists in target
-		_, err := os.Lstat(targetSkillPath)
-		if err == nil {
-			// Something exists at target path
-			if utils.IsSymlinkOrJunction(targetSkillPath) {
-				// It's a symlink/junction - check if it points to source
-				absLink, err := utils.ResolveLinkTarget(targetSkillPath)
-				if err != nil {
-					return nil, fmt.Errorf("failed to resolve link target for %s: %w", skill.FlatName, err)
-				}
-				absSource, _ := filepath.Abs(skill.SourcePath)
-
-				if utils.PathsEqual(absLink, absSource) {
-					// Already correctly linked
-					result.Linked = append(result.Linked, skill.FlatName)
-					continue
-				}
-
-				// Symlink points elsewhere - broken or wrong
-				if dryRun {
-					fmt.Printf("[dry-run] Would fix symlink: %s\n", skill.FlatName)
-				} else {
-					os.Remove(targetSkillPath)
-					if err := createLink(targetSkillPath, skill.SourcePath); err != nil {
-						return nil, fmt.Errorf("failed to create link for %s: %w", skill.FlatName, err)
-					}
-				}
-				result.Updated = append(result.Updated, skill.FlatName)
-			} else {
-				// It's a real directory
-				if force {
-					// Force: replace local copy with symlink
-					if dryRun {
-						fmt.Printf("[dry-run] Would replace local copy: %s\n", skill.FlatName)
-					} else {
-						if err := os.RemoveAll(targetSkillPath); err != nil {
-							return nil, fmt.Errorf("failed to remove local copy %s: %w", skill.FlatName, err)
-						}
-						if err := createLink(targetSkillPath, skill.SourcePath); err != nil {
-							return nil, fmt.Errorf("failed to create link for %s: %w", skill.FlatName, err)
-						}
-					}
-					result.Updated = append(result.Updated, skill.FlatName)
-				} else {
-					// Preserve local skill
-					result.Skipped = append(result.Skipped, skill.FlatName)
-				}
-			}
-		} else if os.IsNotExist(err) {
-			// Doesn't exist - create link
-			if dryRun {
-				fmt.Printf("[dry-run] Would create link: %s -> %s\n", targetSkillPath, skill.SourcePath)
-			} else {
-				if err := createLink(targetSkillPath, skill.SourcePath); err != nil {
-					return nil, fmt.Errorf("failed to create link for %s: %w", skill.FlatName, err)
-				}
-			}
-			result.Linked = append(result.Linked, skill.FlatName)
-		} else {
-			return nil, fmt.Errorf("failed to check target skill %s: %w", skill.FlatName, err)
-		}
-	}
-
-	return result, nil
-}
-
-// PruneResult holds the result of a prune operation
-type PruneResult struct {
-	Removed  []string // Items that were removed
-	Warnings []string // Items that were kept with warnings
-}
-
-// PruneOrphanLinks removes orphan symlinks from target that no longer exist in source.
-// Uses a three-layer safety check:
-// 1. Dead symlinks pointing to source directory -> remove
-// 2. Directories with __ separator or @ prefix (skillshare-managed) -> remove if orphan
-// 3. Unknown directories -> keep and warn
-func PruneOrphanLinks(targetPath, sourcePath string, dryRun bool) (*PruneResult, error) {
-	result := &PruneResult{}
-
-	// Get current valid skills from source
-	discoveredSkills, err := DiscoverSourceSkills(sourcePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to discover skills for pruning: %w", err)
-	}
-
-	// Build a set of valid flat names
-	validFlatNames := make(map[string]bool)
-	for _, skill := range discoveredSkills {
-		validFlatNames[skill.FlatName] = true
-	}
-
-	// Scan target directory
-	entries, err := os.ReadDir(targetPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return result, nil // Target doesn't exist, nothing to prune
-		}
-		return nil, fmt.Errorf("failed to read target directory: %w", err)
-	}
-
-	absSource, _ := filepath.Abs(sourcePath)
-
-	for _, entry := range entries {
-		name := entry.Name()
-
-		// Skip hidden files
-		if utils.IsHidden(name) {
-			continue
-		}
-
-		entryPath := filepath.Join(targetPath, name)
-		info, err := os.Lstat(entryPath)
-		if err != nil {
-			continue
-		}
-
-		// Check if this entry is still valid
-		if validFlatNames[name] {
-			continue // Still exists in source, keep it
-		}
-
-		// Entry is orphan - determine if we should remove it
-		shouldRemove := false
-		reason := ""
-
-		if utils.IsSymlinkOrJunction(entryPath) {
-			absLink, err := utils.ResolveLinkTarget(entryPath)
-			if err != nil {
-				result.Warnings = append(result.Warnings,
-					fmt.Sprintf("%s: unable to resolve link target, kept", name))
-				continue
-			}
-
-			targetExists := false
-			if _, err := os.Stat(absLink); err == nil {
-				targetExists = true
-			}
-
-			if strings.HasPrefix(absLink, absSource+string(filepath.Separator)) {
-				if !targetExists {
-					shouldRemove = true
-					reason = "broken symlink to source"
-				} else {
-					shouldRemove = true
-					reason = "orphan symlink to source"
-				}
-			} else {
-				result.Warnings = append(result.Warnings,
-					fmt.Sprintf("%s: symlink to external location (%s), kept", name, absLink))
-			}
-		} else if info.IsDir() {
-			// It's a directory - check naming characteristics
-			// Safety check 2: Only remove if it has skillshare naming patterns
-			if utils.HasNestedSeparator(name) || utils.IsTrackedRepoDir(name) {
-				shouldRemove = true
-				reason = "orphan skillshare-managed directory"
-			} else {
-				// Safety check 3: Unknown directory - warn and keep
-				result.Warnings = append(result.Warnings,
-					fmt.Sprintf("%s: unknown directory (not from skillshare), kept", name))
-			}
-		}
-
-		if shouldRemove {
-			if dryRun {
-				fmt.Printf("[dry-run] Would remove %s: %s\n", reason, entryPath)
-			} else {
-				if err := os.RemoveAll(entryPath); err != nil {
-					result.Warnings = append(result.Warnings,
-						fmt.Sprintf("%s: failed to remove: %v", name, err))
-					continue
-				}
-			}
-			result.Removed = append(result.Removed, name)
-		}
-	}
-
-	return result, nil
-}
-
 // NameCollision represents a conflict where multiple skills share the same name
 type NameCollision struct {
 	Name  string   // The conflicting SKILL.md name
@@ -566,9 +384,15 @@ func CheckNameCollisions(skills []DiscoveredSkill) []NameCollision {
 	return collisions
 }
 
-// CheckStatusMerge checks the status of a target in merge mode
-func CheckStatusMerge(targetPath, sourcePath string) (TargetStatus, int, int) {
+// CheckStatusMerge checks the status of a target in merge mode.
+// Only links to skills the target should receive (after ignore and
+// include/exclude) are counted as linked; other links skillshare made are
+// awaiting prune and are not counted. Entries are skillshare's when the
+// target's manifest lists them, or for targets without a manifest yet, when
+// they link into a source layer.
+func CheckStatusMerge(target config.TargetConfig, sources []string, ignore ...string) (TargetStatus, int, int) {
 	// Returns: status, linked count, local count
+	targetPath := target.Path
 
 	info, err := os.Lstat(targetPath)
 	if err != nil {
@@ -584,8 +408,7 @@ func CheckStatusMerge(targetPath, sourcePath string) (TargetStatus, int, int) {
 		if err != nil {
 			return StatusUnknown, 0, 0
 		}
-		absSource, _ := filepath.Abs(sourcePath)
-		if utils.PathsEqual(absLink, absSource) {
+		if linkToSourceRoot(absLink, sources) {
 			return StatusLinked, 0, 0
 		}
 		return StatusConflict, 0, 0
@@ -595,6 +418,18 @@ func CheckStatusMerge(targetPath, sourcePath string) (TargetStatus, int, int) {
 		return StatusUnknown, 0, 0
 	}
 
+	wanted := make(map[string]bool)
+	if skills, err := DiscoverTargetSkills(target, sources, ignore...); err == nil {
+		for _, skill := range skills {
+			wanted[skill.FlatName] = true
+		}
+	}
+
+	manifest, err := ReadManifest(targetPath)
+	if err != nil {
+		return StatusUnknown, 0, 0
+	}
+
 	// Count linked vs local skills
 	linkedCount := 0
 	localCount := 0
@@ -606,6 +441,16 @@ func CheckStatusMerge(targetPath, sourcePath string) (TargetStatus, int, int) {
 		}
 		skillPath := filepath.Join(targetPath, entry.Name())
 
+		if !manifest.Missing() {
+			switch {
+			case !manifest.Owns(entry.Name()):
+				localCount++
+			case manifest.Skills[entry.Name()].Mode == EntryLink && utils.IsSymlinkOrJunction(skillPath) && wanted[entry.Name()]:
+				linkedCount++
+			}
+			continue
+		}
+
 		if utils.IsSymlinkOrJunction(skillPath) {
 			// It's a symlink/junction - check if it points to somewhere in source
 			absLink, err := utils.ResolveLinkTarget(skillPath)
@@ -613,11 +458,11 @@ func CheckStatusMerge(targetPath, sourcePath string) (TargetStatus, int, int) {
 				localCount++
 				continue
 			}
-			absSource, _ := filepath.Abs(sourcePath)
-
-			// Check if the symlink target is within the source directory
-			if utils.PathHasPrefix(absLink, absSource+string(filepath.Separator)) || utils.PathsEqual(absLink, absSource) {
-				linkedCount++
+			// Check if the symlink target is within a source layer
+			if linkIntoSources(absLink, sources) {
+				if wanted[entry.Name()] {
+					linkedCount++
+				}
 			} else {
 				localCount++
 			}
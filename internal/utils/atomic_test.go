@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("old"), 0644)
+
+	if err := WriteFileAtomic(path, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "new" {
+		t.Errorf("content = %q, want new", got)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temp file left behind: %v", entries)
+	}
+}
+
+func TestWriteFileAtomic_FollowsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	real := filepath.Join(dir, "dotfiles", "config.yaml")
+	os.MkdirAll(filepath.Dir(real), 0755)
+	os.WriteFile(real, []byte("old"), 0644)
+	link := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(real, link); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+
+	if err := WriteFileAtomic(link, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Lstat(link); info.Mode()&os.ModeSymlink == 0 {
+		t.Error("symlink should be kept")
+	}
+	if got, _ := os.ReadFile(real); string(got) != "new" {
+		t.Errorf("link target content = %q, want new", got)
+	}
+}
+
+func TestWriteFileAtomic_KeepsExistingMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("token: ${HUB_TOKEN}"), 0600)
+
+	if err := WriteFileAtomic(path, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600 kept", info.Mode().Perm())
+	}
+
+	fresh := filepath.Join(dir, "new.yaml")
+	if err := WriteFileAtomic(fresh, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(fresh); info.Mode().Perm() != 0644 {
+		t.Errorf("new file mode = %v, want 0644", info.Mode().Perm())
+	}
+}
@@ -2,9 +2,12 @@ package utils
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // ParseSkillName reads the SKILL.md and extracts the "name" from frontmatter.
@@ -111,3 +114,71 @@ func ParseFrontmatterField(filePath, field string) string {
 
 	return ""
 }
+
+// SplitFrontmatter splits a SKILL.md's text into its YAML frontmatter and
+// the body after the closing ---. ok is false when the text does not open
+// with a frontmatter block; the whole text is then the body.
+func SplitFrontmatter(text string) (frontmatter, body string, ok bool) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return "", text, false
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", text, false
+	}
+	body = rest[end+len("\n---"):]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return rest[:end], body, true
+}
+
+// DecodeFrontmatter decodes the YAML frontmatter of a SKILL.md's text into
+// out and returns the body. Text without frontmatter leaves out unchanged.
+func DecodeFrontmatter(text string, out any) (string, error) {
+	frontmatter, body, ok := SplitFrontmatter(text)
+	if !ok {
+		return body, nil
+	}
+	return body, yaml.Unmarshal([]byte(frontmatter), out)
+}
+
+// ReadFrontmatter decodes the YAML frontmatter of the file at filePath into out.
+func ReadFrontmatter(filePath string, out any) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	_, err = DecodeFrontmatter(string(data), out)
+	return err
+}
+
+// FrontmatterList is a frontmatter field holding a list of names: a YAML
+// list, or a scalar of comma-separated values. Empty entries are dropped.
+type FrontmatterList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *FrontmatterList) UnmarshalYAML(node *yaml.Node) error {
+	var items []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		items = strings.Split(node.Value, ",")
+	case yaml.SequenceNode:
+		if err := node.Decode(&items); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("line %d: expected a list or a comma-separated value", node.Line)
+	}
+	*l = nil
+	for _, item := range items {
+		if v := strings.TrimSpace(item); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
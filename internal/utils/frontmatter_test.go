@@ -3,6 +3,7 @@ package utils
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -144,3 +145,54 @@ func TestParseFrontmatterField_FileNotExist(t *testing.T) {
 		t.Errorf("expected empty string for non-existent file, got %q", got)
 	}
 }
+
+func TestReadFrontmatter_Lists(t *testing.T) {
+	content := `---
+name: my-skill
+description: "Works with: colons"
+targets: [claude, "cursor"]
+exclude_targets: codex, gemini
+os:
+  - linux
+  - 'darwin'
+metadata:
+  targets: [ignored]
+---
+targets: [body]
+`
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "SKILL.md")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Targets        FrontmatterList `yaml:"targets"`
+		ExcludeTargets FrontmatterList `yaml:"exclude_targets"`
+		OS             FrontmatterList `yaml:"os"`
+		Tags           FrontmatterList `yaml:"tags"`
+	}
+	if err := ReadFrontmatter(filePath, &got); err != nil {
+		t.Fatalf("ReadFrontmatter() error = %v", err)
+	}
+	for field, tt := range map[string]struct{ got, want FrontmatterList }{
+		"targets":         {got.Targets, FrontmatterList{"claude", "cursor"}},
+		"exclude_targets": {got.ExcludeTargets, FrontmatterList{"codex", "gemini"}},
+		"os":              {got.OS, FrontmatterList{"linux", "darwin"}},
+		"tags":            {got.Tags, nil},
+	} {
+		if strings.Join(tt.got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s = %v, want %v", field, tt.got, tt.want)
+		}
+	}
+}
+
+func TestSplitFrontmatter(t *testing.T) {
+	frontmatter, body, ok := SplitFrontmatter("---\r\nname: x\r\n---\r\n# Body\r\n")
+	if !ok || frontmatter != "name: x" || body != "# Body\n" {
+		t.Errorf("SplitFrontmatter() = %q, %q, %v", frontmatter, body, ok)
+	}
+	if _, body, ok := SplitFrontmatter("# No frontmatter\n"); ok || body != "# No frontmatter\n" {
+		t.Errorf("SplitFrontmatter() without frontmatter = %q, %v", body, ok)
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// HashDir computes a deterministic content hash of a directory tree.
+// File paths and contents contribute; timestamps and the .git directory do not.
+// Top-level files named in skip are left out as well.
+func HashDir(dir string, skip ...string) (string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if info.Mode().IsRegular() && !isSkipped(dir, path, skip) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, path := range files {
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isSkipped(dir, path string, skip []string) bool {
+	if len(skip) == 0 || filepath.Dir(path) != filepath.Clean(dir) {
+		return false
+	}
+	name := filepath.Base(path)
+	for _, s := range skip {
+		if name == s {
+			return true
+		}
+	}
+	return false
+}
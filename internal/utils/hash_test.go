@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashDir_Skip(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# skill"), 0644)
+	base, err := HashDir(dir, "meta.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "meta.json"), []byte("{}"), 0644)
+	if got, _ := HashDir(dir, "meta.json"); got != base {
+		t.Errorf("skipped top-level file changed the hash")
+	}
+	if got, _ := HashDir(dir); got == base {
+		t.Errorf("hash without skip should include meta.json")
+	}
+
+	os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	os.WriteFile(filepath.Join(dir, "sub", "meta.json"), []byte("{}"), 0644)
+	if got, _ := HashDir(dir, "meta.json"); got == base {
+		t.Errorf("skip should only apply to top-level files")
+	}
+}
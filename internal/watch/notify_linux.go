@@ -0,0 +1,70 @@
+//go:build linux
+
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_ATTRIB |
+	unix.IN_CLOSE_WRITE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF
+
+// inotifyNotifier wakes the watcher on inotify events. Events only trigger a
+// rescan, so their details are not decoded.
+type inotifyNotifier struct {
+	fd   int // Kept separately: File.Fd would switch the file to blocking mode
+	file *os.File
+	wake chan struct{}
+}
+
+func newNotifier() (notifier, error) {
+	fd, err := unix.InotifyInit1(unix.IN_NONBLOCK | unix.IN_CLOEXEC)
+	if err != nil {
+		return nil, err
+	}
+	n := &inotifyNotifier{
+		fd:   fd,
+		file: os.NewFile(uintptr(fd), "inotify"),
+		wake: make(chan struct{}, 1),
+	}
+	go n.read()
+	return n, nil
+}
+
+func (n *inotifyNotifier) read() {
+	buf := make([]byte, 64*1024)
+	for {
+		if _, err := n.file.Read(buf); err != nil {
+			return
+		}
+		select {
+		case n.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Add watches every directory under dirs. Watching a directory again is a no-op.
+func (n *inotifyNotifier) Add(dirs []string) {
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			// Hitting the watch limit leaves polling to catch changes
+			unix.InotifyAddWatch(n.fd, path, inotifyMask) //nolint:errcheck
+			return nil
+		})
+	}
+}
+
+func (n *inotifyNotifier) Wake() <-chan struct{} { return n.wake }
+
+func (n *inotifyNotifier) Close() error { return n.file.Close() }
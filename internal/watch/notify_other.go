@@ -0,0 +1,10 @@
+//go:build !linux
+
+package watch
+
+import "errors"
+
+// newNotifier has no native implementation here; the watcher polls.
+func newNotifier() (notifier, error) {
+	return nil, errors.ErrUnsupported
+}
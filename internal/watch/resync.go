@@ -0,0 +1,60 @@
+package watch
+
+import (
+	"skillshare/internal/config"
+	"skillshare/internal/sync"
+)
+
+// Result is what one resync did to one target.
+type Result struct {
+	Target   string
+	Mode     string
+	Synced   []string // Skills linked or copied
+	Pruned   []string // Entries removed because the skill left source
+	Warnings []string
+	Err      error
+}
+
+// Resync brings targets up to date after the changed skills (flat names)
+// changed in sources. Merge targets resync only those skills (all skills when
+// the target uses a naming policy); copy targets
+// apply their full plan, which leaves unchanged copies alone; symlink targets
+// already see source changes. Target modes must be resolved by the caller.
+// All targets share one plan, so sources are discovered once, and changes are
+// journaled under journalDir, as in a full sync.
+func Resync(targets map[string]config.TargetConfig, sources []string, changed []string, ignore []string, journalDir string) []Result {
+	planned := make(map[string]config.TargetConfig, len(targets))
+	for name, target := range targets {
+		if target.Mode != "symlink" {
+			planned[name] = target
+		}
+	}
+	if len(planned) == 0 {
+		return []Result{}
+	}
+
+	plan := sync.NewPlan(planned, "merge", sources, false, ignore...)
+	for i, tp := range plan.Targets {
+		// Changed skills are known by full flat name, which is only the
+		// target entry name for skill folders under the default naming policy
+		if tp.Mode == "merge" && tp.Format == "" && planned[tp.Name].NamingPolicy().IsDefault() {
+			plan.Targets[i] = tp.Only(changed)
+		}
+	}
+
+	outcomes := plan.Apply(journalDir, sync.DefaultJobs(), false, nil)
+	results := make([]Result, 0, len(outcomes))
+	for _, o := range outcomes {
+		res := Result{Target: o.Plan.Name, Mode: o.Plan.Mode}
+		if o.Err != nil {
+			res.Err = o.Err
+		} else {
+			for _, names := range [][]string{o.Result.Linked, o.Result.Copied, o.Result.Generated, o.Result.Updated} {
+				res.Synced = append(res.Synced, names...)
+			}
+			res.Pruned, res.Warnings = o.Result.Pruned, o.Result.Warnings
+		}
+		results = append(results, res)
+	}
+	return results
+}
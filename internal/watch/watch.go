@@ -0,0 +1,190 @@
+// Package watch detects skill changes in source directories and resyncs the
+// affected skills into targets. It is used by 'sync --watch' and the UI server.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"skillshare/internal/sync"
+)
+
+const (
+	// DefaultInterval is how often sources are rescanned when no file
+	// notification arrives.
+	DefaultInterval = time.Second
+
+	// DefaultDebounce is the quiet period that ends a burst of changes.
+	DefaultDebounce = 300 * time.Millisecond
+)
+
+// Snapshot maps each discovered skill's flat name to a fingerprint of its
+// location and files.
+type Snapshot map[string]string
+
+// Scan discovers the skills in sources and fingerprints them.
+func Scan(sources []string, ignore ...string) (Snapshot, error) {
+	skills, _, err := sync.DiscoverLayeredSkills(sources, ignore...)
+	if err != nil {
+		return nil, err
+	}
+	snap := make(Snapshot, len(skills))
+	for _, skill := range skills {
+		snap[skill.FlatName] = fingerprint(skill.SourcePath)
+	}
+	return snap, nil
+}
+
+// fingerprint hashes the path, size and modification time of every file
+// under dir. Content is not read, so a scan stays cheap.
+func fingerprint(dir string) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\n", dir)
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(h, "%s %d %d\n", rel, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// Changed returns the sorted flat names that were added, removed or modified
+// between s and next.
+func (s Snapshot) Changed(next Snapshot) []string {
+	var names []string
+	for name, fp := range next {
+		if s[name] != fp {
+			names = append(names, name)
+		}
+	}
+	for name := range s {
+		if _, ok := next[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Watcher rescans sources on file notifications (inotify on Linux) and on a
+// polling interval, and reports changed skills once a burst settles.
+type Watcher struct {
+	Dirs     []string                 // Directories to watch recursively
+	Scan     func() (Snapshot, error) // Current state of the sources
+	Interval time.Duration            // Polling interval; DefaultInterval if zero
+	Debounce time.Duration            // Quiet period before reporting; DefaultDebounce if zero
+	OnError  func(error)              // Optional: scan errors, which are otherwise skipped
+}
+
+// Run watches until ctx is cancelled. onChange receives the flat names that
+// changed during a burst; when it returns an error the names are kept and
+// retried after the next quiet period.
+func (w *Watcher) Run(ctx context.Context, onChange func(changed []string) error) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	prev, err := w.Scan()
+	if err != nil {
+		return err
+	}
+
+	var wake <-chan struct{}
+	n, err := newNotifier()
+	if err == nil {
+		defer n.Close()
+		n.Add(w.Dirs)
+		wake = n.Wake()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	pending := map[string]bool{}
+	var settle *time.Timer
+	var settled <-chan time.Time
+	defer func() {
+		if settle != nil {
+			settle.Stop()
+		}
+	}()
+	resetSettle := func() {
+		if settle != nil {
+			settle.Stop()
+		}
+		settle = time.NewTimer(debounce)
+		settled = settle.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-settled:
+			settled = nil
+			names := make([]string, 0, len(pending))
+			for name := range pending {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if err := onChange(names); err != nil {
+				resetSettle()
+				continue
+			}
+			pending = map[string]bool{}
+			continue
+		case <-ticker.C:
+		case <-wake:
+		}
+
+		next, err := w.Scan()
+		if err != nil {
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+			continue
+		}
+		changed := prev.Changed(next)
+		prev = next
+		if len(changed) == 0 {
+			continue
+		}
+		for _, name := range changed {
+			pending[name] = true
+		}
+		resetSettle()
+		if n != nil {
+			n.Add(w.Dirs) // Pick up new directories
+		}
+	}
+}
+
+// notifier signals that something under the watched directories may have changed.
+type notifier interface {
+	Add(dirs []string)
+	Wake() <-chan struct{}
+	Close() error
+}
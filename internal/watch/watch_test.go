@@ -0,0 +1,162 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"skillshare/internal/config"
+)
+
+func writeSkill(t *testing.T, source, rel, content string) {
+	t.Helper()
+	dir := filepath.Join(source, rel)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotChanged(t *testing.T) {
+	source := t.TempDir()
+	writeSkill(t, source, "alpha", "# alpha")
+	writeSkill(t, source, "beta", "# beta")
+	writeSkill(t, source, "team/gamma", "# gamma")
+
+	before, err := Scan([]string{source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := before.Changed(before); len(got) != 0 {
+		t.Fatalf("unchanged snapshot reported %v", got)
+	}
+
+	writeSkill(t, source, "alpha", "# alpha, edited at more length")
+	if err := os.RemoveAll(filepath.Join(source, "beta")); err != nil {
+		t.Fatal(err)
+	}
+	writeSkill(t, source, "delta", "# delta")
+
+	after, err := Scan([]string{source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"alpha", "beta", "delta"}
+	if got := before.Changed(after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed() = %v, want %v", got, want)
+	}
+}
+
+func TestScan_IgnoredSkillsAreNotTracked(t *testing.T) {
+	source := t.TempDir()
+	writeSkill(t, source, "keep", "# keep")
+	writeSkill(t, source, "drafts/wip", "# wip")
+
+	snap, err := Scan([]string{source}, "drafts/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snap["drafts__wip"]; ok {
+		t.Error("ignored skill should not be in the snapshot")
+	}
+	if _, ok := snap["keep"]; !ok {
+		t.Error("keep should be in the snapshot")
+	}
+}
+
+func TestWatcherRun_DebouncesBurst(t *testing.T) {
+	source := t.TempDir()
+	writeSkill(t, source, "alpha", "# alpha")
+
+	w := &Watcher{
+		Dirs:     []string{source},
+		Scan:     func() (Snapshot, error) { return Scan([]string{source}) },
+		Interval: 20 * time.Millisecond,
+		Debounce: 150 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	calls := make(chan []string, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx, func(changed []string) error {
+			calls <- changed
+			return nil
+		})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	writeSkill(t, source, "one", "# one")
+	time.Sleep(40 * time.Millisecond)
+	writeSkill(t, source, "two", "# two")
+
+	select {
+	case got := <-calls:
+		if want := []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("onChange got %v, want %v in one call", got, want)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for onChange")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run returned %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("unexpected extra calls: %v", <-calls)
+	}
+}
+
+func TestResync_MergeLinksAndPrunesChangedSkills(t *testing.T) {
+	source := t.TempDir()
+	targetDir := filepath.Join(t.TempDir(), "skills")
+	writeSkill(t, source, "kept", "# kept")
+	writeSkill(t, source, "added", "# added")
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Link to a skill that has since been removed from source
+	if err := os.Symlink(filepath.Join(source, "removed"), filepath.Join(targetDir, "removed")); err != nil {
+		t.Fatal(err)
+	}
+
+	targets := map[string]config.TargetConfig{
+		"claude": {Path: targetDir, Mode: "merge"},
+	}
+	results := Resync(targets, []string{source}, []string{"added", "removed"}, nil, t.TempDir())
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("Resync() = %+v", results)
+	}
+	if want := []string{"added"}; !reflect.DeepEqual(results[0].Synced, want) {
+		t.Errorf("Synced = %v, want %v", results[0].Synced, want)
+	}
+	if want := []string{"removed"}; !reflect.DeepEqual(results[0].Pruned, want) {
+		t.Errorf("Pruned = %v, want %v", results[0].Pruned, want)
+	}
+
+	if _, err := os.Lstat(filepath.Join(targetDir, "added")); err != nil {
+		t.Error("added should be linked")
+	}
+	if _, err := os.Lstat(filepath.Join(targetDir, "kept")); err == nil {
+		t.Error("kept did not change and should not be touched")
+	}
+}
+
+func TestResync_SkipsSymlinkTargets(t *testing.T) {
+	source := t.TempDir()
+	writeSkill(t, source, "alpha", "# alpha")
+	targets := map[string]config.TargetConfig{
+		"claude": {Path: filepath.Join(t.TempDir(), "skills"), Mode: "symlink"},
+	}
+	if results := Resync(targets, []string{source}, []string{"alpha"}, nil, t.TempDir()); len(results) != 0 {
+		t.Errorf("symlink targets should be skipped, got %+v", results)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSync_EnvInterpolationAndWhen(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	agents := filepath.Join(sb.Root, "agents")
+	os.MkdirAll(filepath.Join(agents, "claude"), 0755)
+	sb.SetEnv("SKS_AGENTS", agents)
+
+	otherOS := "windows"
+	if runtime.GOOS == "windows" {
+		otherOS = "linux"
+	}
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ${SKS_AGENTS}/claude/skills
+    when:
+      os: ` + runtime.GOOS + `
+      exists: ${SKS_AGENTS}/claude
+  cursor:
+    path: ${SKS_AGENTS}/cursor/skills
+    when:
+      exists: ${SKS_AGENTS}/cursor
+  windsurf:
+    path: ${SKS_AGENTS:-/nowhere}/windsurf/skills
+    when:
+      os: ` + otherOS + `
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if !sb.IsSymlink(filepath.Join(agents, "claude", "skills", "my-skill")) {
+		t.Error("claude target should be synced through ${SKS_AGENTS}")
+	}
+	if sb.FileExists(filepath.Join(agents, "cursor")) {
+		t.Error("cursor target should be skipped: its when.exists path is missing")
+	}
+
+	list := sb.RunCLI("target", "list")
+	list.AssertSuccess(t)
+	list.AssertOutputContains(t, "inactive on this machine")
+
+	// Writing the config back keeps ${VAR} and the inactive targets
+	extra := filepath.Join(sb.Root, "extra", "skills")
+	os.MkdirAll(extra, 0755)
+	sb.RunCLI("target", "add", "extra", extra).AssertSuccess(t)
+
+	saved := sb.ReadFile(sb.ConfigPath)
+	for _, want := range []string{"${SKS_AGENTS}/claude/skills", "cursor:", "windsurf:", "${SKS_AGENTS:-/nowhere}"} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config should contain %q:\n%s", want, saved)
+		}
+	}
+}
+
+func TestLoad_UnsetEnvVariable_Fails(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ${SKS_MISSING_ROOT}/skills
+targets: {}
+`)
+
+	result := sb.RunCLI("status")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "SKS_MISSING_ROOT")
+}
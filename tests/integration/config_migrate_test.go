@@ -0,0 +1,87 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestConfigMigrate_DryRunShowsChanges(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	cfg := `# shared team config
+source: ` + sb.SourcePath + `
+targets: {}
+`
+	sb.WriteConfig(cfg)
+
+	result := sb.RunCLI("config", "migrate", "--dry-run")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "v0 -> v1")
+	result.AssertOutputContains(t, "+ version: 1")
+	result.AssertOutputContains(t, "dry-run")
+
+	if got := sb.ReadFile(sb.ConfigPath); got != cfg {
+		t.Errorf("dry run should not modify config, got:\n%s", got)
+	}
+}
+
+func TestConfigMigrate_WritesBackupAndKeepsComments(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`# shared team config
+source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("config", "migrate")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Migrated")
+
+	got := sb.ReadFile(sb.ConfigPath)
+	if !strings.Contains(got, "version: 1") || !strings.Contains(got, "# shared team config") {
+		t.Errorf("migrated config = %s", got)
+	}
+	if !sb.FileExists(sb.ConfigPath + ".v0.bak") {
+		t.Error("backup of the previous config should be written")
+	}
+
+	result = sb.RunCLI("config", "migrate")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "up to date")
+}
+
+func TestConfigMigrate_ProjectConfig(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	projectRoot := sb.SetupProjectDir("claude-code")
+	sb.WriteProjectConfig(projectRoot, "targets:\n  - claude-code\n")
+
+	result := sb.RunCLIInDir(projectRoot, "config", "migrate", "-p")
+	result.AssertSuccess(t)
+
+	cfgPath := filepath.Join(projectRoot, ".skillshare", "config.yaml")
+	if !strings.Contains(sb.ReadFile(cfgPath), "version: 1") {
+		t.Error("project config should be migrated")
+	}
+}
+
+func TestConfig_NewerVersionRejected(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`version: 99
+source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("status")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "newer than this skillshare supports")
+}
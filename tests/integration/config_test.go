@@ -0,0 +1,172 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestConfigGetSetUnset(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`version: 1
+# team source
+source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	sb.RunCLI("config", "set", "targets.claude.mode", "copy").AssertSuccess(t)
+
+	get := sb.RunCLI("config", "get", "targets.claude.mode")
+	get.AssertSuccess(t)
+	if strings.TrimSpace(get.Stdout) != "copy" {
+		t.Errorf("get = %q, want copy", get.Stdout)
+	}
+
+	saved := sb.ReadFile(sb.ConfigPath)
+	if !strings.Contains(saved, "# team source") {
+		t.Errorf("set should keep comments:\n%s", saved)
+	}
+
+	sb.RunCLI("config", "unset", "targets.claude.mode").AssertSuccess(t)
+	sb.RunCLI("config", "get", "targets.claude.mode").AssertFailure(t)
+}
+
+func TestConfigGetSet_ActiveProfile(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	workSource := filepath.Join(sb.Root, "work-skills")
+	sb.WriteConfig(`version: 1
+source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + sb.CreateTarget("claude") + `
+profiles:
+  work:
+    source: ` + workSource + `
+    targets:
+      cursor:
+        path: ` + sb.CreateTarget("cursor") + `
+`)
+	sb.SetEnv("SKILLSHARE_PROFILE", "work")
+
+	get := sb.RunCLI("config", "get", "source")
+	get.AssertSuccess(t)
+	if strings.TrimSpace(get.Stdout) != workSource {
+		t.Errorf("get source = %q, want the profile's %s", get.Stdout, workSource)
+	}
+	// The profile's targets replace the base targets
+	sb.RunCLI("config", "get", "targets.claude.path").AssertFailure(t)
+
+	// An inline profile is edited in place
+	result := sb.RunCLI("config", "set", "mode", "copy")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, sb.ConfigPath)
+	sb.RunCLI("--profile", "default", "config", "get", "mode").AssertFailure(t)
+	if got := strings.TrimSpace(sb.RunCLI("--profile", "default", "config", "get", "profiles.work.mode").Stdout); got != "copy" {
+		t.Errorf("profiles.work.mode = %q, want copy", got)
+	}
+
+	// A file profile is edited in its own file
+	profilePath := filepath.Join(filepath.Dir(sb.ConfigPath), "profiles", "personal.yaml")
+	sb.WriteFile(profilePath, "mode: merge\n")
+	sb.RunCLI("--profile", "personal", "config", "set", "source", workSource).AssertSuccess(t)
+	if got := strings.TrimSpace(sb.RunCLI("--profile", "personal", "config", "get", "source").Stdout); got != workSource {
+		t.Errorf("get source = %q, want %s", got, workSource)
+	}
+	profile := sb.ReadFile(profilePath)
+	if !strings.Contains(profile, "source: "+workSource) || strings.Contains(profile, "version") {
+		t.Errorf("profile file should get the source and no version:\n%s", profile)
+	}
+	if got := strings.TrimSpace(sb.RunCLI("--profile", "default", "config", "get", "source").Stdout); got != sb.SourcePath {
+		t.Errorf("base source = %q, config set under a profile should not change it", got)
+	}
+}
+
+func TestConfigSet_RejectsInvalidValue(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	cfg := `version: 1
+source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`
+	sb.WriteConfig(cfg)
+
+	result := sb.RunCLI("config", "set", "targets.claude.mode", "mirror")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "invalid value")
+
+	result = sb.RunCLI("config", "set", "targets.claude.mdoe", "copy")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "did you mean")
+
+	if got := sb.ReadFile(sb.ConfigPath); got != cfg {
+		t.Errorf("rejected set should not modify config, got:\n%s", got)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`version: 1
+source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	sb.RunCLI("config", "validate").AssertSuccess(t)
+
+	sb.WriteConfig(`version: 1
+source: ` + sb.SourcePath + `
+targts: {}
+targets:
+  claude:
+    path: ` + targetPath + `
+    mode: symlinks
+`)
+	result := sb.RunCLI("config", "validate")
+	result.AssertFailure(t)
+	result.AssertOutputContains(t, "targts: unknown key")
+	result.AssertOutputContains(t, "targets.claude.mode")
+	result.AssertOutputContains(t, "line 3")
+}
+
+func TestConfigSchema(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + "\ntargets: {}\n")
+
+	result := sb.RunCLI("config", "schema")
+	result.AssertSuccess(t)
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(result.Stdout), &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v\n%s", err, result.Stdout)
+	}
+	props, _ := schema["properties"].(map[string]any)
+	if props["targets"] == nil || props["source"] == nil {
+		t.Errorf("schema missing properties: %v", props)
+	}
+
+	projectRoot := sb.SetupProjectDir("claude-code")
+	project := sb.RunCLIInDir(projectRoot, "config", "schema", "-p")
+	project.AssertSuccess(t)
+	project.AssertOutputContains(t, "skillshare project config")
+}
@@ -0,0 +1,131 @@
+//go:build !online
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// writeSkillTarGz writes a .tar.gz holding files under a single top-level
+// directory, like a release tarball
+func writeSkillTarGz(t *testing.T, path, top string, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		hdr := &tar.Header{Name: top + "/" + name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
+
+func TestInstall_Archive_CheckAndUpdate(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	archive := filepath.Join(sb.Root, "pdf-skill-1.0.0.tar.gz")
+	digest := writeSkillTarGz(t, archive, "pdf-skill-1.0.0", map[string]string{
+		"SKILL.md": "---\nname: pdf-skill\n---\n# Version 1",
+	})
+
+	result := sb.RunCLI("install", archive)
+	result.AssertSuccess(t)
+
+	skillPath := filepath.Join(sb.SourcePath, "pdf-skill")
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 1") {
+		t.Fatal("archive skill should be installed as pdf-skill")
+	}
+
+	var meta map[string]any
+	if err := json.Unmarshal([]byte(sb.ReadFile(filepath.Join(skillPath, ".skillshare-meta.json"))), &meta); err != nil {
+		t.Fatalf("failed to parse meta: %v", err)
+	}
+	if meta["url"] != archive || meta["digest"] != "sha256:"+digest {
+		t.Errorf("meta url/digest = %v/%v, want %s/sha256:%s", meta["url"], meta["digest"], archive, digest)
+	}
+
+	sb.RunCLI("check", "--json").AssertOutputContains(t, `"status": "up_to_date"`)
+
+	// A new release at the same location is reported and picked up by update
+	writeSkillTarGz(t, archive, "pdf-skill-1.0.1", map[string]string{
+		"SKILL.md": "---\nname: pdf-skill\n---\n# Version 2",
+	})
+	sb.RunCLI("check", "--json").AssertOutputContains(t, `"status": "update_available"`)
+
+	sb.RunCLI("update", "pdf-skill").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 2") {
+		t.Error("update should reinstall from the new archive")
+	}
+	sb.RunCLI("check", "--json").AssertOutputContains(t, `"status": "up_to_date"`)
+}
+
+func TestInstall_Archive_PinnedDigest(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	archive := filepath.Join(sb.Root, "pack.tar.gz")
+	digest := writeSkillTarGz(t, archive, "pack", map[string]string{
+		"skills/alpha/SKILL.md": "---\nname: alpha\n---\n# Alpha",
+		"skills/beta/SKILL.md":  "---\nname: beta\n---\n# Beta",
+	})
+
+	result := sb.RunCLI("install", archive+"#sha256="+strings.Repeat("0", 64), "--all")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "digest mismatch")
+
+	sb.RunCLI("install", archive+"/skills/alpha#sha256="+digest).AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "alpha", "SKILL.md")) {
+		t.Fatal("subdir of a pinned archive should install")
+	}
+	sb.RunCLI("check").AssertOutputContains(t, "pinned to sha256:"+digest[:12])
+}
+
+func TestInstall_Archive_RejectsTraversal(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	archive := filepath.Join(sb.Root, "evil.tar.gz")
+	writeSkillTarGz(t, archive, "..", map[string]string{"evil/SKILL.md": "---\nname: evil\n---\n"})
+
+	result := sb.RunCLI("install", archive)
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "escapes the extraction directory")
+	if sb.FileExists(filepath.Join(sb.Root, "evil")) {
+		t.Error("traversal entry must not be written")
+	}
+}
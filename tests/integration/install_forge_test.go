@@ -0,0 +1,44 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestInstall_GitLabLegacySubdirPath(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	// Serve https://gitlab.com/ from local repos: team/skills exists, the
+	// nested project team/skills/frontend/pdf does not
+	root := filepath.Join(sb.Root, "gitlab")
+	repo := filepath.Join(root, "team", "skills.git")
+	gitInit(t, repo, false)
+	sb.WriteFile(filepath.Join(repo, "frontend", "pdf", "SKILL.md"), "---\nname: pdf\n---\n# PDF")
+	gitAddCommit(t, repo, "init")
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "url.file://"+filepath.ToSlash(root)+"/.insteadOf")
+	t.Setenv("GIT_CONFIG_VALUE_0", "https://gitlab.com/")
+
+	sb.RunCLI("install", "gitlab.com/team/skills/frontend/pdf").AssertSuccess(t)
+
+	skillPath := filepath.Join(sb.SourcePath, "pdf")
+	if !sb.FileExists(filepath.Join(skillPath, "SKILL.md")) {
+		t.Fatal("the subdir of team/skills should be installed")
+	}
+	meta := sb.ReadFile(filepath.Join(skillPath, ".skillshare-meta.json"))
+	if !strings.Contains(meta, `"source": "https://gitlab.com/team/skills//frontend/pdf"`) {
+		t.Errorf("meta should record the source with //, got:\n%s", meta)
+	}
+
+	sb.RunCLI("update", "pdf").AssertSuccess(t)
+}
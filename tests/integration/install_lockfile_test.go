@@ -0,0 +1,205 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"skillshare/internal/testutil"
+)
+
+func TestInstall_Lockfile_FrozenAndDrift(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	repo := filepath.Join(sb.Root, "lock-skill")
+	gitInit(t, repo, false)
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: lock-skill\n---\n# Version 1")
+	gitAddCommit(t, repo, "v1")
+
+	sb.RunCLI("install", "file://"+repo).AssertSuccess(t)
+
+	lockPath := filepath.Join(filepath.Dir(sb.ConfigPath), "skillshare.lock")
+	lock := sb.ReadFile(lockPath)
+	if !strings.Contains(lock, "lock-skill:") || !strings.Contains(lock, "commit: ") || !strings.Contains(lock, "hash: sha256:") {
+		t.Fatalf("install should write the lockfile, got:\n%s", lock)
+	}
+
+	// A newer upstream commit is ignored by --frozen
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: lock-skill\n---\n# Version 2")
+	gitAddCommit(t, repo, "v2")
+
+	skillPath := filepath.Join(sb.SourcePath, "lock-skill")
+	os.RemoveAll(skillPath)
+	sb.RunCLI("check").AssertOutputContains(t, "locked but not installed")
+
+	sb.RunCLI("install", "--frozen").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 1") {
+		t.Fatal("--frozen should install the locked commit")
+	}
+	if sb.FileExists(filepath.Join(skillPath, ".git")) {
+		t.Error("--frozen should not leave a .git directory in a copied skill")
+	}
+	if sb.ReadFile(lockPath) != lock {
+		t.Error("--frozen must not rewrite the lockfile")
+	}
+
+	// Local edits show up as drift and make --frozen fail
+	sb.WriteFile(filepath.Join(skillPath, "SKILL.md"), "---\nname: lock-skill\n---\n# Edited")
+	result := sb.RunCLI("check")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "files differ from lock")
+	sb.RunCLI("check", "--json").AssertOutputContains(t, `"status": "modified"`)
+
+	result = sb.RunCLI("install", "--frozen")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "content hash mismatch")
+
+	// --update-lock records the current state
+	sb.RunCLI("install", "--update-lock").AssertSuccess(t)
+	result = sb.RunCLI("check")
+	result.AssertSuccess(t)
+	result.AssertOutputNotContains(t, "differ from")
+	sb.RunCLI("install", "--frozen").AssertSuccess(t)
+
+	// Uninstalling drops the entry
+	sb.RunCLI("uninstall", "lock-skill", "--force").AssertSuccess(t)
+	if strings.Contains(sb.ReadFile(lockPath), "lock-skill:") {
+		t.Error("uninstall should remove the lock entry")
+	}
+}
+
+func TestInstall_Lockfile_KeepsEditedHashAfterCheckout(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	first := filepath.Join(sb.Root, "first-skill")
+	gitInit(t, first, false)
+	sb.WriteFile(filepath.Join(first, "SKILL.md"), "---\nname: first-skill\n---\n# First")
+	gitAddCommit(t, first, "v1")
+	second := filepath.Join(sb.Root, "second-skill")
+	gitInit(t, second, false)
+	sb.WriteFile(filepath.Join(second, "SKILL.md"), "---\nname: second-skill\n---\n# Second")
+	gitAddCommit(t, second, "v1")
+
+	sb.RunCLI("install", "file://"+first).AssertSuccess(t)
+
+	// An older lockfile mtime, as after a checkout, must not let an
+	// unrelated install record the local edit as locked
+	lockPath := filepath.Join(filepath.Dir(sb.ConfigPath), "skillshare.lock")
+	past := time.Now().Add(-24 * time.Hour)
+	os.Chtimes(lockPath, past, past)
+	sb.WriteFile(filepath.Join(sb.SourcePath, "first-skill", "SKILL.md"), "---\nname: first-skill\n---\n# Edited")
+
+	sb.RunCLI("install", "file://"+second).AssertSuccess(t)
+	sb.RunCLI("check", "--json").AssertOutputContains(t, `"status": "modified"`)
+}
+
+func TestInstall_Lockfile_PerProfile(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	workSource := filepath.Join(sb.Root, "work-skills")
+	os.MkdirAll(workSource, 0755)
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+profiles:
+  work:
+    source: ` + workSource + `
+`)
+
+	repo := filepath.Join(sb.Root, "lock-skill")
+	gitInit(t, repo, false)
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: lock-skill\n---\n# Personal")
+	gitAddCommit(t, repo, "v1")
+	sb.RunCLI("install", "file://"+repo).AssertSuccess(t)
+
+	sb.RunCLI("--profile", "work", "install", "file://"+repo, "--name", "work-skill").AssertSuccess(t)
+
+	baseLock := sb.ReadFile(filepath.Join(filepath.Dir(sb.ConfigPath), "skillshare.lock"))
+	if !strings.Contains(baseLock, "lock-skill:") || strings.Contains(baseLock, "work-skill:") {
+		t.Errorf("default lock should only hold the default source, got:\n%s", baseLock)
+	}
+	workLock := sb.ReadFile(filepath.Join(filepath.Dir(sb.ConfigPath), "profiles", "work", "skillshare.lock"))
+	if !strings.Contains(workLock, "work-skill:") || strings.Contains(workLock, "lock-skill:") {
+		t.Errorf("work lock should only hold the work source, got:\n%s", workLock)
+	}
+
+	result := sb.RunCLI("check", "--json")
+	result.AssertSuccess(t)
+	result.AssertOutputNotContains(t, `"status": "missing"`)
+}
+
+func TestInstall_FrozenFlagValidation(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("install", "--frozen")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--frozen requires")
+
+	result = sb.RunCLI("install", "--frozen", "user/repo")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "takes no source")
+
+	result = sb.RunCLI("install", "--frozen", "--update-lock")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "cannot be used together")
+}
+
+func TestInstallProject_FromConfig_UsesLockedCommit(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	projectRoot := sb.SetupProjectDir("claude-code")
+
+	repo := filepath.Join(sb.Root, "team-skill")
+	gitInit(t, repo, false)
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: team-skill\n---\n# Version 1")
+	gitAddCommit(t, repo, "v1")
+
+	sb.RunCLIInDir(projectRoot, "install", "file://"+repo, "-p").AssertSuccess(t)
+	lockPath := filepath.Join(projectRoot, ".skillshare", "skillshare.lock")
+	if !sb.FileExists(lockPath) {
+		t.Fatal("project install should write .skillshare/skillshare.lock")
+	}
+
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: team-skill\n---\n# Version 2")
+	gitAddCommit(t, repo, "v2")
+
+	// A fresh checkout reinstalls from config at the locked commit
+	skillPath := filepath.Join(projectRoot, ".skillshare", "skills", "team-skill")
+	os.RemoveAll(skillPath)
+	sb.RunCLIInDir(projectRoot, "install", "-p").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 1") {
+		t.Error("install from config should honor the locked commit")
+	}
+
+	// --frozen refuses config skills the lock does not cover
+	sb.WriteProjectConfig(projectRoot, `targets:
+  - claude-code
+skills:
+  - name: team-skill
+    source: file://`+repo+`
+  - name: unlocked
+    source: someone/skills/unlocked
+`)
+	result := sb.RunCLIInDir(projectRoot, "install", "-p", "--frozen")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "not in skillshare.lock")
+}
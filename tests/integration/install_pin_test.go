@@ -0,0 +1,155 @@
+//go:build !online
+
+package integration
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestInstall_PinnedRef_CheckAndUpdate(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	repo := filepath.Join(sb.Root, "pin-skill")
+	gitInit(t, repo, false)
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: pin-skill\n---\n# Version 1")
+	gitAddCommit(t, repo, "v1")
+	run(t, repo, "git", "tag", "v1")
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: pin-skill\n---\n# Version 2")
+	gitAddCommit(t, repo, "v2")
+
+	sb.RunCLI("install", "file://"+repo+"@v1").AssertSuccess(t)
+
+	skillPath := filepath.Join(sb.SourcePath, "pin-skill")
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 1") {
+		t.Fatal("install should check out the pinned tag")
+	}
+	meta := sb.ReadFile(filepath.Join(skillPath, ".skillshare-meta.json"))
+	if !strings.Contains(meta, `"ref": "v1"`) || !strings.Contains(meta, `@v1"`) {
+		t.Fatalf("meta should record the pin, got:\n%s", meta)
+	}
+
+	result := sb.RunCLI("check")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "pinned to v1")
+	result.AssertOutputContains(t, "Everything is up to date")
+
+	// A plain update keeps the pin
+	sb.RunCLI("update", "pin-skill").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 1") {
+		t.Error("update should stay at the pinned tag")
+	}
+
+	// --unpin follows the default branch again
+	sb.RunCLI("update", "pin-skill", "--unpin").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 2") {
+		t.Error("--unpin should move to the latest commit")
+	}
+	if strings.Contains(sb.ReadFile(filepath.Join(skillPath, ".skillshare-meta.json")), `"ref"`) {
+		t.Error("--unpin should drop the ref from meta")
+	}
+
+	// --to accepts a commit
+	out, err := exec.Command("git", "-C", repo, "rev-parse", "v1").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := strings.TrimSpace(string(out))
+	sb.RunCLI("update", "pin-skill", "--to", commit).AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(skillPath, "SKILL.md")), "Version 1") {
+		t.Error("--to should check out the commit")
+	}
+	sb.RunCLI("check").AssertOutputContains(t, "pinned to "+commit)
+}
+
+func TestUpdate_PinFlagsRejectAll(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("update", "--all", "--to", "v1")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "not --all")
+}
+
+func TestInstall_TrackedCommitPin(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	repo := filepath.Join(sb.Root, "team-skills")
+	gitInit(t, repo, false)
+	sb.WriteFile(filepath.Join(repo, "review", "SKILL.md"), "---\nname: review\n---\n# Version 1")
+	gitAddCommit(t, repo, "v1")
+	out, err := exec.Command("git", "-C", repo, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := strings.TrimSpace(string(out))
+	sb.WriteFile(filepath.Join(repo, "review", "SKILL.md"), "---\nname: review\n---\n# Version 2")
+	gitAddCommit(t, repo, "v2")
+	run(t, repo, "git", "tag", "v2")
+	sb.WriteFile(filepath.Join(repo, "review", "SKILL.md"), "---\nname: review\n---\n# Version 3")
+	gitAddCommit(t, repo, "v3")
+
+	sb.RunCLI("install", "file://"+repo+"@"+commit, "--track").AssertSuccess(t)
+
+	matches, _ := filepath.Glob(filepath.Join(sb.SourcePath, "_*", "review", "SKILL.md"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one tracked repo with the review skill, got %v", matches)
+	}
+	if !strings.Contains(sb.ReadFile(matches[0]), "Version 1") {
+		t.Error("--track should check out the pinned commit")
+	}
+	repoName := filepath.Base(filepath.Dir(filepath.Dir(matches[0])))
+
+	lock := sb.ReadFile(filepath.Join(filepath.Dir(sb.ConfigPath), "skillshare.lock"))
+	if !strings.Contains(lock, "@"+commit) {
+		t.Errorf("lockfile should record the pin, got:\n%s", lock)
+	}
+
+	result := sb.RunCLI("check")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "pinned to "+commit)
+	result.AssertOutputContains(t, "Everything is up to date")
+
+	// Updates check the pin out again instead of pulling past it
+	sb.RunCLI("update", "--all").AssertSuccess(t)
+	sb.RunCLI("update", repoName).AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(matches[0]), "Version 1") {
+		t.Error("update should stay at the pinned commit")
+	}
+
+	// --to moves the pin to a tag
+	sb.RunCLI("update", repoName, "--to", "v2").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(matches[0]), "Version 2") {
+		t.Error("--to should check out the tag")
+	}
+	sb.RunCLI("update", repoName).AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(matches[0]), "Version 2") {
+		t.Error("update should stay at the pinned tag")
+	}
+
+	// --unpin follows the default branch again, and later pulls work
+	sb.RunCLI("update", repoName, "--unpin").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(matches[0]), "Version 3") {
+		t.Error("--unpin should move to the latest commit")
+	}
+	sb.RunCLI("check").AssertOutputNotContains(t, "pinned to")
+	sb.RunCLI("update", repoName).AssertSuccess(t)
+}
@@ -0,0 +1,145 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// gitSkillRepo creates a git repo with a single root skill requiring the
+// given entries and returns its file:// URL
+func gitSkillRepo(t *testing.T, root, name string, requires ...string) string {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	gitInit(t, dir, false)
+	body := "---\nname: " + name + "\n"
+	for i, req := range requires {
+		if i == 0 {
+			body += "requires:\n"
+		}
+		body += "  - " + req + "\n"
+	}
+	body += "---\n# " + name + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAddCommit(t, dir, "init")
+	return "file://" + dir
+}
+
+func readMetaRequires(t *testing.T, sb *testutil.Sandbox, name string) []string {
+	t.Helper()
+	var meta struct {
+		Requires []string `json:"requires"`
+	}
+	if err := json.Unmarshal([]byte(sb.ReadFile(filepath.Join(sb.SourcePath, name, ".skillshare-meta.json"))), &meta); err != nil {
+		t.Fatalf("failed to parse %s meta: %v", name, err)
+	}
+	return meta.Requires
+}
+
+func TestInstall_Requires_ResolvesAndRecordsEdges(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	repos := filepath.Join(sb.Root, "repos")
+	base := gitSkillRepo(t, repos, "base")
+	lint := gitSkillRepo(t, repos, "lint", base)
+	app := gitSkillRepo(t, repos, "app", lint, base)
+
+	dry := sb.RunCLI("install", app, "--dry-run")
+	dry.AssertSuccess(t)
+	dry.AssertAnyOutputContains(t, "Dependencies")
+	dry.AssertAnyOutputContains(t, "install from "+lint)
+	if sb.FileExists(filepath.Join(sb.SourcePath, "base")) {
+		t.Fatal("dry run must not install dependencies")
+	}
+
+	result := sb.RunCLI("install", app)
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "Installed dependency: base")
+	result.AssertAnyOutputContains(t, "Installed dependency: lint")
+
+	if got := readMetaRequires(t, sb, "app"); !reflect.DeepEqual(got, []string{"lint", "base"}) {
+		t.Errorf("app requires %v, want [lint base]", got)
+	}
+	if got := readMetaRequires(t, sb, "lint"); !reflect.DeepEqual(got, []string{"base"}) {
+		t.Errorf("lint requires %v, want [base]", got)
+	}
+
+	tree := sb.RunCLI("list", "--tree")
+	tree.AssertSuccess(t)
+	tree.AssertAnyOutputContains(t, "├── lint")
+	tree.AssertAnyOutputContains(t, "└── base")
+
+	refused := sb.RunCLI("uninstall", "base")
+	refused.AssertFailure(t)
+	refused.AssertAnyOutputContains(t, "required by: app, lint")
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "base", "SKILL.md")) {
+		t.Fatal("a required skill must be kept without --force")
+	}
+
+	forced := sb.RunCLI("uninstall", "base", "--force")
+	forced.AssertSuccess(t)
+	forced.AssertAnyOutputContains(t, "Required by: app, lint")
+
+	sb.RunCLI("list", "--tree").AssertAnyOutputContains(t, "(missing)")
+}
+
+func TestInstall_Requires_RefusesCycle(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	repos := filepath.Join(sb.Root, "repos")
+	a := "file://" + filepath.Join(repos, "alpha")
+	b := gitSkillRepo(t, repos, "beta", a)
+	gitSkillRepo(t, repos, "alpha", b)
+
+	result := sb.RunCLI("install", a)
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "dependency cycle: alpha -> beta -> alpha")
+	if sb.FileExists(filepath.Join(sb.SourcePath, "alpha")) || sb.FileExists(filepath.Join(sb.SourcePath, "beta")) {
+		t.Error("nothing should be installed when dependencies form a cycle")
+	}
+}
+
+func TestUninstall_Requires_ChecksEverySourceLayer(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	team := filepath.Join(sb.Root, "layers", "team")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+sources:
+  - ` + team + `
+  - ` + sb.SourcePath + `
+targets: {}
+`)
+
+	repos := filepath.Join(sb.Root, "repos")
+	sb.RunCLI("install", gitSkillRepo(t, repos, "base")).AssertSuccess(t)
+
+	// A skill in another layer that recorded base as a dependency
+	sb.WriteFile(filepath.Join(team, "app", "SKILL.md"), "---\nname: app\n---\n# app\n")
+	sb.WriteFile(filepath.Join(team, "app", ".skillshare-meta.json"), `{"source": "file:///elsewhere/app", "requires": ["base"]}`)
+
+	result := sb.RunCLI("uninstall", "base")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "required by: app")
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "base", "SKILL.md")) {
+		t.Fatal("a skill required from another layer must be kept without --force")
+	}
+}
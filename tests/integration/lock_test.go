@@ -0,0 +1,88 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"skillshare/internal/filelock"
+	"skillshare/internal/testutil"
+)
+
+func TestSync_WaitsForWriteLock(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	lockPath := filepath.Join(filepath.Dir(sb.ConfigPath), "write.lock")
+	lock, err := filelock.Acquire(lockPath, "skillshare ui: POST /api/sync", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb.SetEnv("SKILLSHARE_LOCK_TIMEOUT", "300ms")
+	result := sb.RunCLI("sync")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "locked by pid")
+	result.AssertAnyOutputContains(t, "skillshare ui: POST /api/sync")
+	if sb.FileExists(filepath.Join(targetPath, "my-skill")) {
+		t.Error("sync should not run while the lock is held")
+	}
+
+	// Read-only commands don't wait
+	sb.RunCLI("status").AssertSuccess(t)
+
+	lock.Release()
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "my-skill")) {
+		t.Error("sync should run once the lock is released")
+	}
+}
+
+func TestReadOnlySubcommands_SkipWriteLock(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	lockPath := filepath.Join(filepath.Dir(sb.ConfigPath), "write.lock")
+	lock, err := filelock.Acquire(lockPath, "skillshare sync --watch", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	sb.SetEnv("SKILLSHARE_LOCK_TIMEOUT", "300ms")
+
+	for _, args := range [][]string{
+		{"config", "get", "source"},
+		{"config", "validate"},
+		{"config", "schema"},
+		{"target", "list"},
+		{"target", "claude"},
+		{"profile", "current"},
+		{"trash", "list"},
+		{"hub", "list"},
+	} {
+		result := sb.RunCLI(args...)
+		result.AssertSuccess(t)
+		result.AssertOutputNotContains(t, "locked by pid")
+	}
+
+	result := sb.RunCLI("config", "set", "mode", "copy")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "locked by pid")
+}
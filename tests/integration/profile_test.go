@@ -0,0 +1,113 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func writeProfileConfig(sb *testutil.Sandbox) (workSource, workTarget string) {
+	workSource = filepath.Join(sb.Root, "work-skills")
+	workTarget = filepath.Join(sb.Root, "work-target")
+	os.MkdirAll(filepath.Join(workSource, "client-skill"), 0755)
+	os.WriteFile(filepath.Join(workSource, "client-skill", "SKILL.md"), []byte("---\nname: client-skill\n---\n# Client"), 0644)
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + sb.CreateTarget("claude") + `
+profiles:
+  work:
+    source: ` + workSource + `
+    targets:
+      work-agent:
+        path: ` + workTarget + `
+`)
+	return workSource, workTarget
+}
+
+func TestProfile_FlagSelectsOverlay(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("personal-skill", map[string]string{"SKILL.md": "# Personal"})
+	_, workTarget := writeProfileConfig(sb)
+
+	result := sb.RunCLI("--profile", "work", "sync")
+	result.AssertSuccess(t)
+
+	if !sb.IsSymlink(filepath.Join(workTarget, "client-skill")) {
+		t.Error("work profile should sync its own source to its own targets")
+	}
+	if sb.FileExists(filepath.Join(sb.Home, ".claude", "skills", "personal-skill")) {
+		t.Error("base targets should not be synced under the work profile")
+	}
+
+	result = sb.RunCLI("status", "--profile=work")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Profile")
+	result.AssertOutputContains(t, "work")
+	result.AssertOutputContains(t, "work-agent")
+
+	if !sb.FileExists(filepath.Join(sb.Home, ".config", "skillshare", "profiles", "work", "logs", "operations.log")) {
+		t.Error("operation log should be namespaced under the profile")
+	}
+}
+
+func TestProfile_UseAndEnvOverride(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	writeProfileConfig(sb)
+	sb.WriteFile(filepath.Join(sb.Home, ".config", "skillshare", "profiles", "personal.yaml"), "mode: copy\n")
+
+	result := sb.RunCLI("profile", "use", "work")
+	result.AssertSuccess(t)
+
+	result = sb.RunCLI("profile", "current")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "work")
+
+	sb.SetEnv("SKILLSHARE_PROFILE", "personal")
+	result = sb.RunCLI("profile", "current")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "personal")
+
+	result = sb.RunCLI("profile", "list")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "personal")
+	result.AssertOutputContains(t, "work")
+
+	result = sb.RunCLI("profile", "use", "missing")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "not found")
+}
+
+func TestProfile_TargetAddWritesInlineProfile(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	writeProfileConfig(sb)
+	extra := filepath.Join(sb.Root, "extra", "skills")
+	os.MkdirAll(extra, 0755)
+
+	result := sb.RunCLI("--profile", "work", "target", "add", "extra", extra)
+	result.AssertSuccess(t)
+
+	profileFile := filepath.Join(sb.Home, ".config", "skillshare", "profiles", "work.yaml")
+	if sb.FileExists(profileFile) {
+		t.Fatal("target add under an inline profile should not create profiles/work.yaml")
+	}
+
+	result = sb.RunCLI("--profile", "work", "target", "list")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "extra")
+
+	result = sb.RunCLI("target", "list")
+	result.AssertSuccess(t)
+	result.AssertOutputNotContains(t, "extra")
+}
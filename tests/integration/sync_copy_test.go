@@ -0,0 +1,148 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func writeCopyModeConfig(sb *testutil.Sandbox, targetPath string) {
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+    mode: copy
+`)
+}
+
+func TestSync_CopyMode_CreatesPhysicalCopies(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	sb.CreateNestedSkill("team/frontend/ui", map[string]string{"SKILL.md": "# UI"})
+	targetPath := sb.CreateTarget("claude")
+	writeCopyModeConfig(sb, targetPath)
+
+	result := sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "copied")
+
+	for _, name := range []string{"my-skill", "team__frontend__ui"} {
+		skillPath := filepath.Join(targetPath, name)
+		if sb.IsSymlink(skillPath) {
+			t.Errorf("%s should be a real directory, not a symlink", name)
+		}
+		if !sb.FileExists(filepath.Join(skillPath, "SKILL.md")) {
+			t.Errorf("%s/SKILL.md should be copied", name)
+		}
+	}
+	if !sb.FileExists(filepath.Join(targetPath, ".skillshare-manifest.json")) {
+		t.Error("copy manifest should be written")
+	}
+}
+
+func TestSync_CopyMode_UpdatesChangedSkills(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	skillDir := sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# Version 1"})
+	targetPath := sb.CreateTarget("claude")
+	writeCopyModeConfig(sb, targetPath)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	sb.WriteFile(filepath.Join(skillDir, "SKILL.md"), "# Version 2")
+
+	result := sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "1 updated")
+
+	if got := sb.ReadFile(filepath.Join(targetPath, "my-skill", "SKILL.md")); got != "# Version 2" {
+		t.Errorf("copy content = %q, want updated content", got)
+	}
+}
+
+func TestSync_CopyMode_PreservesLocalEditsWithoutForce(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	skillDir := sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# Original"})
+	targetPath := sb.CreateTarget("claude")
+	writeCopyModeConfig(sb, targetPath)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	copyFile := filepath.Join(targetPath, "my-skill", "SKILL.md")
+	sb.WriteFile(copyFile, "# Edited in target")
+	sb.WriteFile(filepath.Join(skillDir, "SKILL.md"), "# New upstream")
+
+	result := sb.RunCLI("status")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "edited in target")
+
+	result = sb.RunCLI("diff")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "edited in target")
+
+	result = sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "--force")
+	if got := sb.ReadFile(copyFile); got != "# Edited in target" {
+		t.Errorf("local edit should be preserved, got %q", got)
+	}
+
+	sb.RunCLI("sync", "--force").AssertSuccess(t)
+	if got := sb.ReadFile(copyFile); got != "# New upstream" {
+		t.Errorf("force sync should overwrite local edit, got %q", got)
+	}
+}
+
+func TestSync_CopyMode_PrunesRemovedSkills(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	skillDir := sb.CreateSkill("old-skill", map[string]string{"SKILL.md": "# Old"})
+	targetPath := sb.CreateTarget("claude")
+	localDir := filepath.Join(targetPath, "my-local")
+	os.MkdirAll(localDir, 0755)
+	os.WriteFile(filepath.Join(localDir, "SKILL.md"), []byte("# Local"), 0644)
+	writeCopyModeConfig(sb, targetPath)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	os.RemoveAll(skillDir)
+
+	result := sb.RunCLI("sync")
+	result.AssertSuccess(t)
+
+	if sb.FileExists(filepath.Join(targetPath, "old-skill")) {
+		t.Error("copy of removed skill should be pruned")
+	}
+	if !sb.FileExists(filepath.Join(localDir, "SKILL.md")) {
+		t.Error("unmanaged local skill should be kept")
+	}
+}
+
+func TestSync_CopyMode_ReplacesMergeSymlinks(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	targetPath := sb.CreateTarget("claude")
+	os.Symlink(filepath.Join(sb.SourcePath, "my-skill"), filepath.Join(targetPath, "my-skill"))
+	writeCopyModeConfig(sb, targetPath)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	skillPath := filepath.Join(targetPath, "my-skill")
+	if sb.IsSymlink(skillPath) {
+		t.Error("merge symlink should be replaced by a copy")
+	}
+	if !sb.FileExists(filepath.Join(skillPath, "SKILL.md")) {
+		t.Error("copy should contain SKILL.md")
+	}
+}
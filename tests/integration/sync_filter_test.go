@@ -0,0 +1,132 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSync_TargetInclude_OnlyLinksMatchingSkills(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateNestedSkill("frontend/react", map[string]string{"SKILL.md": "# React"})
+	sb.CreateNestedSkill("frontend/css", map[string]string{"SKILL.md": "# CSS"})
+	sb.CreateSkill("git-commit", map[string]string{"SKILL.md": "# Git"})
+	cursorPath := sb.CreateTarget("cursor")
+	claudePath := sb.CreateTarget("claude")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + claudePath + `
+  cursor:
+    path: ` + cursorPath + `
+    include: [frontend]
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	for _, name := range []string{"frontend__react", "frontend__css"} {
+		if !sb.IsSymlink(filepath.Join(cursorPath, name)) {
+			t.Errorf("cursor should receive %s", name)
+		}
+	}
+	if sb.FileExists(filepath.Join(cursorPath, "git-commit")) {
+		t.Error("cursor should not receive git-commit")
+	}
+	if !sb.IsSymlink(filepath.Join(claudePath, "git-commit")) {
+		t.Error("claude has no filters and should receive git-commit")
+	}
+
+	result := sb.RunCLI("status")
+	result.AssertSuccess(t)
+	result.AssertOutputNotContains(t, "not synced")
+}
+
+func TestSync_TargetExclude_PrunesAlreadyLinkedSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("claude-hooks", map[string]string{"SKILL.md": "# Hooks"})
+	sb.CreateSkill("git-commit", map[string]string{"SKILL.md": "# Git"})
+	targetPath := sb.CreateTarget("codex")
+	cfg := `source: ` + sb.SourcePath + `
+targets:
+  codex:
+    path: ` + targetPath + `
+`
+	sb.WriteConfig(cfg)
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "claude-hooks")) {
+		t.Fatal("claude-hooks should be linked before the exclude is added")
+	}
+
+	sb.WriteConfig(cfg + `    exclude: ["claude-*"]
+`)
+
+	result := sb.RunCLI("diff")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "orphan link")
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if sb.FileExists(filepath.Join(targetPath, "claude-hooks")) {
+		t.Error("excluded skill link should be pruned")
+	}
+	if !sb.IsSymlink(filepath.Join(targetPath, "git-commit")) {
+		t.Error("git-commit should stay linked")
+	}
+}
+
+func TestSync_TargetFilters_CopyMode(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("keep", map[string]string{"SKILL.md": "# Keep"})
+	sb.CreateSkill("skip-me", map[string]string{"SKILL.md": "# Skip"})
+	targetPath := sb.CreateTarget("claude")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+    mode: copy
+    exclude: [skip-me]
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if !sb.FileExists(filepath.Join(targetPath, "keep", "SKILL.md")) {
+		t.Error("keep should be copied")
+	}
+	if sb.FileExists(filepath.Join(targetPath, "skip-me")) {
+		t.Error("skip-me should be excluded")
+	}
+}
+
+func TestSyncProject_TargetInclude(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	projectRoot := sb.SetupProjectDir("claude-code")
+
+	sb.CreateProjectSkill(projectRoot, "frontend-ui", map[string]string{"SKILL.md": "# UI"})
+	sb.CreateProjectSkill(projectRoot, "backend-api", map[string]string{"SKILL.md": "# API"})
+	sb.WriteProjectConfig(projectRoot, `targets:
+  - name: claude-code
+    include: ["frontend-*"]
+`)
+
+	sb.RunCLIInDir(projectRoot, "sync").AssertSuccess(t)
+
+	targetPath := filepath.Join(projectRoot, ".claude", "skills")
+	if !sb.IsSymlink(filepath.Join(targetPath, "frontend-ui")) {
+		t.Error("frontend-ui should be synced")
+	}
+	if sb.FileExists(filepath.Join(targetPath, "backend-api")) {
+		t.Error("backend-api should be filtered out")
+	}
+}
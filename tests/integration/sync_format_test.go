@@ -0,0 +1,113 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSyncFormat_CursorMDC(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("lint", map[string]string{
+		"SKILL.md": "---\nname: lint\ndescription: Run the linter\n---\n# Lint\n\nRun it.\n",
+	})
+	targetPath := sb.CreateTarget("cursor")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  cursor:
+    path: ` + targetPath + `
+    format: cursor-mdc
+`)
+
+	result := sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "generated cursor-mdc (1 new")
+
+	rule := filepath.Join(targetPath, "lint.mdc")
+	content := sb.ReadFile(rule)
+	if !strings.HasPrefix(content, "---\ndescription: Run the linter\n") || !strings.Contains(content, "Generated by skillshare") {
+		t.Fatalf("unexpected rule file:\n%s", content)
+	}
+	if sb.FileExists(filepath.Join(targetPath, "lint", "SKILL.md")) {
+		t.Error("skill folder should not be linked into a cursor-mdc target")
+	}
+
+	result = sb.RunCLI("status")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "[cursor-mdc]")
+
+	// Hand edits are kept until --force
+	os.WriteFile(rule, []byte(content+"my note\n"), 0644)
+	result = sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "written or edited by hand: lint.mdc")
+	if !strings.Contains(sb.ReadFile(rule), "my note") {
+		t.Fatal("sync overwrote a hand-edited file")
+	}
+	sb.RunCLI("sync", "--force").AssertSuccess(t)
+	if strings.Contains(sb.ReadFile(rule), "my note") {
+		t.Error("sync --force should regenerate the edited file")
+	}
+
+	// Removing the skill prunes its generated file
+	os.RemoveAll(filepath.Join(sb.SourcePath, "lint"))
+	sb.RunCLI("sync").AssertSuccess(t)
+	if sb.FileExists(rule) {
+		t.Error("generated file should be pruned after its skill is removed")
+	}
+}
+
+func TestSyncFormat_RegistryDefault(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("review", map[string]string{"SKILL.md": "# Review\n\nCheck the diff.\n"})
+	rulesPath := filepath.Join(sb.Home, ".augment", "rules")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  augment:
+    path: ` + rulesPath + `
+`)
+
+	result := sb.RunCLI("sync", "--dry-run")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Would generate "+filepath.Join(rulesPath, "review.md"))
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !strings.Contains(sb.ReadFile(filepath.Join(rulesPath, "review.md")), "Check the diff.") {
+		t.Error("augment should get a markdown rule file")
+	}
+
+	result = sb.RunCLI("diff")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "Fully synced (single-markdown format)")
+}
+
+func TestSyncFormat_RegistryDefaultSkippedInSymlinkMode(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("review", map[string]string{"SKILL.md": "# Review\n\nCheck the diff.\n"})
+	rulesPath := filepath.Join(sb.Home, ".augment", "rules")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: symlink
+targets:
+  augment:
+    path: ` + rulesPath + `
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(rulesPath) {
+		t.Error("augment should be linked to the source in symlink mode")
+	}
+	if sb.FileExists(filepath.Join(rulesPath, "review.md")) {
+		t.Error("symlink mode should not generate markdown rule files")
+	}
+}
@@ -0,0 +1,143 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSync_IgnorePatterns_SkipsMatchingSkills(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("keep-me", map[string]string{"SKILL.md": "# Keep"})
+	sb.CreateSkill("wip-parser", map[string]string{"SKILL.md": "# WIP"})
+	sb.CreateNestedSkill("drafts/idea", map[string]string{"SKILL.md": "# Idea"})
+	targetPath := sb.CreateTarget("claude")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+ignore:
+  - "wip-*"
+  - drafts/
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if !sb.IsSymlink(filepath.Join(targetPath, "keep-me")) {
+		t.Error("keep-me should be synced")
+	}
+	if sb.FileExists(filepath.Join(targetPath, "wip-parser")) {
+		t.Error("wip-parser should be ignored")
+	}
+	if sb.FileExists(filepath.Join(targetPath, "drafts__idea")) {
+		t.Error("drafts/idea should be ignored")
+	}
+}
+
+func TestSync_SkillshareIgnoreFile(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateNestedSkill("team/ui", map[string]string{"SKILL.md": "# UI"})
+	sb.CreateNestedSkill("team/scratch", map[string]string{"SKILL.md": "# Scratch"})
+	sb.WriteFile(filepath.Join(sb.SourcePath, "team", ".skillshareignore"), "scratch\n")
+	targetPath := sb.CreateTarget("claude")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if !sb.IsSymlink(filepath.Join(targetPath, "team__ui")) {
+		t.Error("team/ui should be synced")
+	}
+	if sb.FileExists(filepath.Join(targetPath, "team__scratch")) {
+		t.Error("team/scratch should be ignored via .skillshareignore")
+	}
+}
+
+func TestSync_IgnorePatterns_PrunesPreviouslySyncedSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("experimental", map[string]string{"SKILL.md": "# Experimental"})
+	targetPath := sb.CreateTarget("claude")
+	cfg := `source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`
+	sb.WriteConfig(cfg)
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "experimental")) {
+		t.Fatal("experimental should be synced before it is ignored")
+	}
+
+	sb.WriteConfig(cfg + "ignore:\n  - experimental\n")
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if sb.FileExists(filepath.Join(targetPath, "experimental")) {
+		t.Error("link to newly ignored skill should be pruned")
+	}
+}
+
+func TestList_Ignored_ShowsPatternAndOrigin(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("keep-me", map[string]string{"SKILL.md": "# Keep"})
+	sb.CreateSkill("wip-parser", map[string]string{"SKILL.md": "# WIP"})
+	sb.CreateNestedSkill("team/scratch", map[string]string{"SKILL.md": "# Scratch"})
+	sb.WriteFile(filepath.Join(sb.SourcePath, "team", ".skillshareignore"), "scratch\n")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+ignore:
+  - "wip-*"
+`)
+
+	result := sb.RunCLI("list")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "keep-me")
+	result.AssertOutputNotContains(t, "wip-parser")
+
+	result = sb.RunCLI("list", "--ignored")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "wip-parser")
+	result.AssertOutputContains(t, "wip-* (config)")
+	result.AssertOutputContains(t, "team/scratch")
+	result.AssertOutputContains(t, "team/.skillshareignore")
+	result.AssertOutputNotContains(t, "keep-me")
+}
+
+func TestListProject_Ignored(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	projectRoot := sb.SetupProjectDir("claude-code")
+
+	sb.CreateProjectSkill(projectRoot, "keep-me", map[string]string{"SKILL.md": "# Keep"})
+	sb.CreateProjectSkill(projectRoot, "draft-skill", map[string]string{"SKILL.md": "# Draft"})
+	sb.WriteProjectConfig(projectRoot, `targets:
+  - claude-code
+ignore:
+  - "draft-*"
+`)
+
+	result := sb.RunCLIInDir(projectRoot, "list", "-p")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "keep-me")
+	result.AssertOutputNotContains(t, "draft-skill")
+
+	result = sb.RunCLIInDir(projectRoot, "list", "-p", "--ignored")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "draft-skill")
+}
@@ -0,0 +1,60 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSyncIndex_Project(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	projectRoot := sb.SetupProjectDir("claude-code")
+	sb.CreateProjectSkill(projectRoot, "lint", map[string]string{
+		"SKILL.md": "---\nname: lint\ndescription: Run the linter\n---\n# Lint\n",
+	})
+	sb.WriteProjectConfig(projectRoot, `targets:
+  - name: claude-code
+    index: AGENTS.md
+`)
+	agents := filepath.Join(projectRoot, "AGENTS.md")
+	sb.WriteFile(agents, "# Project notes\n\nUse tabs.\n")
+
+	result := sb.RunCLIInDir(projectRoot, "diff", "-p")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "skill index: add index block")
+
+	result = sb.RunCLIInDir(projectRoot, "sync", "-p")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Skill index updated in")
+
+	content := sb.ReadFile(agents)
+	if !strings.HasPrefix(content, "# Project notes\n\nUse tabs.\n") {
+		t.Errorf("user content not kept:\n%s", content)
+	}
+	if !strings.Contains(content, "- **lint** — Run the linter (`.claude/skills/lint/SKILL.md`)") {
+		t.Errorf("skill missing from index:\n%s", content)
+	}
+
+	// Idempotent: nothing to do on the next run
+	result = sb.RunCLIInDir(projectRoot, "sync", "-p")
+	result.AssertSuccess(t)
+	if strings.Contains(result.Stdout, "Skill index updated") {
+		t.Error("second sync should leave the index alone")
+	}
+	if sb.ReadFile(agents) != content {
+		t.Error("index changed without skill changes")
+	}
+
+	sb.CreateProjectSkill(projectRoot, "review", map[string]string{"SKILL.md": "# Review\n"})
+	sb.RunCLIInDir(projectRoot, "sync", "-p").AssertSuccess(t)
+	if updated := sb.ReadFile(agents); !strings.Contains(updated, "- **review** (`.claude/skills/review/SKILL.md`)") ||
+		strings.Count(updated, "BEGIN SKILLSHARE INDEX") != 1 {
+		t.Errorf("index not updated in place:\n%s", updated)
+	}
+}
@@ -0,0 +1,90 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// writeLeftoverJournal leaves a journal as if sync had been killed right
+// after linking alpha.
+func writeLeftoverJournal(t *testing.T, sb *testutil.Sandbox, targetPath string) string {
+	t.Helper()
+	dir := filepath.Join(filepath.Dir(sb.ConfigPath), "journal")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(dir, "claude.json")
+	data := `{"target":"claude","path":"` + targetPath + `","started":"2026-01-01T00:00:00Z","entries":[` +
+		`{"op":"create","path":"` + filepath.Join(targetPath, "alpha") + `"}]}`
+	if err := os.WriteFile(journal, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(sb.SourcePath, "alpha"), filepath.Join(targetPath, "alpha")); err != nil {
+		t.Fatal(err)
+	}
+	return journal
+}
+
+func TestSyncJournal_DoctorRevertsInterruptedSync(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	journal := writeLeftoverJournal(t, sb, targetPath)
+
+	result := sb.RunCLI("sync")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "interrupted sync of claude")
+
+	result = sb.RunCLI("doctor")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "sync interrupted")
+	result.AssertOutputContains(t, "doctor --revert")
+
+	sb.RunCLI("doctor", "--revert").AssertSuccess(t)
+	if _, err := os.Lstat(filepath.Join(targetPath, "alpha")); !os.IsNotExist(err) {
+		t.Error("revert should remove the link the interrupted sync created")
+	}
+	if sb.FileExists(journal) {
+		t.Error("revert should remove the journal")
+	}
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "alpha")) {
+		t.Error("sync should work again after revert")
+	}
+}
+
+func TestSyncJournal_DoctorFinishResyncs(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	sb.CreateSkill("beta", map[string]string{"SKILL.md": "# Beta"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	journal := writeLeftoverJournal(t, sb, targetPath)
+
+	sb.RunCLI("doctor", "--finish").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "alpha")) || !sb.IsSymlink(filepath.Join(targetPath, "beta")) {
+		t.Error("finish should keep alpha and sync the rest")
+	}
+	if sb.FileExists(journal) {
+		t.Error("finish should remove the journal")
+	}
+}
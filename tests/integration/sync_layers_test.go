@@ -0,0 +1,98 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// setupLayers creates base and team source layers plus a claude target.
+func setupLayers(t *testing.T, sb *testutil.Sandbox) (base, team, targetPath string) {
+	t.Helper()
+	base = filepath.Join(sb.Root, "layers", "base")
+	team = filepath.Join(sb.Root, "layers", "team")
+	for _, dir := range []string{base, team} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sb.WriteFile(filepath.Join(base, "shared", "SKILL.md"), "# Base shared")
+	sb.WriteFile(filepath.Join(base, "base-only", "SKILL.md"), "# Base only")
+	sb.WriteFile(filepath.Join(team, "shared", "SKILL.md"), "# Team shared")
+	targetPath = sb.CreateTarget("claude")
+
+	sb.WriteConfig(`sources:
+  - ` + base + `
+  - ` + team + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	return base, team, targetPath
+}
+
+func TestSync_Layers_LinksWinningCopy(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	base, team, targetPath := setupLayers(t, sb)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	if got := sb.SymlinkTarget(filepath.Join(targetPath, "shared")); got != filepath.Join(team, "shared") {
+		t.Errorf("shared should link to the team layer, got %s", got)
+	}
+	if got := sb.SymlinkTarget(filepath.Join(targetPath, "base-only")); got != filepath.Join(base, "base-only") {
+		t.Errorf("base-only should link to the base layer, got %s", got)
+	}
+
+	// Removing the override falls back to the lower layer on the next sync
+	os.RemoveAll(filepath.Join(team, "shared"))
+	sb.RunCLI("sync").AssertSuccess(t)
+	if got := sb.SymlinkTarget(filepath.Join(targetPath, "shared")); got != filepath.Join(base, "shared") {
+		t.Errorf("shared should fall back to the base layer, got %s", got)
+	}
+}
+
+func TestStatusAndDoctor_Layers_ReportShadowed(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	_, _, _ = setupLayers(t, sb)
+
+	status := sb.RunCLI("status")
+	status.AssertSuccess(t)
+	status.AssertAnyOutputContains(t, "1 shadowed skill")
+	status.AssertAnyOutputContains(t, "shared")
+
+	doctor := sb.RunCLI("doctor")
+	doctor.AssertAnyOutputContains(t, "Shadowed: shared")
+}
+
+func TestInstallAndNew_Layer_WritesIntoChosenLayer(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	base, team, _ := setupLayers(t, sb)
+
+	local := filepath.Join(sb.Root, "local-skill")
+	sb.WriteFile(filepath.Join(local, "SKILL.md"), "---\nname: local-skill\n---\n# Local")
+
+	sb.RunCLI("install", local, "--layer", "base").AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(base, "local-skill", "SKILL.md")) {
+		t.Error("install --layer base should write into the base layer")
+	}
+
+	sb.RunCLI("new", "fresh-skill").AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(team, "fresh-skill", "SKILL.md")) {
+		t.Error("new without --layer should write into the last layer")
+	}
+
+	sb.RunCLI("new", "base-skill", "--layer", "1").AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(base, "base-skill", "SKILL.md")) {
+		t.Error("new --layer 1 should write into the first layer")
+	}
+
+	sb.RunCLI("new", "nowhere", "--layer", "missing").AssertFailure(t)
+}
@@ -0,0 +1,109 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSync_RelativeLinks_ProjectSurvivesMove(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("junctions are always absolute")
+	}
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	projectRoot := sb.SetupProjectDir("claude-code")
+	sb.CreateProjectSkill(projectRoot, "lint", map[string]string{"SKILL.md": "# Lint"})
+	sb.WriteProjectConfig(projectRoot, `link_style: relative
+targets:
+  - name: claude-code
+    mode: symlink
+`)
+
+	sb.RunCLIInDir(projectRoot, "sync", "-p").AssertSuccess(t)
+	if link := sb.SymlinkTarget(filepath.Join(projectRoot, ".claude", "skills")); link != filepath.Join("..", ".skillshare", "skills") {
+		t.Fatalf("link = %q, want relative link to .skillshare/skills", link)
+	}
+
+	moved := filepath.Join(sb.Root, "moved-project")
+	if err := os.Rename(projectRoot, moved); err != nil {
+		t.Fatal(err)
+	}
+	if !sb.FileExists(filepath.Join(moved, ".claude", "skills", "lint", "SKILL.md")) {
+		t.Error("relative link should still resolve after the project moves")
+	}
+	result := sb.RunCLIInDir(moved, "sync", "-p", "--dry-run")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "already linked")
+	result.AssertOutputNotContains(t, "conflict")
+}
+
+func TestSync_LinkStyle_RewritesExistingLinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("junctions are always absolute")
+	}
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	targetPath := sb.CreateTarget("claude")
+	cfg := `source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+    mode: merge
+`
+	sb.WriteConfig(cfg)
+	sb.RunCLI("sync").AssertSuccess(t)
+	linkPath := filepath.Join(targetPath, "my-skill")
+	if !filepath.IsAbs(sb.SymlinkTarget(linkPath)) {
+		t.Fatal("links should be absolute by default")
+	}
+
+	sb.WriteConfig(cfg + "link_style: relative\n")
+	result := sb.RunCLI("diff")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "rewrite as relative link")
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if link := sb.SymlinkTarget(linkPath); filepath.IsAbs(link) {
+		t.Errorf("link = %q, want relative after sync", link)
+	}
+	if !sb.FileExists(filepath.Join(linkPath, "SKILL.md")) {
+		t.Error("relative link should resolve to the skill")
+	}
+}
+
+func TestSync_LinkStyle_RewritesSymlinkTarget(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("junctions are always absolute")
+	}
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	targetPath := filepath.Join(sb.Root, "claude-skills")
+	cfg := `source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+    mode: symlink
+`
+	sb.WriteConfig(cfg)
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	sb.WriteConfig(cfg + "link_style: relative\n")
+	result := sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "link rewritten as relative")
+	result.AssertOutputNotContains(t, "conflict resolved")
+	if link := sb.SymlinkTarget(targetPath); filepath.IsAbs(link) {
+		t.Errorf("link = %q, want relative after sync", link)
+	}
+}
@@ -0,0 +1,118 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func writeMergeConfig(sb *testutil.Sandbox, targetPath, mode string) {
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+    mode: ` + mode + `
+`)
+}
+
+func TestSync_Manifest_PrunesOnlyOwnedEntries(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("keep", map[string]string{"SKILL.md": "# Keep"})
+	gone := sb.CreateSkill("gone", map[string]string{"SKILL.md": "# Gone"})
+	targetPath := sb.CreateTarget("claude")
+	writeMergeConfig(sb, targetPath, "merge")
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	manifest := sb.ReadFile(filepath.Join(targetPath, ".skillshare-manifest.json"))
+	if !strings.Contains(manifest, `"gone"`) || !strings.Contains(manifest, `"mode": "merge"`) {
+		t.Fatalf("manifest should list the links, got:\n%s", manifest)
+	}
+
+	// A user directory that merely looks like a nested skill is not ours
+	sb.WriteFile(filepath.Join(targetPath, "team__notes", "SKILL.md"), "# Mine")
+	os.RemoveAll(gone)
+
+	result := sb.RunCLI("sync")
+	result.AssertSuccess(t)
+	if sb.FileExists(filepath.Join(targetPath, "gone")) {
+		t.Error("link to removed skill should be pruned")
+	}
+	if !sb.FileExists(filepath.Join(targetPath, "team__notes", "SKILL.md")) {
+		t.Error("user directory with __ in its name must not be pruned")
+	}
+	if !sb.IsSymlink(filepath.Join(targetPath, "keep")) {
+		t.Error("keep should stay linked")
+	}
+}
+
+func TestSync_Manifest_MigratesTargetWithoutManifest(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	keep := sb.CreateSkill("keep", map[string]string{"SKILL.md": "# Keep"})
+	targetPath := sb.CreateTarget("claude")
+	writeMergeConfig(sb, targetPath, "merge")
+
+	// Left by a version that wrote no manifest
+	if err := os.Symlink(keep, filepath.Join(targetPath, "keep")); err != nil {
+		t.Fatal(err)
+	}
+	sb.WriteFile(filepath.Join(targetPath, "old__skill", "SKILL.md"), "# Old")
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if sb.FileExists(filepath.Join(targetPath, "old__skill")) {
+		t.Error("orphan nested directory should be pruned while migrating")
+	}
+	manifest := sb.ReadFile(filepath.Join(targetPath, ".skillshare-manifest.json"))
+	if !strings.Contains(manifest, `"keep"`) {
+		t.Errorf("existing link should be recorded in the manifest, got:\n%s", manifest)
+	}
+}
+
+func TestSync_Manifest_CopyToMergeReplacesOwnCopies(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("my-skill", map[string]string{"SKILL.md": "# My Skill"})
+	targetPath := sb.CreateTarget("claude")
+	writeMergeConfig(sb, targetPath, "copy")
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	writeMergeConfig(sb, targetPath, "merge")
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "my-skill")) {
+		t.Error("unchanged copy from copy mode should be replaced by a link without --force")
+	}
+}
+
+func TestUninstall_RemovesManagedEntries(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("del-me", map[string]string{"SKILL.md": "# Delete"})
+	sb.CreateSkill("other", map[string]string{"SKILL.md": "# Other"})
+	targetPath := sb.CreateTarget("claude")
+	writeMergeConfig(sb, targetPath, "merge")
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	result := sb.RunCLI("uninstall", "del-me", "--force")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Removed del-me from claude")
+
+	if _, err := os.Lstat(filepath.Join(targetPath, "del-me")); !os.IsNotExist(err) {
+		t.Error("link to the uninstalled skill should be removed from the target")
+	}
+	if !sb.IsSymlink(filepath.Join(targetPath, "other")) {
+		t.Error("other skills should stay linked")
+	}
+	if strings.Contains(sb.ReadFile(filepath.Join(targetPath, ".skillshare-manifest.json")), `"del-me"`) {
+		t.Error("manifest should no longer list the uninstalled skill")
+	}
+}
@@ -0,0 +1,84 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSyncNaming_LastSegmentWithAliasAndPrune(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateNestedSkill("_team/frontend/hooks", map[string]string{"SKILL.md": "# Hooks"})
+	sb.CreateNestedSkill("_team/backend/api", map[string]string{"SKILL.md": "# API"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "_team__frontend__hooks")) {
+		t.Fatal("default naming should use full flat names")
+	}
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+naming:
+  strategy: last
+  aliases:
+    _team/backend/api: backend-api
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	for _, name := range []string{"hooks", "backend-api"} {
+		if !sb.IsSymlink(filepath.Join(targetPath, name)) {
+			t.Errorf("expected %s to be linked", name)
+		}
+	}
+	for _, old := range []string{"_team__frontend__hooks", "_team__backend__api"} {
+		if _, err := os.Lstat(filepath.Join(targetPath, old)); !os.IsNotExist(err) {
+			t.Errorf("old link %s should be pruned", old)
+		}
+	}
+
+	result := sb.RunCLI("status")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "2 shared")
+}
+
+func TestSyncNaming_CollisionErrorAndSuffix(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateNestedSkill("frontend/utils", map[string]string{"SKILL.md": "# FE"})
+	sb.CreateNestedSkill("backend/utils", map[string]string{"SKILL.md": "# BE"})
+	targetPath := sb.CreateTarget("claude")
+	config := `source: ` + sb.SourcePath + `
+naming:
+  strategy: last
+targets:
+  claude:
+    path: ` + targetPath + `
+`
+	sb.WriteConfig(config)
+
+	result := sb.RunCLI("sync")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, `"utils" would be used by backend/utils, frontend/utils`)
+
+	sb.WriteConfig(config + "    naming:\n      strategy: last\n      collisions: suffix\n")
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "utils")) || !sb.IsSymlink(filepath.Join(targetPath, "utils-2")) {
+		t.Error("suffix collisions should link utils and utils-2")
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSync_Placement_LinksOnlyIntoNamedTargets(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("everywhere", map[string]string{"SKILL.md": "---\nname: everywhere\n---\n# Everywhere"})
+	review := sb.CreateSkill("review", map[string]string{"SKILL.md": "---\nname: review\ntargets: [claude]\n---\n# Review"})
+	claudePath := sb.CreateTarget("claude")
+	cursorPath := sb.CreateTarget("cursor")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + claudePath + `
+  cursor:
+    path: ` + cursorPath + `
+`)
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(claudePath, "review")) {
+		t.Error("review should be linked into claude")
+	}
+	if sb.FileExists(filepath.Join(cursorPath, "review")) {
+		t.Error("review should not be linked into cursor")
+	}
+	if !sb.IsSymlink(filepath.Join(cursorPath, "everywhere")) {
+		t.Error("everywhere should be linked into cursor")
+	}
+
+	result := sb.RunCLI("list", "--verbose")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "     claude\n")
+	result.AssertOutputContains(t, "     claude, cursor\n")
+
+	// Moving the skill to the other target prunes it from the first
+	sb.WriteFile(filepath.Join(review, "SKILL.md"), "---\nname: review\nexclude_targets:\n  - claude\n---\n# Review")
+	sb.RunCLI("sync").AssertSuccess(t)
+	if sb.FileExists(filepath.Join(claudePath, "review")) {
+		t.Error("review should be pruned from claude")
+	}
+	if !sb.IsSymlink(filepath.Join(cursorPath, "review")) {
+		t.Error("review should now be linked into cursor")
+	}
+}
+
+func TestSync_Placement_OtherOSSkipsSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("elsewhere", map[string]string{"SKILL.md": "---\nname: elsewhere\nos: [plan9]\n---\n# Elsewhere"})
+	targetPath := sb.CreateTarget("claude")
+	writeMergeConfig(sb, targetPath, "merge")
+
+	sb.RunCLI("sync").AssertSuccess(t)
+	if sb.FileExists(filepath.Join(targetPath, "elsewhere")) {
+		t.Error("skill for another OS should not be linked")
+	}
+}
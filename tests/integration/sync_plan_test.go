@@ -0,0 +1,95 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestSyncPlanJSON_ApplyAndStaleness(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	result := sb.RunCLI("sync", "--plan-json")
+	result.AssertSuccess(t)
+
+	var plan struct {
+		Version int `json:"version"`
+		Targets []struct {
+			Name    string `json:"name"`
+			Actions []struct {
+				Kind  string `json:"kind"`
+				Skill string `json:"skill"`
+			} `json:"actions"`
+		} `json:"targets"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &plan); err != nil {
+		t.Fatalf("plan is not JSON: %v\n%s", err, result.Stdout)
+	}
+	if len(plan.Targets) != 1 || len(plan.Targets[0].Actions) != 1 ||
+		plan.Targets[0].Actions[0].Kind != "create-link" || plan.Targets[0].Actions[0].Skill != "alpha" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if sb.FileExists(filepath.Join(targetPath, "alpha")) {
+		t.Fatal("--plan-json should not change the target")
+	}
+
+	planFile := filepath.Join(sb.Root, "plan.json")
+	if err := os.WriteFile(planFile, []byte(result.Stdout), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A source change makes the saved plan stale
+	sb.CreateSkill("beta", map[string]string{"SKILL.md": "# Beta"})
+	result = sb.RunCLI("sync", "--apply", planFile)
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "plan is stale")
+	if sb.FileExists(filepath.Join(targetPath, "alpha")) {
+		t.Fatal("stale plan should not be applied")
+	}
+
+	if err := os.RemoveAll(filepath.Join(sb.SourcePath, "beta")); err != nil {
+		t.Fatal(err)
+	}
+	sb.RunCLI("sync", "--apply", planFile).AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(targetPath, "alpha")) {
+		t.Error("applied plan should link alpha")
+	}
+}
+
+func TestSyncDryRun_PrintsPlannedActions(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	result := sb.RunCLI("sync", "--dry-run")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Would link")
+
+	// diff reads the same plan
+	sb.RunCLI("diff").AssertOutputContains(t, "alpha")
+	if sb.FileExists(filepath.Join(targetPath, "alpha")) {
+		t.Error("dry run should not link")
+	}
+}
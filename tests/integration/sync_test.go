@@ -5,6 +5,7 @@ package integration
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"skillshare/internal/testutil"
@@ -463,3 +464,31 @@ targets:
 		t.Error("local skill directory should be preserved")
 	}
 }
+
+func TestSync_JobsSyncsTargetsInOrder(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	names := []string{"claude", "codex", "cursor"}
+	config := "source: " + sb.SourcePath + "\ntargets:\n"
+	for _, name := range names {
+		config += "  " + name + ":\n    path: " + sb.CreateTarget(name) + "\n"
+	}
+	sb.WriteConfig(config)
+
+	result := sb.RunCLI("sync", "--jobs", "3")
+	result.AssertSuccess(t)
+	last := -1
+	for _, name := range names {
+		i := strings.Index(result.Stdout, name+": merged")
+		if i < 0 || i < last {
+			t.Errorf("expected targets reported in name order, got:\n%s", result.Stdout)
+		}
+		last = i
+	}
+
+	result = sb.RunCLI("sync", "--jobs", "0")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--jobs must be a positive number")
+}
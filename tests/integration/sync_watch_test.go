@@ -0,0 +1,106 @@
+//go:build !online
+
+package integration
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"skillshare/internal/testutil"
+)
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestSyncWatch_ResyncsChangedSkills(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sends SIGINT")
+	}
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("first", map[string]string{"SKILL.md": "# First"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	var out bytes.Buffer
+	cmd := exec.Command(sb.BinaryPath, "sync", "--watch", "--interval", "100ms")
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill() //nolint:errcheck
+
+	waitFor(t, "initial sync", func() bool { return sb.IsSymlink(filepath.Join(targetPath, "first")) })
+
+	sb.CreateSkill("second", map[string]string{"SKILL.md": "# Second"})
+	waitFor(t, "new skill to be linked", func() bool { return sb.IsSymlink(filepath.Join(targetPath, "second")) })
+
+	if err := os.RemoveAll(filepath.Join(sb.SourcePath, "first")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "removed skill to be pruned", func() bool {
+		_, err := os.Lstat(filepath.Join(targetPath, "first"))
+		return os.IsNotExist(err)
+	})
+
+	// The write lock is not held while idle
+	sb.SetEnv("SKILLSHARE_LOCK_TIMEOUT", "2s")
+	sb.RunCLI("backup").AssertSuccess(t)
+
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("watch exited with %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Stopped watching") {
+		t.Errorf("expected clean stop message, got:\n%s", out.String())
+	}
+
+	logData := sb.ReadFile(filepath.Join(filepath.Dir(sb.ConfigPath), "logs", "operations.log"))
+	if !strings.Contains(logData, `"watch":true`) || !strings.Contains(logData, `"second"`) {
+		t.Errorf("expected watch cycles in operations.log, got:\n%s", logData)
+	}
+}
+
+func TestSyncWatch_RejectsDryRun(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	result := sb.RunCLI("sync", "--watch", "--dry-run")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--watch cannot be combined with --dry-run")
+
+	result = sb.RunCLI("sync", "--interval", "1s")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--interval requires --watch")
+}
@@ -0,0 +1,103 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func writeUserTargets(sb *testutil.Sandbox, content string) string {
+	path := filepath.Join(filepath.Dir(sb.ConfigPath), "targets.yaml")
+	sb.WriteFile(path, content)
+	return path
+}
+
+func TestTargetRegistryList_ShowsOrigins(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	writeUserTargets(sb, `targets:
+  - name: in-house
+    global_path: ~/.in-house/skills
+  - name: claude
+    global_path: ~/.claude-fork/skills
+`)
+
+	result := sb.RunCLI("target", "registry", "list")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "in-house")
+	result.AssertOutputContains(t, "~/.claude-fork/skills")
+	result.AssertOutputContains(t, "targets.yaml")
+	result.AssertOutputContains(t, "builtin")
+}
+
+func TestTargetRegistry_InitDiscoverFindsCustomAgent(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	writeUserTargets(sb, `targets:
+  - name: in-house
+    global_path: ~/.in-house/skills
+`)
+	os.MkdirAll(filepath.Join(sb.Home, ".in-house"), 0755)
+
+	result := sb.RunCLI("init", "--discover", "--select", "in-house")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Added 1 agent")
+
+	configContent := sb.ReadFile(sb.ConfigPath)
+	if !strings.Contains(configContent, "in-house:") {
+		t.Errorf("config should contain in-house target, got: %s", configContent)
+	}
+}
+
+func TestTargetRegistry_ProjectFileAddsKnownTarget(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	projectRoot := sb.SetupProjectDir("claude-code")
+
+	sb.WriteFile(filepath.Join(projectRoot, ".skillshare", "targets.yaml"), `targets:
+  - name: team-agent
+    project_path: .team-agent/skills
+`)
+
+	result := sb.RunCLIInDir(projectRoot, "target", "add", "team-agent", "-p")
+	result.AssertSuccess(t)
+
+	result = sb.RunCLIInDir(projectRoot, "sync")
+	result.AssertSuccess(t)
+	if _, err := os.Stat(filepath.Join(projectRoot, ".team-agent", "skills")); err != nil {
+		t.Errorf("team-agent target dir should be created by sync: %v", err)
+	}
+}
+
+func TestTargetRegistry_InvalidEntryReportedByDoctor(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	writeUserTargets(sb, `targets:
+  - name: no-path
+`)
+
+	result := sb.RunCLI("doctor")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "no-path")
+
+	result = sb.RunCLI("target", "registry", "list")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "sets no path")
+}
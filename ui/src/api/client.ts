@@ -1,10 +1,18 @@
 const BASE = '/api';
 
+export interface FieldError {
+  field: string;
+  message: string;
+  line?: number;
+}
+
 export class ApiError extends Error {
   status: number;
-  constructor(status: number, message: string) {
+  fieldErrors: FieldError[];
+  constructor(status: number, message: string, fieldErrors: FieldError[] = []) {
     super(message);
     this.status = status;
+    this.fieldErrors = fieldErrors;
   }
 }
 
@@ -15,7 +23,7 @@ export async function apiFetch<T>(path: string, init?: RequestInit): Promise<T>
   });
   const data = await res.json();
   if (!res.ok) {
-    throw new ApiError(res.status, data.error ?? res.statusText);
+    throw new ApiError(res.status, data.error ?? res.statusText, data.errors ?? []);
   }
   return data as T;
 }
@@ -50,6 +58,12 @@ export const api = {
     }),
   diff: (target?: string) =>
     apiFetch<{ diffs: DiffTarget[] }>(`/diff${target ? '?target=' + encodeURIComponent(target) : ''}`),
+  getWatch: () => apiFetch<WatchStatus>('/watch'),
+  setWatch: (enabled: boolean) =>
+    apiFetch<WatchStatus>('/watch', {
+      method: 'PUT',
+      body: JSON.stringify({ enabled }),
+    }),
 
   // Hub
   hubIndex: () => apiFetch<HubIndex>('/hub/index'),
@@ -127,6 +141,14 @@ export const api = {
     }),
   availableTargets: () => apiFetch<{ targets: AvailableTarget[] }>('/config/available-targets'),
 
+  // Profiles
+  listProfiles: () => apiFetch<ProfilesResponse>('/profiles'),
+  switchProfile: (name: string) =>
+    apiFetch<{ success: boolean; active: string }>('/profiles/active', {
+      method: 'PUT',
+      body: JSON.stringify({ name }),
+    }),
+
   // Backups
   listBackups: () => apiFetch<BackupListResponse>('/backups'),
   createBackup: (target?: string) =>
@@ -211,6 +233,18 @@ export interface Overview {
   trackedRepos: TrackedRepo[];
   isProjectMode: boolean;
   projectRoot?: string;
+  profile?: string;
+}
+
+export interface Profile {
+  name: string;
+  inline: boolean;
+  file?: string;
+}
+
+export interface ProfilesResponse {
+  active: string;
+  profiles: Profile[];
 }
 
 export interface VersionCheck {
@@ -242,6 +276,9 @@ export interface Target {
   status: string;
   linkedCount: number;
   localCount: number;
+  expectedCount: number;
+  include?: string[];
+  exclude?: string[];
 }
 
 export interface SyncResult {
@@ -252,6 +289,15 @@ export interface SyncResult {
   pruned: string[];
 }
 
+export interface WatchStatus {
+  enabled: boolean;
+  since?: string;
+  cycles: number;
+  lastRun?: string;
+  lastChanged: string[];
+  lastError?: string;
+}
+
 export interface DiffTarget {
   target: string;
   items: { skill: string; action: string; reason?: string }[];
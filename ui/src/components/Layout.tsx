@@ -19,6 +19,7 @@ import {
 } from 'lucide-react';
 import { wobbly, shadows } from '../design';
 import { useAppContext } from '../context/AppContext';
+import ProfileSwitcher from './ProfileSwitcher';
 
 const allNavItems = [
   { to: '/', icon: LayoutDashboard, label: 'Dashboard' },
@@ -38,7 +39,7 @@ const allNavItems = [
 
 export default function Layout() {
   const [mobileOpen, setMobileOpen] = useState(false);
-  const { isProjectMode } = useAppContext();
+  const { isProjectMode, profile } = useAppContext();
 
   const navItems = useMemo(() => {
     if (isProjectMode) {
@@ -103,6 +104,7 @@ export default function Layout() {
               </span>
             )}
           </div>
+          {!isProjectMode && <ProfileSwitcher active={profile} />}
         </div>
 
         {/* Navigation */}
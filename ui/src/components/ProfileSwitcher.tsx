@@ -0,0 +1,47 @@
+import { useEffect, useState } from 'react';
+import { api } from '../api/client';
+import type { Profile } from '../api/client';
+import { wobbly } from '../design';
+
+export default function ProfileSwitcher({ active }: { active?: string }) {
+  const [profiles, setProfiles] = useState<Profile[]>([]);
+  const [switching, setSwitching] = useState(false);
+
+  useEffect(() => {
+    api.listProfiles().then((data) => setProfiles(data.profiles)).catch(() => {
+      // Profiles are optional; hide the switcher on error
+    });
+  }, []);
+
+  // Only the default profile exists
+  if (profiles.length <= 1) return null;
+
+  const handleChange = async (name: string) => {
+    setSwitching(true);
+    try {
+      await api.switchProfile(name);
+      window.location.reload();
+    } catch {
+      setSwitching(false);
+    }
+  };
+
+  return (
+    <label className="flex items-center gap-2 mt-2 text-sm text-pencil-light" style={{ fontFamily: 'var(--font-hand)' }}>
+      Profile
+      <select
+        value={active}
+        disabled={switching}
+        onChange={(e) => handleChange(e.target.value)}
+        className="flex-1 px-2 py-1 bg-white border-2 border-pencil text-pencil"
+        style={{ borderRadius: wobbly.sm }}
+      >
+        {profiles.map((p) => (
+          <option key={p.name} value={p.name}>
+            {p.name}
+          </option>
+        ))}
+      </select>
+    </label>
+  );
+}
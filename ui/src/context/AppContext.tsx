@@ -5,6 +5,7 @@ import { api } from '../api/client';
 interface AppContextValue {
   isProjectMode: boolean;
   projectRoot?: string;
+  profile?: string;
 }
 
 const AppContext = createContext<AppContextValue>({ isProjectMode: false });
@@ -21,6 +22,7 @@ export function AppProvider({ children }: { children: ReactNode }) {
       setValue({
         isProjectMode: data.isProjectMode,
         projectRoot: data.projectRoot,
+        profile: data.profile,
       });
     }).catch(() => {
       // Keep defaults on error
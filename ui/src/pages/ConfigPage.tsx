@@ -7,7 +7,7 @@ import Card from '../components/Card';
 import HandButton from '../components/HandButton';
 import { PageSkeleton } from '../components/Skeleton';
 import { useToast } from '../components/Toast';
-import { api } from '../api/client';
+import { api, ApiError, type FieldError } from '../api/client';
 import { useApi } from '../hooks/useApi';
 import { useAppContext } from '../context/AppContext';
 import { handTheme } from '../lib/codemirror-theme';
@@ -17,6 +17,7 @@ export default function ConfigPage() {
   const [raw, setRaw] = useState('');
   const [saving, setSaving] = useState(false);
   const [dirty, setDirty] = useState(false);
+  const [fieldErrors, setFieldErrors] = useState<FieldError[]>([]);
   const { toast } = useToast();
   const { isProjectMode } = useAppContext();
 
@@ -40,8 +41,10 @@ export default function ConfigPage() {
       await api.putConfig(raw);
       toast('Config saved successfully.', 'success');
       setDirty(false);
+      setFieldErrors([]);
       refetch();
     } catch (e: unknown) {
+      setFieldErrors(e instanceof ApiError ? e.fieldErrors : []);
       toast((e as Error).message, 'error');
     } finally {
       setSaving(false);
@@ -96,6 +99,24 @@ export default function ConfigPage() {
         </div>
       </div>
 
+      {fieldErrors.length > 0 && (
+        <Card variant="accent" className="mb-4">
+          <p className="text-danger font-bold mb-2" style={{ fontFamily: 'var(--font-heading)' }}>
+            Config not saved
+          </p>
+          <ul className="text-sm text-pencil space-y-1">
+            {fieldErrors.map((fe, i) => (
+              <li key={i}>
+                {fe.line ? <span className="text-pencil-light">line {fe.line}: </span> : null}
+                {fe.field && <code className="font-mono">{fe.field}</code>}
+                {fe.field ? ' — ' : ''}
+                {fe.message}
+              </li>
+            ))}
+          </ul>
+        </Card>
+      )}
+
       <Card decoration="tape">
         <div className="flex items-center gap-2 mb-3">
           <FileCode size={16} strokeWidth={2.5} className="text-blue" />
@@ -641,12 +641,11 @@ function SecurityAuditSection() {
 function TargetsHealthSection() {
   const { data, loading } = useApi(() => api.listTargets());
 
-  const sourceSkillCount = data?.sourceSkillCount ?? 0;
   const driftTargets = (data?.targets ?? []).filter(
-    (t) => t.mode === 'merge' && t.status === 'merged' && t.linkedCount < sourceSkillCount
+    (t) => t.mode === 'merge' && t.status === 'merged' && t.linkedCount < t.expectedCount
   );
   const maxDrift = driftTargets.reduce(
-    (max, t) => Math.max(max, sourceSkillCount - t.linkedCount),
+    (max, t) => Math.max(max, t.expectedCount - t.linkedCount),
     0
   );
 
@@ -679,7 +678,7 @@ function TargetsHealthSection() {
         <>
           <div className="grid grid-cols-1 sm:grid-cols-2 gap-3">
             {data.targets.map((t: TargetType) => {
-              const hasDrift = t.mode === 'merge' && t.status === 'merged' && t.linkedCount < sourceSkillCount;
+              const hasDrift = t.mode === 'merge' && t.status === 'merged' && t.linkedCount < t.expectedCount;
               return (
                 <Link key={t.name} to="/targets">
                   <div
@@ -698,7 +697,7 @@ function TargetsHealthSection() {
                     <div className="flex items-center gap-2 shrink-0 ml-2">
                       <StatusBadge status={t.status} />
                       {hasDrift ? (
-                        <Badge variant="warning">{t.linkedCount}/{sourceSkillCount} synced</Badge>
+                        <Badge variant="warning">{t.linkedCount}/{t.expectedCount} synced</Badge>
                       ) : t.linkedCount > 0 ? (
                         <span className="text-xs text-muted-dark">{t.linkedCount} linked</span>
                       ) : null}
@@ -16,6 +16,7 @@ import {
   Target,
   FileText,
   Info,
+  Radio,
 } from 'lucide-react';
 import Card from '../components/Card';
 import Badge from '../components/Badge';
@@ -35,6 +36,17 @@ export default function SyncPage() {
   const { toast } = useToast();
 
   const diff = useApi(() => api.diff());
+  const watch = useApi(() => api.getWatch());
+
+  const handleWatchToggle = async (enabled: boolean) => {
+    try {
+      await api.setWatch(enabled);
+      toast(enabled ? 'Watching source for changes.' : 'Stopped watching source.', 'info');
+      watch.refetch();
+    } catch (e: unknown) {
+      toast((e as Error).message, 'error');
+    }
+  };
 
   const handleSync = async () => {
     setSyncing(true);
@@ -248,6 +260,24 @@ export default function SyncPage() {
                 <Zap size={16} strokeWidth={2.5} className="text-accent" />
                 <span style={{ fontFamily: 'var(--font-hand)' }}>Force</span>
               </label>
+
+              <label
+                className="flex items-center gap-2 text-base cursor-pointer select-none"
+                title="Resync changed skills automatically, like 'skillshare sync --watch'"
+              >
+                <input
+                  type="checkbox"
+                  checked={watch.data?.enabled ?? false}
+                  disabled={watch.loading}
+                  onChange={(e) => handleWatchToggle(e.target.checked)}
+                  className="w-4 h-4 accent-success"
+                />
+                <Radio size={16} strokeWidth={2.5} className="text-success" />
+                <span style={{ fontFamily: 'var(--font-hand)' }}>
+                  Watch
+                  {watch.data?.enabled && watch.data.cycles > 0 && ` (${watch.data.cycles} resyncs)`}
+                </span>
+              </label>
             </div>
           )}
         </div>
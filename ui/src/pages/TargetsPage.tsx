@@ -52,7 +52,6 @@ export default function TargetsPage() {
   }
 
   const targets = data?.targets ?? [];
-  const sourceSkillCount = data?.sourceSkillCount ?? 0;
 
   const handleAdd = async () => {
     if (!newTarget.name) return;
@@ -379,13 +378,13 @@ export default function TargetsPage() {
                     {shortenHome(target.path)}
                   </p>
                   {target.mode === 'merge' && (() => {
-                    const hasDrift = target.status === 'merged' && target.linkedCount < sourceSkillCount;
+                    const hasDrift = target.status === 'merged' && target.linkedCount < target.expectedCount;
                     return (
                       <p className={`text-sm mt-1 ${hasDrift ? 'text-warning' : 'text-muted-dark'}`}>
                         {hasDrift ? (
                           <span className="flex items-center gap-1">
                             <AlertTriangle size={12} strokeWidth={2.5} />
-                            {target.linkedCount}/{sourceSkillCount} shared, {target.localCount} local
+                            {target.linkedCount}/{target.expectedCount} shared, {target.localCount} local
                           </span>
                         ) : (
                           <>{target.linkedCount} shared, {target.localCount} local</>